@@ -24,9 +24,35 @@ type Cli struct {
 func (c *Cli) Init() *Cli {
 	c.workspace = gentee.New()
 	c.args.Parse()
+	c.workspace.NoOptimize = c.args.NoOptimize
+	if len(c.args.Tags) > 0 {
+		c.workspace.Tags = strings.Split(c.args.Tags, `,`)
+	}
+	for _, def := range c.args.Defines {
+		pair := strings.SplitN(def, `=`, 2)
+		if len(pair) == 1 {
+			pair = append(pair, `true`)
+		}
+		if err := c.workspace.Define(strings.TrimSpace(pair[0]), pair[1]); err != nil {
+			fmt.Fprintf(os.Stderr, "%s", err)
+			os.Exit(errCompile)
+		}
+	}
 	return c
 }
 
+// defineList is a list of -D NAME=value options
+type defineList []string
+
+func (d *defineList) String() string {
+	return strings.Join(*d, ` `)
+}
+
+func (d *defineList) Set(value string) error {
+	*d = append(*d, value)
+	return nil
+}
+
 type CommandArgs struct {
 	Env      string
 	TestMode bool
@@ -34,6 +60,14 @@ type CommandArgs struct {
 
 	Execute string
 	Stdin   bool
+
+	Tags    string
+	Defines defineList
+
+	MaxThreads int64
+	NoOptimize bool
+	DryRun     bool
+	Audit      string
 }
 
 func (c *CommandArgs) Parse() *CommandArgs {
@@ -42,6 +76,12 @@ func (c *CommandArgs) Parse() *CommandArgs {
 	flag.BoolVar(&c.Ver, "ver", false, "print version")
 	flag.StringVar(&c.Execute, "e", "", "Execute the string")
 	flag.BoolVar(&c.Stdin, "p", false, "read from stdin")
+	flag.StringVar(&c.Tags, "tags", "", "comma-separated list of tags for #if tag(name)")
+	flag.Var(&c.Defines, "D", "define compile-time constant NAME=value")
+	flag.Int64Var(&c.MaxThreads, "threads", 0, "maximum count of running threads")
+	flag.BoolVar(&c.NoOptimize, "noopt", false, "disable the bytecode optimizer")
+	flag.BoolVar(&c.DryRun, "n", false, "dry run, print the side effects instead of executing them")
+	flag.StringVar(&c.Audit, "audit", "", "append the audit log of the side effects to the file")
 	flag.Parse()
 	c.Completion()
 	return c
@@ -55,11 +95,13 @@ func (c *CommandArgs) Completion() {
 	//cmd := complete.FlagSet(flag.CommandLine)
 	cmd := &complete.Command{
 		Flags: map[string]complete.Predictor{
-			"env": envName,
-			"t":   predict.Nothing,
-			"ver": predict.Nothing,
-			"e":   predict.Nothing,
-			"p":   predict.Nothing,
+			"env":  envName,
+			"t":    predict.Nothing,
+			"ver":  predict.Nothing,
+			"e":    predict.Nothing,
+			"p":    predict.Nothing,
+			"tags": predict.Nothing,
+			"D":    predict.Nothing,
 		},
 		Args: predict.Files("*.*"),
 	}
@@ -99,6 +141,15 @@ func (c *Cli) exec_Ver() {
 	fmt.Print(gentee.Version())
 }
 
+// printWarnings prints the warnings of the compiled units
+func (c *Cli) printWarnings() {
+	for _, unit := range c.workspace.Units {
+		for _, warning := range unit.Warnings {
+			fmt.Fprintf(os.Stderr, "WARNING: %s\n", warning)
+		}
+	}
+}
+
 func (c *Cli) exec_RunString(w io.Writer, str string) error {
 	params := flag.Args()
 	var (
@@ -112,8 +163,23 @@ func (c *Cli) exec_RunString(w io.Writer, str string) error {
 	if err != nil {
 		return codedError(err, errCompile)
 	}
+	c.printWarnings()
 	settings.CmdLine = params
+	settings.MaxThreads = c.args.MaxThreads
+	settings.DryRun = c.args.DryRun
+	var audit *gentee.AuditLog
+	if len(c.args.Audit) > 0 {
+		if audit, err = gentee.NewAuditLog(c.args.Audit); err != nil {
+			return codedError(err, errRun)
+		}
+		settings.AuditHandle = audit.Handle
+	}
 	result, err = exec.Run(settings)
+	if audit != nil {
+		if errAudit := audit.Close(); err == nil {
+			err = errAudit
+		}
+	}
 	if err != nil {
 		return codedError(err, errRun)
 	}
@@ -162,8 +228,23 @@ func (c *Cli) exec_RunFile(w io.Writer) error {
 	if err != nil {
 		return codedError(err, errCompile)
 	}
+	c.printWarnings()
 	settings.CmdLine = params
+	settings.MaxThreads = c.args.MaxThreads
+	settings.DryRun = c.args.DryRun
+	var audit *gentee.AuditLog
+	if len(c.args.Audit) > 0 {
+		if audit, err = gentee.NewAuditLog(c.args.Audit); err != nil {
+			return codedError(err, errRun)
+		}
+		settings.AuditHandle = audit.Handle
+	}
 	result, err = exec.Run(settings)
+	if audit != nil {
+		if errAudit := audit.Close(); err == nil {
+			err = errAudit
+		}
+	}
 	if err != nil {
 		return codedError(err, errRun)
 	}
@@ -0,0 +1,139 @@
+// Copyright 2019 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package compiler
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/gentee/gentee/core"
+	"github.com/gentee/gentee/vm"
+)
+
+func isChan(itype *core.TypeObject) bool {
+	return itype != nil && itype.Original == reflect.TypeOf(core.Chan{})
+}
+
+// chanSuffix returns the suffix of embedded channel funcs for the type of values
+func chanSuffix(itype *core.TypeObject) string {
+	switch itype.Original {
+	case reflect.TypeOf(int64(0)), reflect.TypeOf(true), reflect.TypeOf('a'):
+		return core.ChanSuffixes[0]
+	case reflect.TypeOf(float64(0.0)):
+		return core.ChanSuffixes[1]
+	case reflect.TypeOf(``):
+		return core.ChanSuffixes[2]
+	}
+	return core.ChanSuffixes[3]
+}
+
+// chanEmbed returns the embedded channel func for the type of values
+func chanEmbed(cmpl *compiler, name string, itype *core.TypeObject) core.IObject {
+	return cmpl.ws.StdLib().FindObj(name + chanSuffix(itype))
+}
+
+// chanFunc returns Send or Receive func for the channel and the type of the result
+func chanFunc(cmpl *compiler, name string, params []*core.TypeObject) (core.IObject,
+	*core.TypeObject) {
+	if len(params) == 0 || !isChan(params[0]) || params[0].IndexOf == nil {
+		return nil, nil
+	}
+	valType := params[0].IndexOf
+	switch name {
+	case `Send`:
+		if len(params) == 2 && isEqualTypes(valType, params[1]) {
+			return chanEmbed(cmpl, core.DefSendChan, valType), nil
+		}
+	case `Receive`:
+		if len(params) == 1 {
+			return chanEmbed(cmpl, core.DefReceiveChan, valType), valType
+		}
+	}
+	return nil, nil
+}
+
+// chanFor appends the commands for receiving values in for statement
+func chanFor(cmpl *compiler, cmd *core.CmdBlock) {
+	cmd.Children = append(cmd.Children, &core.CmdAnyFunc{
+		CmdCommon: core.CmdCommon{TokenID: cmd.TokenID},
+		Object:    cmpl.ws.StdLib().FindObj(core.DefNextChan),
+		Result:    cmpl.unit.FindType(`bool`).(*core.TypeObject),
+	}, &core.CmdAnyFunc{
+		CmdCommon: core.CmdCommon{TokenID: cmd.TokenID},
+		Object:    chanEmbed(cmpl, core.DefReceivedChan, cmd.Vars[0]),
+		Result:    cmd.Vars[0],
+	})
+}
+
+func isChanEmbed(icmd core.ICmd, name string) bool {
+	return icmd.GetType() == core.CtFunc && icmd.GetObject() != nil &&
+		strings.HasPrefix(icmd.GetObject().GetName(), name)
+}
+
+func coSelect(cmpl *compiler) error {
+	cmd := core.CmdBlock{ID: core.StackSelect, CmdCommon: core.CmdCommon{TokenID: uint32(cmpl.pos)}}
+	intType := cmpl.getIntType()
+	cmd.Children = []core.ICmd{&core.CmdAnyFunc{
+		CmdCommon: core.CmdCommon{TokenID: uint32(cmpl.pos)},
+		Object:    cmpl.ws.StdLib().FindObj(core.DefSelectChan),
+		Result:    intType,
+		Children: []core.ICmd{&core.CmdValue{Value: int64(0), Result: intType,
+			CmdCommon: core.CmdCommon{TokenID: uint32(cmpl.pos)}}},
+	}}
+	appendCmd(cmpl, &cmd)
+	cmpl.owners = append(cmpl.owners, &cmd)
+	return nil
+}
+
+func coSelectBack(cmpl *compiler) error {
+	cmd := cmpl.curOwner()
+	if last := cmd.Children[len(cmd.Children)-1].(*core.CmdBlock); last.ID == core.StackDefault {
+		selCall := cmd.Children[0].(*core.CmdAnyFunc)
+		selCall.Children[0].(*core.CmdValue).Value = int64(1)
+	}
+	cmpl.owners = cmpl.owners[:len(cmpl.owners)-1]
+	return nil
+}
+
+// selectCase replaces the channel operation of the case with the number of the case.
+// It returns the assignment of the received value if it is required.
+func selectCase(cmpl *compiler, cmd *core.CmdBlock) (core.ICmd, error) {
+	var (
+		assign *core.CmdBlock
+		kind   int64
+	)
+	if len(cmd.Children) != 1 {
+		return nil, cmpl.ErrorPos(cmd.Children[1].GetToken(), ErrSelectCase)
+	}
+	icmd := cmd.Children[0]
+	if icmd.GetType() == core.CtStack && icmd.(*core.CmdBlock).ID == core.StackAssign &&
+		cmpl.unit.Lexeme.Tokens[icmd.GetToken()].Type == tkAssign {
+		assign = icmd.(*core.CmdBlock)
+		icmd = assign.Children[1]
+		if !isChanEmbed(icmd, core.DefReceiveChan) {
+			return nil, cmpl.ErrorPos(icmd.GetToken(), ErrSelectCase)
+		}
+	} else if isChanEmbed(icmd, core.DefSendChan) {
+		kind = vm.ChanSend
+	} else if !isChanEmbed(icmd, core.DefReceiveChan) {
+		return nil, cmpl.ErrorPos(icmd.GetToken(), ErrSelectCase)
+	}
+	intType := cmpl.getIntType()
+	selCall := cmd.Parent.Children[0].(*core.CmdAnyFunc)
+	selCall.Children = append(selCall.Children, &core.CmdValue{Value: kind, Result: intType,
+		CmdCommon: core.CmdCommon{TokenID: uint32(icmd.GetToken())}})
+	selCall.Children = append(selCall.Children, icmd.(*core.CmdAnyFunc).Children...)
+	cmd.Children[0] = &core.CmdValue{Value: int64(len(cmd.Parent.Children) - 1), Result: intType,
+		CmdCommon: core.CmdCommon{TokenID: uint32(icmd.GetToken())}}
+	if assign == nil {
+		return nil, nil
+	}
+	assign.Children[1] = &core.CmdAnyFunc{
+		CmdCommon: core.CmdCommon{TokenID: uint32(icmd.GetToken())},
+		Object:    chanEmbed(cmpl, core.DefReceivedChan, icmd.GetResult()),
+		Result:    icmd.GetResult(),
+	}
+	return assign, nil
+}
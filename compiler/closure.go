@@ -0,0 +1,226 @@
+// Copyright 2019 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package compiler
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gentee/gentee/core"
+)
+
+func coClosure(cmpl *compiler) error {
+	lp := cmpl.unit.Lexeme
+	if len(lp.Tokens) == cmpl.pos+1 || (lp.Tokens[cmpl.pos+1].Type != tkLPar &&
+		lp.Tokens[cmpl.pos+1].Type != tkLCurly) {
+		return cmpl.Error(ErrValue)
+	}
+	if len(cmpl.owners) == 0 || cmpl.owners[0].(*core.CmdBlock).Object == nil {
+		return cmpl.Error(ErrClosure)
+	}
+	newClosure(cmpl)
+	return nil
+}
+
+// newClosure starts a new function literal in the current block
+func newClosure(cmpl *compiler) {
+	outer := cmpl.curOwner()
+	newFunc(cmpl, goExpPush(cmpl))
+	closure := &cmpl.goStack[len(cmpl.goStack)-1]
+	closure.Closure = &cmpl.latestFunc().Block
+	closure.Outer = outer
+}
+
+func coClosureRetType(cmpl *compiler) error {
+	obj, err := getType(cmpl)
+	if err != nil {
+		return err
+	}
+	cmpl.latestFunc().Block.Result = obj.(*core.TypeObject)
+	return coClosureStart(cmpl)
+}
+
+func coClosureStart(cmpl *compiler) error {
+	block := &cmpl.latestFunc().Block
+	if block.Variadic {
+		return cmpl.Error(ErrFnVariadic)
+	}
+	block.ParCount = len(block.Vars)
+	closure := &cmpl.goStack[len(cmpl.goStack)-1]
+	names := make([]string, len(block.Vars))
+	for i, par := range block.Vars {
+		names[i] = par.GetName()
+	}
+	name := `fn(` + strings.Join(names, `, `) + `)`
+	if block.Result != nil {
+		name += ` ` + block.Result.GetName()
+	}
+	closure.Type = &core.TypeObject{
+		Object: core.Object{
+			Name: name,
+			Unit: cmpl.unit,
+		},
+		Original: reflect.TypeOf(core.Fn{}),
+		Func: &core.FnType{
+			Params: append([]*core.TypeObject{}, block.Vars...),
+			Result: block.Result,
+		},
+	}
+	// captured variables are appended to the parameters so the body has own block
+	body := &core.CmdBlock{ID: core.StackBlock, Parent: block,
+		CmdCommon: core.CmdCommon{TokenID: uint32(cmpl.pos)}}
+	block.Children = append(block.Children, body)
+	cmpl.owners = append(cmpl.owners, body)
+	return nil
+}
+
+// closureEnd finishes the function literal and returns its value
+func closureEnd(cmpl *compiler) (*core.CmdValue, error) {
+	closure := cmpl.goStack[len(cmpl.goStack)-1]
+	funcObj := cmpl.latestFunc()
+	body := cmpl.curOwner()
+	if funcObj.Block.Result != nil {
+		if len(body.Children) == 0 {
+			return nil, cmpl.Error(ErrMustReturn)
+		}
+		last := body.Children[len(body.Children)-1]
+		if last.GetType() != core.CtStack ||
+			last.(*core.CmdBlock).ID != core.StackReturn {
+			return nil, cmpl.Error(ErrMustReturn)
+		}
+	}
+	funcObj.Block.ParCount = len(funcObj.Block.Vars)
+	cmpl.owners = cmpl.owners[:len(cmpl.owners)-2]
+	goExpPop(cmpl)
+	return &core.CmdValue{Value: &core.Fn{Func: funcObj, Captures: closure.Captures},
+		CmdCommon: core.CmdCommon{TokenID: funcObj.Block.TokenID},
+		Result:    closure.Type}, nil
+}
+
+func coClosureBack(cmpl *compiler) error {
+	value, err := closureEnd(cmpl)
+	if err != nil {
+		return err
+	}
+	appendExp(cmpl, value)
+	// the function literal is an operand so we continue the expression
+	states := *cmpl.states
+	states[len(states)-1].Origin = &cmState{tkToken, cmExpOper, nil, nil, cfStopBack}
+	cmpl.dynamic = &cmState{tkToken, cmExpOper, nil, nil, 0}
+	return nil
+}
+
+// isValueType returns true if the variable of this type is passed by value
+func isValueType(itype *core.TypeObject) bool {
+	switch itype.Original {
+	case reflect.TypeOf(int64(0)), reflect.TypeOf(float64(0.0)), reflect.TypeOf(true),
+		reflect.TypeOf('a'), reflect.TypeOf(``):
+		return true
+	}
+	return false
+}
+
+// captureVar looks for the variable in the outer blocks of the function literal.
+// The found variable is added to the function as a hidden parameter. The variables of int,
+// float, bool, char and str types are moved to heap cells so the outer block and
+// the function literal share them.
+func captureVar(cmpl *compiler, block *core.CmdBlock, token string) (*core.CmdBlock, int) {
+	for i := len(cmpl.goStack) - 1; i >= 0; i-- {
+		closure := &cmpl.goStack[i]
+		if closure.Closure != block {
+			continue
+		}
+		outer, ind := findVarBlock(cmpl, closure.Outer, token)
+		if outer == nil {
+			break
+		}
+		if block.VarNames == nil {
+			block.VarNames = make(map[string]int)
+		}
+		block.VarNames[token] = len(block.Vars)
+		block.Vars = append(block.Vars, outer.Vars[ind])
+		if isValueType(outer.Vars[ind]) {
+			if outer.Heap == nil {
+				outer.Heap = make(map[int]bool)
+			}
+			if _, ok := outer.Heap[ind]; !ok {
+				outer.Heap[ind] = false
+			}
+			if block.Heap == nil {
+				block.Heap = make(map[int]bool)
+			}
+			block.Heap[len(block.Vars)-1] = true
+		}
+		closure.Captures = append(closure.Captures, &core.CmdVar{Block: outer, Index: ind,
+			CmdCommon: core.CmdCommon{TokenID: uint32(cmpl.pos)}})
+		return block, len(block.Vars) - 1
+	}
+	return nil, 0
+}
+
+// checkCaptureAssign returns an error if the body of parallel for assigns the captured
+// variable. Each thread of parallel for has own copies of the captured variables.
+func checkCaptureAssign(cmpl *compiler, cmd core.ICmd, pos int) error {
+	cmdVar, ok := cmd.(*core.CmdVar)
+	if !ok {
+		return nil
+	}
+	block, index := cmdVar.Block, cmdVar.Index
+	for i := len(cmpl.goStack) - 1; i >= 0; i-- {
+		closure := &cmpl.goStack[i]
+		if closure.Closure != block || closure.Type == nil {
+			continue
+		}
+		params := len(closure.Type.Func.Params)
+		if index < params {
+			break
+		}
+		if closure.Parallel != nil {
+			return cmpl.ErrorPos(pos, ErrParallelVar, getToken(cmpl.unit.Lexeme, int(cmdVar.TokenID)))
+		}
+		// the variable can be captured by the outer function literal
+		capture := closure.Captures[index-params].(*core.CmdVar)
+		block, index = capture.Block, capture.Index
+	}
+	return nil
+}
+
+func isClosureType(itype *core.TypeObject) bool {
+	return itype != nil && itype.Func != nil && strings.HasPrefix(itype.Name, `fn(`)
+}
+
+// getClosureFunc looks for the function replacing the types of function literals with
+// the declared fn types which have the same parameters and the result.
+func getClosureFunc(cmpl *compiler, name string, params []*core.TypeObject, start int) core.IObject {
+	for i := start; i < len(params); i++ {
+		if !isClosureType(params[i]) {
+			continue
+		}
+		fnTypes := make([]string, 0)
+		for key := range cmpl.unit.NameSpace {
+			if !strings.HasPrefix(key, `@`) {
+				continue
+			}
+			itype, ok := cmpl.unit.FindObj(key).(*core.TypeObject)
+			if ok && itype.Func != nil && isEqualTypes(itype, params[i]) {
+				fnTypes = append(fnTypes, key[1:])
+			}
+		}
+		sort.Strings(fnTypes)
+		for _, fnType := range fnTypes {
+			pars := append([]*core.TypeObject{}, params...)
+			pars[i] = cmpl.unit.FindType(fnType).(*core.TypeObject)
+			if obj, variadic := cmpl.unit.FindFunc(name, pars); !variadic {
+				return obj
+			}
+			if obj := getClosureFunc(cmpl, name, pars, i+1); obj != nil {
+				return obj
+			}
+		}
+		break
+	}
+	return nil
+}
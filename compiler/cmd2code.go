@@ -20,30 +20,54 @@ func cmd2Code(linker *Linker, cmd core.ICmd, out *core.Bytecode) {
 	push := func(pars ...core.Bcode) {
 		out.Code = append(out.Code, pars...)
 	}
+	if linker.Optimize {
+		if value := foldConst(cmd); value != nil {
+			cmd = value
+		}
+	}
+	// getIndex pushes the command with the variable. CLOSURE command pushes the heap cell of
+	// the captured variable.
 	getIndex := func(cmdVar *core.CmdVar, command core.Bcode) {
 		var (
-			shift  int
-			locOut bool
+			shift, blockShift, index int
+			locOut                   bool
 		)
 		block := cmdVar.Block
-		for shift = len(linker.Blocks) - 1; shift >= 0; shift-- {
-			if linker.Blocks[shift].Block == block {
-				break
-			}
-			if linker.Blocks[shift].IsLocal {
-				locOut = true
-			}
-		}
 		for i := len(cmdVar.Indexes) - 1; i >= 0; i-- {
 			cmd2Code(linker, cmdVar.Indexes[i].Cmd, out)
 		}
 		inType := int(type2Code(block.Vars[cmdVar.Index], out))
-		blockShift := len(linker.Blocks) - 1 - shift
-		if locOut {
-			blockShift = 0x0f00 + shift
+		if varObj, ok := block.Object.(*core.VarObject); ok {
+			blockShift = core.GlobalShift
+			index = int(varObj.ObjID)
+			usedGlobal(varObj, out)
+		} else {
+			for shift = len(linker.Blocks) - 1; shift >= 0; shift-- {
+				if linker.Blocks[shift].Block == block {
+					break
+				}
+				if linker.Blocks[shift].IsLocal {
+					locOut = true
+				}
+			}
+			blockShift = len(linker.Blocks) - 1 - shift
+			if locOut {
+				blockShift = 0x0f00 + shift
+			}
+			index = linker.Blocks[shift].Vars[cmdVar.Index]
+			if cell, ok := linker.Blocks[shift].Cells[cmdVar.Index]; ok {
+				index = cell
+				if command == core.CLOSURE {
+					inType = core.TYPEBOX
+				} else {
+					blockShift |= core.BoxShift
+				}
+			}
+		}
+		if command == core.CLOSURE {
+			command = core.GETVAR
 		}
-		push(core.Bcode(blockShift<<16)|command,
-			core.Bcode(inType<<16|linker.Blocks[shift].Vars[cmdVar.Index]))
+		push(core.Bcode(blockShift<<16)|command, core.Bcode(inType<<16|index))
 		if inType >= core.TYPESTRUCT {
 			structOffset(out, -len(out.Code)+1)
 		}
@@ -98,7 +122,11 @@ func cmd2Code(linker *Linker, cmd core.ICmd, out *core.Bytecode) {
 			anyFunc := cmd.(*core.CmdAnyFunc)
 			if anyFunc.IsThread {
 				block := obj.(*core.FuncObject).Block
-				push(core.Bcode(block.ParCount<<16)|core.GOBYID, core.Bcode(id))
+				code := core.Bcode(block.ParCount<<16) | core.GOBYID
+				if isFuture(anyFunc.Result) {
+					code |= 0x8000
+				}
+				push(code, core.Bcode(id))
 				for k := 0; k < block.ParCount; k++ {
 					ptype := type2Code(block.Vars[k], out)
 					push(core.Bcode(ptype))
@@ -109,14 +137,7 @@ func cmd2Code(linker *Linker, cmd core.ICmd, out *core.Bytecode) {
 			} else {
 				push(core.Bcode(count<<16)|core.CALLBYID, core.Bcode(id))
 			}
-			if out.Used == nil {
-				out.Used = make(map[int32]byte)
-			}
-			if out.Used[id] == 0 {
-				genBytecode(obj.(*core.FuncObject).Unit.VM, id)
-				copyUsed(&obj.(*core.FuncObject).BCode, out)
-				out.Used[id] = 1
-			}
+			usedFunc(obj.(*core.FuncObject), out)
 		case core.ObjConst:
 			id := obj.(*core.ConstObject).ObjID
 			push(core.CONSTBYID, core.Bcode(id))
@@ -246,7 +267,22 @@ func cmd2Code(linker *Linker, cmd core.ICmd, out *core.Bytecode) {
 			push(core.Bcode(uint32(id)<<16) | core.PUSHSTR)
 		case *core.Fn:
 			id := v.Func.(*core.FuncObject).ObjID
-			push(core.PUSHFUNC, core.Bcode(id))
+			if len(v.Captures) > 0 {
+				types := make([]core.Bcode, len(v.Captures))
+				for i, capture := range v.Captures {
+					cmdVar := capture.(*core.CmdVar)
+					getIndex(cmdVar, core.CLOSURE)
+					// we don't need call structOffset here because it doesn't matter type of struct
+					types[i] = type2Code(cmdVar.GetResult(), out)
+					if _, ok := cmdVar.Block.Heap[cmdVar.Index]; ok {
+						types[i] = core.TYPEBOX
+					}
+				}
+				push(core.Bcode(len(v.Captures)<<16)|core.CLOSURE, core.Bcode(id))
+				push(types...)
+			} else {
+				push(core.PUSHFUNC, core.Bcode(id))
+			}
 			if out.Used == nil {
 				out.Used = make(map[int32]byte)
 			}
@@ -265,7 +301,7 @@ func cmd2Code(linker *Linker, cmd core.ICmd, out *core.Bytecode) {
 	case core.CtStack:
 		cmdStack := cmd.(*core.CmdBlock)
 		switch cmdStack.ID {
-		case core.StackSwitch:
+		case core.StackSwitch, core.StackSelect:
 			cmd2Code(linker, cmdStack.Children[0], out)
 			cmpType := type2Code(cmdStack.Children[0].GetResult(), out)
 			offsets := make([]int, 0)
@@ -283,16 +319,14 @@ func cmd2Code(linker *Linker, cmd core.ICmd, out *core.Bytecode) {
 						cases = append(cases, len(out.Code))
 						push(core.Bcode(cmpType<<16)|core.JEQ, 0)
 					}
-					pos := len(out.Code)
-					push(core.JMP, 0)
+					pos := pushJump(linker, out, core.JMP, false)
 					for _, icase := range cases {
 						out.Code[icase+1] = core.Bcode(len(out.Code) - icase)
 					}
 					offsets = append(offsets, len(out.Code))
 					out.BlockFlags = core.BlBreak
 					cmd2Code(linker, caseStack.Children[len(caseStack.Children)-1], out)
-					offsets = append(offsets, len(out.Code))
-					push(core.JMP, 0)
+					offsets = append(offsets, pushJump(linker, out, core.JMP, false))
 					out.Code[pos+1] = core.Bcode(len(out.Code) - pos)
 					cmds = cmds[:0]
 				}
@@ -358,13 +392,21 @@ func cmd2Code(linker *Linker, cmd core.ICmd, out *core.Bytecode) {
 				structOffset(out, -len(out.Code)+1)
 			}
 		case core.StackQuestion:
+			if linker.Optimize {
+				if value, ok := constBool(cmdStack.Children[0]); ok {
+					if value {
+						cmd2Code(linker, cmdStack.Children[1], out)
+					} else {
+						cmd2Code(linker, cmdStack.Children[2], out)
+					}
+					break
+				}
+			}
 			cmd2Code(linker, cmdStack.Children[0], out)
-			pos := len(out.Code)
-			push(core.JZE, 0)
+			pos := pushJump(linker, out, core.JZE, false)
 			cmd2Code(linker, cmdStack.Children[1], out)
 			out.Code[pos+1] = core.Bcode(len(out.Code) - pos + 2)
-			pos = len(out.Code)
-			push(core.JMP, 0)
+			pos = pushJump(linker, out, core.JMP, false)
 			cmd2Code(linker, cmdStack.Children[2], out)
 			out.Code[pos+1] = core.Bcode(len(out.Code) - pos)
 		case core.StackAnd, core.StackOr:
@@ -374,10 +416,19 @@ func cmd2Code(linker *Linker, cmd core.ICmd, out *core.Bytecode) {
 				logic = core.JNZ
 			}
 			pos := len(out.Code)
-			push(core.DUP, logic, 0) //core.Bcode(size))
+			push(core.DUP)
+			pushJump(linker, out, logic, true)
 			cmd2Code(linker, cmdStack.Children[1], out)
 			out.Code[pos+2] = core.Bcode(len(out.Code) - pos - 3 + 2)
 		case core.StackAssign, core.StackIncDec:
+			if linker.Optimize && cmdStack.ID == core.StackAssign {
+				// superinstruction for var = var + value and var += value
+				if cmdVar, value, ok := incVar(cmdStack); ok {
+					getIndex(cmdVar, core.INCVAR)
+					push(core.Bcode(value))
+					break
+				}
+			}
 			rightType := core.Bcode(core.TYPEINT)
 			if cmdStack.ID == core.StackAssign {
 				cmd2Code(linker, cmdStack.Children[1], out)
@@ -415,19 +466,24 @@ func cmd2Code(linker *Linker, cmd core.ICmd, out *core.Bytecode) {
 			}
 		case core.StackIf:
 			var k int
-			lenIf := len(cmdStack.Children) >> 1
-			jumps := make([]int, lenIf)
+			children := cmdStack.Children
+			if linker.Optimize {
+				children = liveBranches(children)
+			}
+			lenIf := len(children) >> 1
+			jumps := make([]int, 0, lenIf)
 			for k = 0; k < lenIf; k++ {
-				cmd2Code(linker, cmdStack.Children[k<<1], out)
-				pos := len(out.Code)
-				push(core.JZE, 0)
-				cmd2Code(linker, cmdStack.Children[(k<<1)+1], out)
-				jumps[k] = len(out.Code)
-				push(core.JMP, core.Bcode(jumps[k]))
+				cmd2Code(linker, children[k<<1], out)
+				pos := pushJump(linker, out, core.JZE, false)
+				cmd2Code(linker, children[(k<<1)+1], out)
+				// the last branch without else doesn't need to jump to the end
+				if !linker.Optimize || k < lenIf-1 || len(children)&1 == 1 {
+					jumps = append(jumps, pushJump(linker, out, core.JMP, false))
+				}
 				out.Code[pos+1] = core.Bcode(len(out.Code) - pos)
 			}
-			if len(cmdStack.Children)&1 == 1 {
-				cmd2Code(linker, cmdStack.Children[len(cmdStack.Children)-1], out)
+			if len(children)&1 == 1 {
+				cmd2Code(linker, children[len(children)-1], out)
 			}
 			for _, off := range jumps {
 				out.Code[off+1] = core.Bcode(len(out.Code) - off)
@@ -437,43 +493,59 @@ func cmd2Code(linker *Linker, cmd core.ICmd, out *core.Bytecode) {
 			push(core.CYCLE)
 			getPos(linker, cmdStack, out)
 			cmd2Code(linker, cmdStack.Children[0], out)
-			push(core.JZE, 0) // core.Bcode(save(cmdStack.Children[1])+2))
+			pushJump(linker, out, core.JZE, false)
 			blockStart := len(out.Code)
 			out.BlockFlags = core.BlContinue | core.BlBreak
 			cmd2Code(linker, cmdStack.Children[1], out)
 			out.Code[blockStart-1] = core.Bcode(len(out.Code) - blockStart + 4)
-			push(core.JMP, core.Bcode(pos-len(out.Code)))
+			back := pushJump(linker, out, core.JMP, false)
+			out.Code[back+1] = core.Bcode(pos - back)
 			out.Code[blockStart+1] = core.Bcode(len(out.Code) - blockStart) // set break of BLOCK
 			out.Code[blockStart+2] = core.Bcode(pos - blockStart)           // set continue of BLOCK
 		case core.StackFor:
-			bInfo, _ := initBlock(linker, cmdStack, out)
+			bInfo, types := initBlock(linker, cmdStack, out)
 
 			cmd2Code(linker, cmdStack.Children[0], out)
 			srcType := type2Code(cmdStack.Children[0].GetResult(), out)
 			curType := type2Code(cmdStack.Vars[0], out)
 			// we don't need to use structOffset because for doesn't support structs
 			indcur := 0
-			if curType&0xf == core.STACKANY {
-				indcur = 1
+			for _, itype := range types {
+				if itype&0xf == core.STACKANY {
+					indcur++
+				}
+			}
+			curShift, curIndex := 0, bInfo.Vars[0]
+			if cell, ok := bInfo.Cells[0]; ok {
+				curShift, curIndex = core.BoxShift, cell
 			}
 			pos := len(out.Code)
 			push(core.CYCLE)
 			getPos(linker, cmdStack, out)
-			push(core.GETVAR, core.Bcode(int(core.TYPEINT)<<16|bInfo.Vars[1]),
-				(srcType<<16)|core.DUP, (srcType<<16)|core.LEN, core.LT)
-			posJmp := len(out.Code)
-			push(core.JZE, 0)
-			push(core.GETVAR, core.Bcode(int(core.TYPEINT)<<16|bInfo.Vars[1])) // set index
-			push(core.GETVAR, core.Bcode(int(srcType)<<16|indcur),             // get cur value
-				core.Bcode(1<<16|core.INDEX), core.Bcode(int(srcType)<<16)|curType)
-			push(core.SETVAR, core.Bcode(int(curType)<<16|bInfo.Vars[0]),
-				core.Bcode(int(curType)<<16|core.ASSIGNPTR), core.Bcode(int(curType)<<16|core.POP))
+			var posJmp int
+			if srcType == core.TYPECHAN {
+				push(core.GETVAR, core.Bcode(int(srcType)<<16|indcur))
+				cmd2Code(linker, cmdStack.Children[2], out) // receive the next value
+				posJmp = pushJump(linker, out, core.JZE, false)
+				cmd2Code(linker, cmdStack.Children[3], out) // get cur value
+			} else {
+				push(core.GETVAR, core.Bcode(int(core.TYPEINT)<<16|bInfo.Vars[1]),
+					(srcType<<16)|core.DUP, (srcType<<16)|core.LEN, core.LT)
+				posJmp = pushJump(linker, out, core.JZE, false)
+				push(core.GETVAR, core.Bcode(int(core.TYPEINT)<<16|bInfo.Vars[1])) // set index
+				push(core.GETVAR, core.Bcode(int(srcType)<<16|indcur),             // get cur value
+					core.Bcode(1<<16|core.INDEX), core.Bcode(int(srcType)<<16)|curType)
+			}
+			push(core.Bcode(curShift<<16)|core.SETVAR, core.Bcode(int(curType)<<16|curIndex),
+				core.Bcode(int(curType)<<16|core.ASSIGNPTR))
+			popAssigned(linker, out, len(out.Code)-1, curType)
 			blockStart := len(out.Code)
 			out.BlockFlags = core.BlContinue | core.BlBreak
 			cmd2Code(linker, cmdStack.Children[1], out)
 			out.Code[blockStart+2] = core.Bcode(len(out.Code) - blockStart) // set continue of BLOCK
 			push(core.Bcode(bInfo.Vars[1]<<16) | core.FORINC)
-			push(core.JMP, core.Bcode(pos-len(out.Code)))
+			back := pushJump(linker, out, core.JMP, false)
+			out.Code[back+1] = core.Bcode(pos - back)
 			out.Code[blockStart+1] = core.Bcode(len(out.Code) - blockStart) // set break of BLOCK
 			out.Code[posJmp+1] = core.Bcode(len(out.Code) - posJmp)
 			push(core.DELVARS)
@@ -491,7 +563,25 @@ func cmd2Code(linker *Linker, cmd core.ICmd, out *core.Bytecode) {
 			linker.Blocks = linker.Blocks[:len(linker.Blocks)-1]
 			//deleteVars(rt)
 		case core.StackReturn:
-			if cmdStack.Children != nil {
+			if len(cmdStack.Children) == 1 && len(getResults(cmdStack.Children[0])) > 0 {
+				cmd2Code(linker, cmdStack.Children[0], out)
+				results := getResults(cmdStack.Children[0])
+				types := make([]core.Bcode, len(results))
+				for i, item := range results {
+					types[i] = type2Code(item, out)
+				}
+				push(core.Bcode(len(types)<<16) | core.RETMULTI)
+				push(types...)
+			} else if len(cmdStack.Children) > 1 {
+				types := make([]core.Bcode, len(cmdStack.Children))
+				for i, item := range cmdStack.Children {
+					cmd2Code(linker, item, out)
+					// we don't need call structOffset here because it doesn't matter type of struct
+					types[i] = type2Code(item.GetResult(), out)
+				}
+				push(core.Bcode(len(types)<<16) | core.RETMULTI)
+				push(types...)
+			} else if cmdStack.Children != nil {
 				cmd2Code(linker, cmdStack.Children[0], out)
 				retType := type2Code(cmdStack.Children[0].GetResult(), out)
 				push((retType << 16) | core.RET)
@@ -501,6 +591,25 @@ func cmd2Code(linker *Linker, cmd core.ICmd, out *core.Bytecode) {
 			} else {
 				push(core.END)
 			}
+		case core.StackMultiAssign:
+			count := cmdStack.ParCount
+			cmd2Code(linker, cmdStack.Children[count], out)
+			results := getResults(cmdStack.Children[count])
+			for i := count - 1; i >= 0; i-- {
+				rightType := type2Code(results[i], out)
+				if cmdVar, ok := cmdStack.Children[i].(*core.CmdVar); ok {
+					getIndex(cmdVar, core.SETVAR)
+					push(rightType<<16 | core.ASSIGN)
+					assign := len(out.Code) - 1
+					if rightType >= core.TYPESTRUCT {
+						structOffset(out, -assign)
+					}
+					getPos(linker, cmdStack.Children[i], out)
+					popAssigned(linker, out, assign, rightType)
+				} else {
+					push(rightType<<16 | core.POP)
+				}
+			}
 		case core.StackOptional:
 			pos := len(out.Code)
 			// assigns value if the variable has not yet been assigned as optional
@@ -522,6 +631,21 @@ func cmd2Code(linker *Linker, cmd core.ICmd, out *core.Bytecode) {
 			push(core.END)
 			out.Code[pos+1] = core.Bcode(len(out.Code) - pos)
 			linker.Blocks = linker.Blocks[:len(linker.Blocks)-1]
+		case core.StackFinally:
+			linker.Blocks = append(linker.Blocks, BlockInfo{
+				Block:   cmdStack.Children[0].(*core.CmdBlock),
+				IsLocal: true,
+			})
+			pos := len(out.Code)
+			push(core.JMP, 0)
+			cmd2Code(linker, cmdStack.Children[0], out)
+			push(core.END)
+			out.Code[pos+1] = core.Bcode(len(out.Code) - pos)
+			linker.Blocks = linker.Blocks[:len(linker.Blocks)-1]
+			push(core.FINALLY, core.Bcode(pos+1-len(out.Code)))
+		case core.StackDefer:
+			cmd2Code(linker, cmdStack.Children[0], out)
+			push(core.DEFER)
 		case core.StackCallLocal:
 			offset := -1
 			for _, local := range out.Locals {
@@ -545,12 +669,63 @@ func cmd2Code(linker *Linker, cmd core.ICmd, out *core.Bytecode) {
 			if retType >= core.TYPESTRUCT {
 				structOffset(out, -len(out.Code)+1)
 			}
+		case core.StackEnum:
+			operand := cmdStack.Children[0]
+			enum := cmdStack.Result.Enum
+			opcode := core.Bcode(core.NOP)
+			switch {
+			case enum == nil && cmdStack.Result.GetName() == `int`:
+				// enum value is int value
+				cmd2Code(linker, operand, out)
+			case enum == nil:
+				enum = operand.GetResult().Enum
+				opcode = core.ENUMSTR
+			case operand.GetResult().GetName() == `str`:
+				opcode = core.ENUMVAL
+			default:
+				opcode = core.ENUMINT
+			}
+			if opcode == core.NOP {
+				break
+			}
+			if opcode != core.ENUMINT {
+				for _, name := range enum.Names {
+					cmd2Code(linker, &core.CmdValue{Value: name}, out)
+				}
+			}
+			cmd2Code(linker, operand, out)
+			push(core.Bcode(len(enum.Names)<<16) | opcode)
+			getPos(linker, cmdStack, out)
+		case core.StackIface:
+			var shift int
+			for _, par := range cmdStack.Vars {
+				if type2Code(par, out)&0xf == core.STACKANY {
+					shift++
+				}
+			}
+			ifaceType := cmdStack.Object.(*core.TypeObject)
+			impls := ifaceType.Iface.Impls
+			push(core.Bcode(shift<<16) | core.IFACE)
+			getPos(linker, cmdStack, out)
+			push(core.Bcode(len(impls)), type2Code(ifaceType, out))
+			structOffset(out, len(out.Code)-1)
+			for _, impl := range impls {
+				push(type2Code(impl.Type, out))
+				structOffset(out, len(out.Code)-1)
+				funcObj := impl.Funcs[cmdStack.ParCount].(*core.FuncObject)
+				push(core.Bcode(funcObj.ObjID))
+				usedFunc(funcObj, out)
+			}
+		case core.StackCatchID:
+			for _, item := range cmdStack.Children {
+				cmd2Code(linker, item, out)
+			}
+			push(core.Bcode(len(cmdStack.Children)/2<<16) | core.CATCHID)
 		case core.StackTry:
 			out.BlockFlags = core.BlTry
 			blockTry := len(out.Code)
 			cmd2Code(linker, cmdStack.Children[0], out)
-			pos := len(out.Code)
-			push(core.JMP, 0)
+			pos := pushJump(linker, out, core.JMP, false)
 			out.Code[blockTry+1] = core.Bcode(len(out.Code) - blockTry)
 			blockCatch := len(out.Code)
 			out.BlockFlags = core.BlRecover | core.BlRetry
@@ -35,7 +35,14 @@ type compiler struct {
 	isImport    bool // import or include mode
 	next        *cmState
 	dynamic     *cmState
+	parallel    *parallelFor // parallel for statement which is being started
 	goStack     []goStack
+	aliases     map[string]bool // aliases of imported units
+	generics    map[string]*genericFunc
+	instances   []genericInst     // instances of generic functions
+	curInstance int               // the next instance for compiling
+	typeParams  map[string]string // type parameters of the current instance
+	literals    []literalInfo     // struct literals inside expressions
 }
 
 type optInfo struct {
@@ -146,21 +153,32 @@ func (cmpl *compiler) latestFunc() *core.FuncObject {
 
 // Compile compiles the source code
 func Compile(ws *core.Workspace, input, path string) (int, error) {
+	ws.Lock()
+	defer ws.Unlock()
+	return compile(ws, input, path)
+}
 
+func compile(ws *core.Workspace, input, path string) (int, error) {
 	countObjects := len(ws.Objects)
 	countUnits := len(ws.Units)
 
+	input, err := preprocess(ws, input, path)
+	if err != nil {
+		return core.Undefined, err
+	}
 	lp, errID := LexParsing([]rune(input))
 	lp.Path = path
 	cmpl := &compiler{
-		ws:      ws,
-		unit:    ws.InitUnit(),
-		lexems:  []int{0}, // added lp in Lexeme
-		runID:   core.Undefined,
-		owners:  make([]core.ICmd, 0, 128),
-		exp:     make([]core.ICmd, 0, 128),
-		expbuf:  make([]ExpBuf, 0, 128),
-		curIota: core.NotIota,
+		ws:       ws,
+		unit:     ws.InitUnit(),
+		lexems:   []int{0}, // added lp in Lexeme
+		runID:    core.Undefined,
+		owners:   make([]core.ICmd, 0, 128),
+		exp:      make([]core.ICmd, 0, 128),
+		expbuf:   make([]ExpBuf, 0, 128),
+		curIota:  core.NotIota,
+		aliases:  make(map[string]bool),
+		generics: make(map[string]*genericFunc),
 	}
 	cmpl.unit.Lexeme = lp
 	if err := cmpl.copyNameSpace(ws.StdLib(), true); err != nil {
@@ -189,6 +207,9 @@ func Compile(ws *core.Workspace, input, path string) (int, error) {
 		cmpl.pos = len(lp.Tokens) - 1
 		return cmplError(errID)
 	}
+	if err := parseGenerics(cmpl); err != nil {
+		return cmplError(err)
+	}
 
 	stackState := make([]StateStack, 0, 32)
 	state := cmMain
@@ -373,11 +394,32 @@ func isEqualTypes(left *core.TypeObject, right *core.TypeObject) bool {
 			return true
 		}
 		return isEqualTypes(left.IndexOf, right.IndexOf)
+	case reflect.TypeOf(core.Chan{}):
+		if right.Original != reflect.TypeOf(core.Chan{}) {
+			return false
+		}
+		// compare for chan*
+		if left.IndexOf == nil || right.IndexOf == nil {
+			return true
+		}
+		return isEqualTypes(left.IndexOf, right.IndexOf)
+	case reflect.TypeOf(core.Future{}):
+		if right.Original != reflect.TypeOf(core.Future{}) {
+			return false
+		}
+		// compare for future*
+		if left.IndexOf == nil || right.IndexOf == nil {
+			return true
+		}
+		return isEqualTypes(left.IndexOf, right.IndexOf)
 	}
 	return left == right
 }
 
 func autoType(cmpl *compiler, name string) (obj core.IObject, err error) {
+	if cmpl.typeParams != nil {
+		name = genericType(cmpl, name)
+	}
 	if strings.HasSuffix(name, `.arr`) || strings.HasSuffix(name, `.map`) {
 		name += `.str`
 	}
@@ -401,6 +443,22 @@ func autoType(cmpl *compiler, name string) (obj core.IObject, err error) {
 						return
 					}
 				}
+			} else if ins[0] == `chan` {
+				var indexOf core.IObject
+				indexOf, err = autoType(cmpl, ins[1])
+				if indexOf != nil {
+					if obj = cmpl.unit.NewType(name, reflect.TypeOf(core.Chan{}), indexOf); obj != nil {
+						return
+					}
+				}
+			} else if ins[0] == `future` {
+				var indexOf core.IObject
+				indexOf, err = autoType(cmpl, ins[1])
+				if indexOf != nil {
+					if obj = cmpl.unit.NewType(name, reflect.TypeOf(core.Future{}), indexOf); obj != nil {
+						return
+					}
+				}
 			}
 		}
 		return nil, cmpl.Error(ErrType)
@@ -421,11 +479,20 @@ func getType(cmpl *compiler) (obj core.IObject, err error) {
 }
 
 func findVar(cmpl *compiler, token string) (*core.CmdBlock, int) {
-	block := cmpl.curOwner()
+	if block, ind := findVarBlock(cmpl, cmpl.curOwner(), token); block != nil {
+		return block, ind
+	}
+	return findGlobal(cmpl, token)
+}
+
+func findVarBlock(cmpl *compiler, block *core.CmdBlock, token string) (*core.CmdBlock, int) {
 	for block != nil {
 		if ind, ok := block.VarNames[token]; ok {
 			return block, ind
 		}
+		if block.Parent == nil {
+			return captureVar(cmpl, block, token)
+		}
 		block = block.Parent
 	}
 	return nil, 0
@@ -614,16 +681,20 @@ func coExpEnv(cmpl *compiler) error {
 }
 
 func isInLoop(cmpl *compiler, incase bool) bool {
+	var ret bool
 	for _, item := range cmpl.owners {
 		if item.GetType() == core.CtStack {
 			id := item.(*core.CmdBlock).ID
 			if id == core.StackWhile || id == core.StackFor ||
 				(incase && (id == core.StackCase || id == core.StackDefault)) {
-				return true
+				ret = true
+			} else if id == core.StackFinally || item.(*core.CmdBlock).Object != nil {
+				// break and continue cannot leave finally blocks and function literals
+				ret = false
 			}
 		}
 	}
-	return false
+	return ret
 }
 
 func coBreak(cmpl *compiler) error {
@@ -46,6 +46,13 @@ const (
 	cmLocalParams
 	cmCatch // catch command
 	cmCatchIdent
+	cmClosure // function literal
+	cmResults // several results of the function
+	cmDefer   // defer statement
+	cmFinally // finally block
+	cmGlobal  // global variable
+	cmGlobalName
+	cmGlobalEnd
 
 	cmBack // go to back
 
@@ -74,9 +81,12 @@ var (
 			{tkFunc, cmFunc, nil, coFuncBack, cfStopBack},
 			{tkStruct, cmStruct, nil, nil, cfStopBack},
 			{tkFn, cmFn, nil, nil, cfStopBack},
+			{tkEnum, 0, coEnum, nil, 0},
+			{tkInterface, 0, coInterface, nil, 0},
 			{tkInclude, cmInclude, coInclude, nil, cfStopBack},
 			{tkImport, cmInclude, coImport, nil, cfStopBack},
 			{tkPub, 0, coPub, nil, 0},
+			{tkIdent, cmGlobal, coGlobal, coGlobalBack, cfStopBack},
 		},
 		cmRun: {
 			{tkToken, ErrLCurly, coError, nil, 0},
@@ -91,6 +101,7 @@ var (
 		},
 		cmBody: {
 			{tkToken, cmExp, coExpStart, nil, cfStay | cfStopBack},
+			{tkIdent, cmExp, coMultiAssign, coMultiAssignBack, cfStay | cfStopBack},
 			{tkLine, 0, nil, nil, 0},
 			{tkLCurly, ErrExp, coError, nil, 0},
 			{tkRCurly, cmBack, nil, nil, 0},
@@ -99,6 +110,9 @@ var (
 			{tkWhile, cmExp, coWhile, coWhileBack, cfStopBack},
 			{tkFor, cmExp, coFor, coForBack, cfStopBack},
 			{tkSwitch, cmExp, coSwitch, coSwitchBack, cfStopBack},
+			{tkSelect, cmCaseMust, coSelect, coSelectBack, cfStopBack},
+			{tkParallel, cmExp, coParallel, coParallelBack, cfStopBack},
+			{tkGo, 0, coGoFor, nil, 0},
 			{tkReturn, cmExp, coReturn, coReturnBack, cfStopBack},
 			{tkBreak, 0, coBreak, nil, 0},
 			{tkContinue, 0, coContinue, nil, 0},
@@ -106,6 +120,7 @@ var (
 			{tkRetry, 0, coRetry, nil, 0},
 			{tkLocal, cmLocal, nil, coLocalBack, cfStopBack},
 			{tkTry, cmLCurly, coTry, coTryBack, cfStopBack},
+			{tkDefer, cmDefer, coDefer, coDeferBack, cfStopBack},
 		},
 		cmExp: {
 			{tkToken, ErrValue, coError, nil, 0},
@@ -123,11 +138,14 @@ var (
 			{tkEnv, cmExpOper, coExpEnv, nil, cfStopBack},
 			{tkQuestion, cmExpIdent, nil, nil, cfStopBack},
 			{tkGo, cmGo, coGo, coGoBack, cfStopBack},
+			{tkFn, cmClosure, coClosure, coClosureBack, cfStopBack},
+			{tkFor, cmBack, coParallelEnd, nil, cfStay},
 		},
 		cmExpIdent: {
 			{tkToken, cmExpOper, coExpVar, nil, cfStay},
 			{tkLPar, cmBack, coCallFunc, nil, cfStay},
 			{tkLSBracket, cmBack, coIndex, nil, cfStay},
+			{tkLCurly, cmExpOper, coExpLiteral, nil, cfStay},
 		},
 		cmExpOper: {
 			{tkToken, ErrOper, coError, nil, 0},
@@ -141,7 +159,9 @@ var (
 			{[]int{tkRPar, tkRSBracket}, 0, coOperator, nil, 0},
 			{[]int{tkLSBracket}, cmBack, coIndex, nil, cfStay},
 			{tkComma, cmBack, coComma, nil, 0},
-			{[]int{tkLCurly, tkLine, tkRCurly, tkColon}, cmBack, nil, nil, cfStay},
+			{tkLCurly, cmBack, coExpCurly, nil, cfStay},
+			{[]int{tkLine, tkRCurly, tkColon}, cmBack, nil, nil, cfStay},
+			{tkFor, cmBack, coParallelOper, nil, cfStay},
 		},
 		cmElseIf: {
 			{tkToken, cmBack, coIfEnd, nil, cfStay},
@@ -152,12 +172,13 @@ var (
 		cmFunc: {
 			{tkToken, ErrName, coError, nil, 0},
 			{tkIdent, cmParams, coFuncName, nil, 0},
+			{tkLPar, cmParams, coMethod, nil, 0},
 			{tkLine, 0, nil, nil, 0},
 		},
 		cmParams: {
 			{tkToken, ErrLCurly, coError, nil, 0},
 			{tkIdent, cmLCurly, coRetType, nil, 0},
-			{tkLPar, cmParam, nil, nil, cfStopBack},
+			{tkLPar, cmParam, coParams, nil, cfStopBack},
 			{tkLCurly, cmLCurly, coFuncStart, nil, cfStay},
 			{tkLine, 0, nil, nil, 0},
 		},
@@ -203,6 +224,18 @@ var (
 			{tkIdent, 0, coVar, nil, 0},
 			{tkLine, cmBack, nil, nil, 0},
 		},
+		cmGlobal: {
+			{tkToken, ErrType, coError, nil, 0},
+			{tkIdent, cmGlobalName, coVarType, nil, 0},
+		},
+		cmGlobalName: {
+			{tkToken, ErrName, coError, nil, 0},
+			{tkIdent, cmGlobalEnd, coGlobalName, nil, 0},
+		},
+		cmGlobalEnd: {
+			{tkToken, ErrMustAssign, coError, nil, 0},
+			{tkLine, cmBack, nil, nil, 0},
+		},
 		cmConst: {
 			{tkToken, cmExp, coConstEnum, coConstEnumBack, cfStay},
 			{tkLine, 0, nil, nil, 0},
@@ -255,6 +288,7 @@ var (
 		cmStructName: {
 			{tkToken, ErrLCurly, coError, nil, 0},
 			{tkIdent, 0, coStructName, nil, 0},
+			{tkAssign, 0, coStructDefault, nil, 0},
 			{tkRCurly, cmBack, nil, nil, cfStay},
 			{tkLine, cmBack, nil, nil, 0},
 		},
@@ -319,11 +353,35 @@ var (
 			{tkToken, ErrCatch, coError, nil, 0},
 			{tkLine, 0, nil, nil, 0},
 			{tkCatch, cmCatchIdent, nil, coCatchBack, 0},
+			{tkFinally, cmLCurly, coFinallyOnly, nil, 0},
 		},
 		cmCatchIdent: {
 			{tkToken, ErrName, coError, nil, 0},
 			{tkIdent, cmLCurly, coCatch, nil, 0},
 		},
+		cmResults: {
+			{tkToken, ErrType, coError, nil, 0},
+			{tkIdent, 0, coResultType, nil, 0},
+			{tkComma, 0, nil, nil, 0},
+			{tkRPar, cmLCurly, coResultsEnd, nil, 0},
+			{tkLine, 0, nil, nil, 0},
+		},
+		cmDefer: {
+			{tkToken, ErrLCurly, coError, nil, 0},
+			{tkLCurly, cmLCurly, coClosureStart, nil, cfStay},
+		},
+		cmFinally: {
+			{tkToken, ErrLCurly, coError, nil, 0},
+			{tkLine, 0, nil, nil, 0},
+			{tkFinally, cmLCurly, coFinally, nil, 0},
+		},
+		cmClosure: {
+			{tkToken, ErrLCurly, coError, nil, 0},
+			{tkIdent, cmLCurly, coClosureRetType, nil, 0},
+			{tkLPar, cmParam, nil, nil, cfStopBack},
+			{tkLCurly, cmLCurly, coClosureStart, nil, cfStay},
+			{tkLine, 0, nil, nil, 0},
+		},
 	}
 	compileTable [][tkToken]*cmState
 )
@@ -0,0 +1,136 @@
+// Copyright 2019 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package compiler
+
+import (
+	"github.com/gentee/gentee/core"
+)
+
+// lineToBlock encloses the rest of the line in curly brackets
+func lineToBlock(cmpl *compiler, start int) {
+	var depth int
+
+	lp := cmpl.unit.Lexeme
+	end := start
+main:
+	for ; end < len(lp.Tokens); end++ {
+		switch lp.Tokens[end].Type {
+		case tkLPar, tkLSBracket, tkLCurly:
+			depth++
+		case tkRPar, tkRSBracket:
+			depth--
+		case tkRCurly:
+			if depth == 0 {
+				break main
+			}
+			depth--
+		case tkLine:
+			if depth <= 0 {
+				break main
+			}
+		}
+	}
+	offset := lp.Tokens[len(lp.Tokens)-1].Offset
+	if end < len(lp.Tokens) {
+		offset = lp.Tokens[end].Offset
+	}
+	tokens := make([]core.Token, 0, len(lp.Tokens)+2)
+	tokens = append(tokens, lp.Tokens[:start]...)
+	tokens = append(tokens, core.Token{Type: int32(tkLCurly), Offset: lp.Tokens[start].Offset,
+		Length: 1})
+	tokens = append(tokens, lp.Tokens[start:end]...)
+	tokens = append(tokens, core.Token{Type: int32(tkRCurly), Offset: offset, Length: 1})
+	lp.Tokens = append(tokens, lp.Tokens[end:]...)
+	if cmpl.endColon > start {
+		cmpl.endColon += 2
+	}
+}
+
+// coDefer compiles the deferred statement as a function literal. It can be a block
+// or the rest of the line.
+func coDefer(cmpl *compiler) error {
+	lp := cmpl.unit.Lexeme
+	if next := cmpl.pos + 1; next < len(lp.Tokens) {
+		switch lp.Tokens[next].Type {
+		case tkLCurly, tkColon, tkLine:
+		default:
+			lineToBlock(cmpl, next)
+		}
+	}
+	newClosure(cmpl)
+	return nil
+}
+
+func coDeferBack(cmpl *compiler) error {
+	value, err := closureEnd(cmpl)
+	if err != nil {
+		return err
+	}
+	appendCmd(cmpl, &core.CmdBlock{ID: core.StackDefer, Children: []core.ICmd{value},
+		CmdCommon: core.CmdCommon{TokenID: value.TokenID}})
+	return nil
+}
+
+// isFinallyNext returns true if the try statement has just been compiled and finally follows it
+func isFinallyNext(cmpl *compiler) bool {
+	owner := cmpl.curOwner()
+	if len(owner.Children) == 0 {
+		return false
+	}
+	if last, ok := owner.Children[len(owner.Children)-1].(*core.CmdBlock); !ok ||
+		last.ID != core.StackTry {
+		return false
+	}
+	lp := cmpl.unit.Lexeme
+	for i := cmpl.pos + 1; i < len(lp.Tokens); i++ {
+		if lp.Tokens[i].Type != tkLine {
+			return lp.Tokens[i].Type == tkFinally
+		}
+	}
+	return false
+}
+
+// coFinallyOnly adds an empty catch block which throws the error further
+func coFinallyOnly(cmpl *compiler) error {
+	appendCmd(cmpl, &core.CmdBlock{ID: core.StackCatch,
+		CmdCommon: core.CmdCommon{TokenID: uint32(cmpl.pos)}, ParCount: 1,
+		Vars: []*core.TypeObject{cmpl.unit.FindType(`error`).(*core.TypeObject)}})
+	cmpl.owners = cmpl.owners[:len(cmpl.owners)-1]
+	return coFinally(cmpl)
+}
+
+// coFinally places the try statement into a new block together with the finally block.
+// The finally block is called when this block is left.
+func coFinally(cmpl *compiler) error {
+	owner := cmpl.curOwner()
+	last := len(owner.Children) - 1
+	cmdTry := owner.Children[last].(*core.CmdBlock)
+	wrapper := &core.CmdBlock{ID: core.StackBlock, Parent: owner, CmdCommon: cmdTry.CmdCommon}
+	finally := &core.CmdBlock{ID: core.StackFinally, Parent: wrapper,
+		CmdCommon: core.CmdCommon{TokenID: uint32(cmpl.pos)}}
+	body := &core.CmdBlock{ID: core.StackBlock, Parent: finally,
+		CmdCommon: core.CmdCommon{TokenID: uint32(cmpl.pos)}}
+	finally.Children = []core.ICmd{body}
+	cmdTry.Parent = wrapper
+	wrapper.Children = []core.ICmd{finally, cmdTry}
+	owner.Children[last] = wrapper
+	cmpl.owners = append(cmpl.owners, finally, body)
+	return nil
+}
+
+// isInFinally returns true if the current statement is inside finally block
+func isInFinally(cmpl *compiler) bool {
+	owners := funcOwners(cmpl)
+	for i := len(owners) - 1; i >= 0; i-- {
+		block := owners[i].(*core.CmdBlock)
+		if block.ID == core.StackFinally {
+			return true
+		}
+		if block.Parent != nil && block.Parent.ID == core.StackLocal {
+			break
+		}
+	}
+	return false
+}
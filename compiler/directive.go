@@ -0,0 +1,274 @@
+// Copyright 2019 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package compiler
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/gentee/gentee/core"
+)
+
+var (
+	reDirective = regexp.MustCompile(`^\s*#(if|elif|else|end|endif)\b(.*)$`)
+	reTag       = regexp.MustCompile(`^tag\(\s*([\w\.\-]+)\s*\)$`)
+
+	knownOS = map[string]bool{`aix`: true, `android`: true, `darwin`: true, `dragonfly`: true,
+		`freebsd`: true, `illumos`: true, `ios`: true, `js`: true, `linux`: true, `netbsd`: true,
+		`openbsd`: true, `plan9`: true, `solaris`: true, `wasip1`: true, `windows`: true}
+	knownArch = map[string]bool{`386`: true, `amd64`: true, `arm`: true, `arm64`: true,
+		`loong64`: true, `mips`: true, `mipsle`: true, `mips64`: true, `mips64le`: true,
+		`ppc64`: true, `ppc64le`: true, `riscv64`: true, `s390x`: true, `wasm`: true}
+)
+
+type ifState struct {
+	Line   int
+	Active bool // the current branch is compiled
+	Done   bool // one of the branches has already been compiled
+	Parent bool // the parent block is compiled
+	Else   bool
+}
+
+// Define adds a compile-time constant to stdlib. The value is converted to int, float or bool
+// if it is possible.
+func Define(ws *core.Workspace, name, value string) error {
+	if len(name) == 0 || !isCapital(name) || unicode.IsDigit(rune(name[0])) {
+		return fmt.Errorf(`%s [%s]`, errText[ErrConstName], name)
+	}
+	var v interface{}
+	if i, err := strconv.ParseInt(value, 0, 64); err == nil {
+		v = i
+	} else if f, err := strconv.ParseFloat(value, 64); err == nil {
+		v = f
+	} else if value == `true` || value == `false` {
+		v = value == `true`
+	} else {
+		v = value
+	}
+	ws.Lock()
+	defer ws.Unlock()
+	ws.StdLib().NewConst(name, v, false)
+	return nil
+}
+
+func isTrue(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case int64:
+		return v != 0
+	case float64:
+		return v != 0
+	case string:
+		return len(v) > 0 && v != `false` && v != `0`
+	}
+	return value != nil
+}
+
+// evalTerm returns the value of the condition item
+func evalTerm(ws *core.Workspace, term string) (bool, error) {
+	var not bool
+	for strings.HasPrefix(term, `!`) {
+		not = !not
+		term = strings.TrimSpace(term[1:])
+	}
+	var ret bool
+	switch {
+	case term == `true`:
+		ret = true
+	case term == `false`:
+	case term == `unix`:
+		ret = runtime.GOOS != `windows` && runtime.GOOS != `plan9` && runtime.GOOS != `js` &&
+			runtime.GOOS != `wasip1`
+	case knownOS[term]:
+		ret = runtime.GOOS == term
+	case knownArch[term]:
+		ret = runtime.GOARCH == term
+	case reTag.MatchString(term):
+		tag := reTag.FindStringSubmatch(term)[1]
+		for _, item := range ws.Tags {
+			if item == tag {
+				ret = true
+				break
+			}
+		}
+	case len(term) > 0 && isCapital(term):
+		if obj := ws.StdLib().FindConst(term); obj != nil {
+			if value, ok := obj.(*core.ConstObject).Exp.(*core.CmdValue); ok {
+				ret = isTrue(value.Value)
+			}
+		}
+	default:
+		return false, fmt.Errorf(errText[ErrDirectiveCond], term)
+	}
+	return ret != not, nil
+}
+
+// evalCondition evaluates the condition of #if directive. The condition can contain
+// the names of OS and architecture, tag(name), defined constants and !, &&, || operators.
+func evalCondition(ws *core.Workspace, cond string) (bool, error) {
+	if len(strings.TrimSpace(cond)) == 0 {
+		return false, fmt.Errorf(errText[ErrDirectiveCond], ``)
+	}
+	for _, or := range strings.Split(cond, `||`) {
+		ret := true
+		for _, term := range strings.Split(or, `&&`) {
+			val, err := evalTerm(ws, strings.TrimSpace(term))
+			if err != nil {
+				return false, err
+			}
+			ret = ret && val
+		}
+		if ret {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// scanLine returns the state at the end of the line by the state at its beginning. The state
+// is the quote of the unfinished string, '*' for the unfinished /* comment or 0.
+func scanLine(line string, state byte) byte {
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch state {
+		case '`':
+			if c == '`' {
+				if i+1 < len(line) && line[i+1] == '`' {
+					i++
+				} else {
+					state = 0
+				}
+			}
+		case '"':
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				state = 0
+			}
+		case '*':
+			if c == '*' && i+1 < len(line) && line[i+1] == '/' {
+				i++
+				state = 0
+			}
+		default:
+			switch c {
+			case '`', '"':
+				state = c
+			case '\'':
+				i++
+				if i < len(line) && line[i] == '\\' {
+					i++
+				}
+				if i+1 < len(line) {
+					if off := strings.IndexByte(line[i+1:], '\''); off >= 0 {
+						i += off + 1
+					}
+				}
+			case '/':
+				if i+1 < len(line) {
+					if line[i+1] == '/' {
+						return 0
+					}
+					if line[i+1] == '*' {
+						i++
+						state = '*'
+					}
+				}
+			case '$':
+				if i+1 < len(line) && line[i+1] == ' ' {
+					// the command line lasts until the end of the line
+					return 0
+				}
+			}
+		}
+	}
+	return state
+}
+
+// preprocess processes #if directives. Directive lines and the excluded code are replaced
+// with empty lines so the positions of the tokens are not changed.
+func preprocess(ws *core.Workspace, input, path string) (string, error) {
+	if strings.IndexByte(input, '#') < 0 {
+		return input, nil
+	}
+	lines := strings.Split(input, "\n")
+	stack := make([]ifState, 0, 8)
+	active := true
+	dirError := func(line int, err error) error {
+		return errors.New(core.ErrFormat(path, line+1, 1, err.Error()))
+	}
+	var state byte // the multiline string or comment which is continued on the next line
+	for i, line := range lines {
+		var match []string
+		// directives inside multiline strings and comments are not processed
+		if state == 0 {
+			match = reDirective.FindStringSubmatch(line)
+		}
+		state = scanLine(line, state)
+		if match == nil {
+			if !active {
+				lines[i] = ``
+			}
+			continue
+		}
+		lines[i] = ``
+		cond := strings.TrimSpace(match[2])
+		// ignore comments after directives
+		if off := strings.Index(cond, `//`); off >= 0 {
+			cond = strings.TrimSpace(cond[:off])
+		}
+		switch match[1] {
+		case `if`:
+			state := ifState{Line: i, Parent: active}
+			if active {
+				ok, err := evalCondition(ws, cond)
+				if err != nil {
+					return ``, dirError(i, err)
+				}
+				state.Active, state.Done = ok, ok
+			}
+			stack = append(stack, state)
+		case `elif`, `else`:
+			if len(stack) == 0 {
+				return ``, dirError(i, fmt.Errorf(errText[ErrDirectiveIf], `#`+match[1]))
+			}
+			state := &stack[len(stack)-1]
+			if state.Else {
+				return ``, dirError(i, fmt.Errorf(errText[ErrDirectiveIf], `#`+match[1]))
+			}
+			state.Active = false
+			if match[1] == `else` {
+				state.Else = true
+				state.Active = state.Parent && !state.Done
+			} else if state.Parent && !state.Done {
+				ok, err := evalCondition(ws, cond)
+				if err != nil {
+					return ``, dirError(i, err)
+				}
+				state.Active = ok
+			}
+			state.Done = state.Done || state.Active
+		default:
+			if len(stack) == 0 {
+				return ``, dirError(i, fmt.Errorf(errText[ErrDirectiveIf], `#`+match[1]))
+			}
+			stack = stack[:len(stack)-1]
+		}
+		active = true
+		if len(stack) > 0 {
+			active = stack[len(stack)-1].Active
+		}
+	}
+	if len(stack) > 0 {
+		return ``, dirError(stack[len(stack)-1].Line, errors.New(errText[ErrDirectiveEnd]))
+	}
+	return strings.Join(lines, "\n"), nil
+}
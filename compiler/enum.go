@@ -0,0 +1,177 @@
+// Copyright 2019 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package compiler
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/gentee/gentee/core"
+)
+
+// coEnum parses the declaration of enum type like enum Color { Red, Green, Blue }
+func coEnum(cmpl *compiler) error {
+	lp := cmpl.unit.Lexeme
+	i := cmpl.pos + 1
+	if i >= len(lp.Tokens) {
+		return cmpl.ErrorPos(len(lp.Tokens)-1, ErrEnd)
+	}
+	if lp.Tokens[i].Type != tkIdent {
+		return cmpl.ErrorPos(i, ErrName)
+	}
+	cmpl.pos = i
+	name, err := checkNewType(cmpl)
+	if err != nil {
+		return err
+	}
+	for i++; i < len(lp.Tokens) && lp.Tokens[i].Type == tkLine; i++ {
+	}
+	if i >= len(lp.Tokens) {
+		return cmpl.ErrorPos(len(lp.Tokens)-1, ErrEnd)
+	}
+	if lp.Tokens[i].Type != tkLCurly {
+		return cmpl.ErrorPos(i, ErrLCurly)
+	}
+	enum := &core.EnumType{}
+	for i++; i < len(lp.Tokens) && lp.Tokens[i].Type != tkRCurly; i++ {
+		switch lp.Tokens[i].Type {
+		case tkLine, tkComma:
+			continue
+		case tkIdent:
+			token := getToken(lp, i)
+			if strings.IndexRune(token, '.') >= 0 {
+				return cmpl.ErrorPos(i, ErrIdent)
+			}
+			if enumIndex(enum, token) >= 0 {
+				return cmpl.ErrorPos(i, ErrEnumMember, token)
+			}
+			enum.Names = append(enum.Names, token)
+		default:
+			return cmpl.ErrorPos(i, ErrName)
+		}
+	}
+	if i >= len(lp.Tokens) {
+		return cmpl.ErrorPos(len(lp.Tokens)-1, ErrEnd)
+	}
+	if len(enum.Names) == 0 {
+		return cmpl.ErrorPos(i, ErrName)
+	}
+	pType := cmpl.unit.NewType(name, reflect.TypeOf(int64(0)), nil).(*core.TypeObject)
+	enum.Range = cmpl.unit.NewType(`range.`+name, reflect.TypeOf(core.Range{}),
+		pType).(*core.TypeObject)
+	pType.Enum = enum
+	cmpl.newPos = i
+	return nil
+}
+
+func enumIndex(enum *core.EnumType, name string) int {
+	for i, item := range enum.Names {
+		if item == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// enumValue returns the member of enum like Color.Red or the range of all members
+// if the token is the name of enum type
+func enumValue(cmpl *compiler, token string, pos int) (core.ICmd, error) {
+	var member string
+	if off := strings.IndexRune(token, '.'); off >= 0 {
+		member = token[off+1:]
+		token = token[:off]
+	}
+	obj := cmpl.unit.FindType(token)
+	if obj == nil || obj.(*core.TypeObject).Enum == nil {
+		return nil, nil
+	}
+	pType := obj.(*core.TypeObject)
+	if len(member) == 0 {
+		return &core.CmdBinary{CmdCommon: core.CmdCommon{TokenID: uint32(pos)},
+			Object: getFunc(cmpl, `NewRange`, []*core.TypeObject{cmpl.getIntType(),
+				cmpl.getIntType()}),
+			Result: pType.Enum.Range,
+			Left: &core.CmdValue{Value: int64(0), Result: cmpl.getIntType(),
+				CmdCommon: core.CmdCommon{TokenID: uint32(pos)}},
+			Right: &core.CmdValue{Value: int64(len(pType.Enum.Names) - 1),
+				Result: cmpl.getIntType(), CmdCommon: core.CmdCommon{TokenID: uint32(pos)}},
+		}, nil
+	}
+	ind := enumIndex(pType.Enum, member)
+	if ind < 0 {
+		return nil, cmpl.ErrorPos(pos, ErrEnumUnknown, pType.GetName(), member)
+	}
+	return &core.CmdValue{Value: int64(ind), Result: pType,
+		CmdCommon: core.CmdCommon{TokenID: uint32(pos)}}, nil
+}
+
+// enumCast converts enum value to int or str and int or str value to enum
+func enumCast(cmpl *compiler, name string, param core.ICmd, pos int) core.ICmd {
+	var result *core.TypeObject
+	pType := param.GetResult()
+	if pType.Enum != nil {
+		if name == `int` || name == `str` {
+			result = cmpl.unit.FindType(name).(*core.TypeObject)
+		}
+	} else if pType.GetName() == `int` || pType.GetName() == `str` {
+		if obj := cmpl.unit.FindType(name); obj != nil && obj.(*core.TypeObject).Enum != nil {
+			result = obj.(*core.TypeObject)
+		}
+	}
+	if result == nil {
+		return nil
+	}
+	// Object is the name of the conversion in the error trace
+	return &core.CmdBlock{ID: core.StackEnum, Result: result, Object: result,
+		Children: []core.ICmd{param}, CmdCommon: core.CmdCommon{TokenID: uint32(pos)}}
+}
+
+// enumOperator returns the operator of int type for the values of the same enum type
+func enumOperator(cmpl *compiler, name string, params []*core.TypeObject) core.IObject {
+	if len(params) != 2 || params[0].Enum == nil || params[0] != params[1] {
+		return nil
+	}
+	switch name {
+	case `Assign`, `Equal`, `Less`, `Greater`:
+		return getFunc(cmpl, name, []*core.TypeObject{cmpl.getIntType(), cmpl.getIntType()})
+	}
+	return nil
+}
+
+// checkEnumSwitch appends a warning if switch over enum doesn't cover all members
+func checkEnumSwitch(cmpl *compiler, cmd *core.CmdBlock) {
+	if len(cmd.Children) == 0 {
+		return
+	}
+	enum := cmd.Children[0].GetResult().Enum
+	if enum == nil {
+		return
+	}
+	used := make([]bool, len(enum.Names))
+	for _, item := range cmd.Children[1:] {
+		caseStack := item.(*core.CmdBlock)
+		if caseStack.ID == core.StackDefault || len(caseStack.Children) == 0 {
+			return
+		}
+		for _, value := range caseStack.Children[:len(caseStack.Children)-1] {
+			if value.GetType() != core.CtValue {
+				continue
+			}
+			if ind := value.(*core.CmdValue).Value.(int64); ind >= 0 && ind < int64(len(used)) {
+				used[ind] = true
+			}
+		}
+	}
+	var missing []string
+	for i, name := range enum.Names {
+		if !used[i] {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		cmpl.Warning(int(cmd.TokenID), WarnEnumSwitch, cmd.Children[0].GetResult().GetName(),
+			strings.Join(missing, `, `))
+	}
+}
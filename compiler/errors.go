@@ -181,6 +181,65 @@ const (
 	ErrFnBuildIn
 	// ErrFnVariadic is returned when fn variable assigned to variadic function
 	ErrFnVariadic
+	// ErrAliasInclude is returned when alias is used with include
+	ErrAliasInclude
+	// ErrAlias is returned when alias has already been defined
+	ErrAlias
+	// ErrDirectiveCond is returned when #if directive has an unknown condition
+	ErrDirectiveCond
+	// ErrDirectiveIf is returned when #elif, #else or #end is used without #if
+	ErrDirectiveIf
+	// ErrDirectiveEnd is returned when #if directive doesn't have #end
+	ErrDirectiveEnd
+	// ErrClosure is returned when the function literal is used outside of functions
+	ErrClosure
+	// ErrReturnCount is returned when the function returns a wrong number of values
+	ErrReturnCount
+	// ErrMultiFunc is returned when a function with several results is expected
+	ErrMultiFunc
+	// ErrMultiCount is returned when the count of variables and results are different
+	ErrMultiCount
+	// ErrFinally is returned when return is used inside finally block
+	ErrFinally
+	// ErrCatchFilter is returned when the filter of catch is invalid
+	ErrCatchFilter
+	// ErrEnumMember is returned when the member of enum has already been defined
+	ErrEnumMember
+	// ErrEnumUnknown is returned when enum doesn't have the specified member
+	ErrEnumUnknown
+	// ErrGeneric is returned when the declaration of generic function is invalid
+	ErrGeneric
+	// ErrGenericExists is returned when the generic function has already been defined
+	ErrGenericExists
+	// ErrGenericInfer is returned when the type parameter cannot be inferred
+	ErrGenericInfer
+	// ErrIfaceMethod is returned when the method of interface has already been defined
+	ErrIfaceMethod
+	// ErrReceiver is returned when the receiver of the method is not a struct
+	ErrReceiver
+	// ErrIfaceImpl is returned when the struct doesn't implement the method of interface
+	ErrIfaceImpl
+	// ErrFieldDefault is returned when the default value of the field is not a literal
+	ErrFieldDefault
+	// ErrGlobalExists is returned when the global variable has already been defined
+	ErrGlobalExists
+	// ErrSelectCase is returned when the case of select is not Send or Receive of the channel
+	ErrSelectCase
+	// ErrParallel is returned when parallel is not followed by for statement
+	ErrParallel
+	// ErrParallelFor is returned when parallel for doesn't support the type of the source
+	ErrParallelFor
+	// ErrParallelVar is returned when the body of parallel for assigns the outer variable
+	ErrParallelVar
+	// ErrMultiValue is returned when the function with several results is used as one value
+	ErrMultiValue
+	// ErrMultiTarget is returned when not a variable is assigned in the assignment of several values
+	ErrMultiTarget
+	// ErrPlatform is returned when the function is not supported on the current platform
+	ErrPlatform
+
+	// WarnEnumSwitch is the warning when switch over enum doesn't cover all members
+	WarnEnumSwitch
 
 	// ErrCompiler error. It means a bug.
 	ErrCompiler
@@ -284,6 +343,36 @@ var (
 		ErrLinkIndex:     `incorrect link index %d`,
 		ErrFnBuildIn:     `fn variable can't be assigned to a built-in function`,
 		ErrFnVariadic:    `fn variable can't be assigned to a variadic function`,
+		ErrAliasInclude:  `alias can only be used with import`,
+		ErrAlias:         `alias %s has already been defined`,
+		ErrDirectiveCond: `unknown condition '%s' in directive`,
+		ErrDirectiveIf:   `%s without #if`,
+		ErrDirectiveEnd:  `#if without #end`,
+		ErrClosure:       `function literal can only be used inside functions`,
+		ErrReturnCount:   `function must return %d values`,
+		ErrMultiFunc:     `expecting a function that returns several values`,
+		ErrMultiCount:    `assignment mismatch: %d variables but %d values`,
+		ErrFinally:       `return cannot be used inside finally`,
+		ErrCatchFilter:   `expecting an error id, a range of ids or a category of errors`,
+		ErrEnumMember:    `%s member has already been defined`,
+		ErrEnumUnknown:   `enum %s doesn't have %s member`,
+		ErrGeneric:       `invalid declaration of generic function`,
+		ErrGenericExists: `generic function %s has already been defined`,
+		ErrGenericInfer:  `cannot infer type %s of generic function %s`,
+		ErrIfaceMethod:   `%s method has already been defined`,
+		ErrReceiver:      `%s cannot be the receiver of the method`,
+		ErrIfaceImpl:     `%s doesn't implement %s (missing %s method)`,
+		ErrFieldDefault:  `default value of the field must be a number, a string or a boolean literal`,
+		ErrGlobalExists:  `global variable %s has already been defined`,
+		ErrSelectCase:    `select case must be Send or Receive of the channel`,
+		ErrParallel:      `parallel must be followed by for statement`,
+		ErrParallelFor:   `parallel for doesn't support %s type`,
+		ErrParallelVar:   `outer variable %s cannot be assigned in parallel for, each thread has its copy`,
+		ErrMultiValue:    `function %s returns %d values, they can only be assigned to several variables`,
+		ErrMultiTarget:   `only variables can be assigned several values`,
+		ErrPlatform:      `function %s is not supported on %s, exclude it with #if`,
+
+		WarnEnumSwitch: `switch over %s doesn't cover %s`,
 
 		ErrCompiler: `you have found a compiler bug [%s]. Let us know, please`,
 	}
@@ -295,6 +384,11 @@ func (cmpl *compiler) ErrorPos(pos int, errID int, pars ...interface{}) error {
 	return errors.New(core.ErrFormat(lex.Path, line, column, fmt.Sprintf(errText[errID], pars...)))
 }
 
+// Warning appends the warning message to the unit
+func (cmpl *compiler) Warning(pos int, warnID int, pars ...interface{}) {
+	cmpl.unit.Warnings = append(cmpl.unit.Warnings, cmpl.ErrorPos(pos, warnID, pars...).Error())
+}
+
 func (cmpl *compiler) Error(errID int, pars ...interface{}) error {
 	return cmpl.ErrorPos(cmpl.pos, errID, pars...)
 }
@@ -7,6 +7,7 @@ package compiler
 import (
 	"fmt"
 	"reflect"
+	"runtime"
 	"strconv"
 	"strings"
 
@@ -91,7 +92,7 @@ func coExpVar(cmpl *compiler) error {
 			Result:    cmpl.unit.FindType(`str`).(*core.TypeObject)})
 		return nil
 	}
-	if isCapital(token) {
+	if isCapital(token) || isAliasConst(cmpl, token) {
 		if token == core.ConstIota && cmpl.curIota == core.NotIota {
 			return cmpl.ErrorPos(cmpl.pos-1, ErrIota)
 		}
@@ -105,10 +106,31 @@ func coExpVar(cmpl *compiler) error {
 		}
 	} else {
 		var fields []string
+		block, _ := findVar(cmpl, strings.SplitN(token, `.`, 2)[0])
+		if block == nil && cmpl.aliases[token] && cmpl.unit.Lexeme.Tokens[cmpl.pos].Type == tkDot {
+			// alias.Func() - the name of the function is resolved by the call
+			appendExp(cmpl, &core.CmdValue{Value: aliasName(token),
+				CmdCommon: core.CmdCommon{TokenID: uint32(cmpl.pos - 1)}})
+			return nil
+		}
+		if block == nil {
+			cmdEnum, err := enumValue(cmpl, token, cmpl.pos-1)
+			if err != nil {
+				return err
+			}
+			if cmdEnum != nil {
+				appendExp(cmpl, cmdEnum)
+				return nil
+			}
+		}
 		if strings.IndexRune(token, '.') >= 0 {
 			fields = strings.Split(token, `.`)
 			token = fields[0]
 			fields = fields[1:]
+			if block == nil && cmpl.aliases[token] {
+				token += `.` + fields[0]
+				fields = fields[1:]
+			}
 		}
 
 		block, ind := findVar(cmpl, token)
@@ -141,9 +163,15 @@ func coExpEnd(cmpl *compiler) error {
 		}
 	}
 	init := isInState(cmpl, cmInit, 0)
-	if len(cmpl.exp) > 1 && !init && !isCase(cmpl) {
+	if len(cmpl.exp) > 1 && !init && !isCase(cmpl) && !isReturn(cmpl) {
 		return cmpl.Error(ErrCompiler, `coExpEnd`)
 	}
+	if owner := cmpl.curOwner(); owner.ID != core.StackBlock && owner.ID != core.StackMultiAssign &&
+		(owner.ID != core.StackReturn || len(cmpl.exp) > 1) {
+		if err := checkMultiValue(cmpl, cmpl.exp...); err != nil {
+			return err
+		}
+	}
 	for len(cmpl.exp) > 0 {
 		cmpl.curOwner().Children = append(cmpl.curOwner().Children, cmpl.exp[0])
 		cmpl.exp = cmpl.exp[1:]
@@ -154,6 +182,9 @@ func coExpEnd(cmpl *compiler) error {
 func popBuf(cmpl *compiler) error {
 	var obj core.IObject
 	expBuf := cmpl.expbuf[len(cmpl.expbuf)-1]
+	if err := checkMultiValue(cmpl, cmpl.exp[max(len(cmpl.exp)-2, 0):]...); err != nil {
+		return err
+	}
 	prior := priority[expBuf.Oper]
 	switch expBuf.Oper {
 	case tkAnd, tkOr:
@@ -218,16 +249,25 @@ func popBuf(cmpl *compiler) error {
 		if left.GetType() != core.CtVar {
 			return cmpl.ErrorPos(expBuf.Pos, ErrLValue)
 		}
+		if err := checkCaptureAssign(cmpl, left, expBuf.Pos); err != nil {
+			return err
+		}
 
 		obj = getOperator(cmpl, prior.Name, left, right)
 		if obj == nil {
 			if expBuf.Oper == tkAssign {
 				if left.GetResult().Custom != nil {
-					if left.GetResult() != right.GetResult() {
-						return cmpl.ErrorPos(expBuf.Pos, ErrStructAssign, right.GetResult().GetName(),
-							left.GetResult().GetName())
+					if left.GetResult() != right.GetResult() &&
+						!ifaceAssign(cmpl, left.GetResult(), right.GetResult()) {
+						return ifaceAssignError(cmpl, expBuf.Pos, left.GetResult(), right.GetResult())
 					}
 					obj = cmpl.ws.StdLib().FindObj(core.DefAssignStructStruct)
+				} else if isChan(left.GetResult()) && isChan(right.GetResult()) &&
+					(left.GetResult() == right.GetResult() || right.GetResult().IndexOf == nil) {
+					obj = cmpl.unit.FindObj(core.DefAssignChan)
+				} else if isFuture(left.GetResult()) && isFuture(right.GetResult()) &&
+					isEqualTypes(left.GetResult(), right.GetResult()) {
+					obj = cmpl.unit.FindObj(core.DefAssignFuture)
 				} else if left.GetResult().Func != nil {
 					if !isEqualTypes(left.GetResult(), right.GetResult()) {
 						return cmpl.ErrorPos(expBuf.Pos, ErrStructAssign, right.GetResult().GetName(),
@@ -266,6 +306,8 @@ func popBuf(cmpl *compiler) error {
 						obj = cmpl.unit.FindObj(core.DefAssignAddArrArr)
 					} else if right.GetResult().Original == reflect.TypeOf(core.Map{}) {
 						obj = cmpl.unit.FindObj(core.DefAssignAddMap)
+					} else if isFuture(right.GetResult()) {
+						obj = cmpl.unit.FindObj(core.DefAssignAddFuture)
 					}
 				} else if right.GetResult().Original == reflect.TypeOf(core.Array{}) &&
 					left.GetResult().IndexOf == right.GetResult().IndexOf {
@@ -320,6 +362,9 @@ func popBuf(cmpl *compiler) error {
 		if top.GetType() != core.CtVar {
 			return cmpl.ErrorPos(expBuf.Pos, ErrLValue)
 		}
+		if err := checkCaptureAssign(cmpl, top, expBuf.Pos); err != nil {
+			return err
+		}
 		val := 1
 		if (expBuf.Oper & 0xff) == tkDec {
 			val = -1
@@ -477,8 +522,16 @@ func appendExpBuf(cmpl *compiler, operation int) error {
 							prevToken.LenExp--
 							cmpl.expbuf[len(cmpl.expbuf)-1] = prevToken
 						}
+						if alias, ok := isAlias(cmpl.exp[prevToken.LenExp:]); ok {
+							nameFunc = alias + `.` + nameFunc
+							prevToken.Pos = cmpl.exp[prevToken.LenExp].GetToken() + 1
+							cmpl.exp = append(cmpl.exp[:prevToken.LenExp], cmpl.exp[prevToken.LenExp+1:]...)
+						}
 
 						numParams := len(cmpl.exp) - prevToken.LenExp - optCount
+						if err := checkMultiValue(cmpl, cmpl.exp[prevToken.LenExp:]...); err != nil {
+							return err
+						}
 						params := make([]*core.TypeObject, 0)
 						for i := 0; i < numParams; i++ {
 							params = append(params, cmpl.exp[prevToken.LenExp+i].GetResult())
@@ -527,12 +580,42 @@ func appendExpBuf(cmpl *compiler, operation int) error {
 							}
 						} else {
 							var (
-								result *core.TypeObject
-								pobj   core.IObject
-								fnVar  core.ICmd
+								result    *core.TypeObject
+								defResult *core.TypeObject
+								pobj      core.IObject
+								fnVar     core.ICmd
 							)
 							obj := getFunc(cmpl, nameFunc, params)
+							if obj == nil && optCount == 0 {
+								var err error
+								if obj, err = genericInstance(cmpl, nameFunc, params,
+									prevToken.Pos-1); err != nil {
+									return err
+								}
+							}
 							if obj == nil {
+								obj = ifaceFunc(cmpl, nameFunc, params)
+							}
+							if obj == nil && optCount == 0 {
+								obj, defResult = chanFunc(cmpl, nameFunc, params)
+							}
+							if obj == nil && optCount == 0 {
+								obj, defResult = futureFunc(cmpl, nameFunc, params)
+							}
+							if obj == nil && optCount == 0 {
+								if fnVar = ifaceMethod(cmpl, nameFunc, params, prevToken.Pos-1); fnVar != nil {
+									result = fnVar.GetResult()
+								}
+							}
+							if obj == nil && numParams == 1 && optCount == 0 {
+								if icmd := enumCast(cmpl, nameFunc, cmpl.exp[prevToken.LenExp],
+									prevToken.Pos-1); icmd != nil {
+									cmpl.exp[len(cmpl.exp)-1] = icmd
+									cmpl.expbuf = cmpl.expbuf[:len(cmpl.expbuf)-1]
+									return nil
+								}
+							}
+							if obj == nil && fnVar == nil {
 								var isMatch bool
 								block, ind := findVar(cmpl, nameFunc)
 								if block != nil {
@@ -575,7 +658,11 @@ func appendExpBuf(cmpl *compiler, operation int) error {
 									cmpl.expbuf = cmpl.expbuf[:len(cmpl.expbuf)-1]
 									return nil
 								}
-							} else {
+							} else if obj != nil {
+								if embed, ok := obj.(*core.EmbedObject); ok && embed.Platform {
+									return cmpl.ErrorPos(prevToken.Pos-1, ErrPlatform, nameFunc,
+										runtime.GOOS)
+								}
 								result = obj.Result()
 								if result != nil && len(params) > 0 {
 									retName := result.GetName()
@@ -583,6 +670,9 @@ func appendExpBuf(cmpl *compiler, operation int) error {
 										result = params[0]
 									}
 								}
+								if defResult != nil {
+									result = defResult
+								}
 								pobj = obj
 							}
 							if optCount > 0 {
@@ -661,7 +751,7 @@ func setIndex(cmpl *compiler) error {
 	if typeObject.Original == reflect.TypeOf(core.Map{}) {
 		varIndex = cmpl.getStrType()
 	}
-	if typeObject.IndexOf == nil {
+	if typeObject.IndexOf == nil || isChan(typeObject) || isFuture(typeObject) {
 		return cmpl.ErrorPos(cmpl.expbuf[len(cmpl.expbuf)-1].Pos-1, ErrSupportIndex,
 			typeObject.GetName())
 	}
@@ -725,8 +815,18 @@ func coOptionalFunc(cmpl *compiler) (bool, error) {
 	return false, nil
 }
 
+// inParentheses returns true if the expression has an unclosed left parenthesis
+func inParentheses(cmpl *compiler) bool {
+	for _, expBuf := range cmpl.expbuf {
+		if expBuf.Oper == tkLPar || expBuf.Oper == tkLSBracket {
+			return true
+		}
+	}
+	return false
+}
+
 func coComma(cmpl *compiler) error {
-	if isCase(cmpl) {
+	if isCase(cmpl) && !inParentheses(cmpl) {
 		for len(cmpl.expbuf) > 0 {
 			if err := popBuf(cmpl); err != nil {
 				return err
@@ -742,6 +842,9 @@ func coComma(cmpl *compiler) error {
 	if isInState(cmpl, cmInit, 1) || isInState(cmpl, cmInit, 2) {
 		return nil
 	}
+	if len(cmpl.expbuf) == 0 && isReturn(cmpl) { // return several values
+		return nil
+	}
 	if len(cmpl.expbuf) < 2 || (cmpl.expbuf[len(cmpl.expbuf)-1].Oper != tkLPar &&
 		cmpl.expbuf[len(cmpl.expbuf)-2].Oper != tkCallFunc) {
 		return cmpl.Error(ErrOper)
@@ -84,6 +84,9 @@ func coRetType(cmpl *compiler) error {
 func coFuncStart(cmpl *compiler) error {
 	funcObj := cmpl.latestFunc()
 	funcObj.Block.ParCount = len(funcObj.Block.Vars)
+	if cmpl.typeParams != nil {
+		return nil
+	}
 	params := funcObj.GetParams()
 	if funcObj.Block.Variadic {
 		funcObj.Block.ParCount--
@@ -110,6 +113,11 @@ func coFuncStart(cmpl *compiler) error {
 func getFunc(cmpl *compiler, name string, params []*core.TypeObject) (obj core.IObject) {
 	var variadic bool
 	obj, variadic = cmpl.unit.FindFunc(name, params)
+	if variadic {
+		if fnObj := getClosureFunc(cmpl, name, params, 0); fnObj != nil {
+			return fnObj
+		}
+	}
 	if obj == nil || !variadic {
 		return
 	}
@@ -152,12 +160,15 @@ func getOperator(cmpl *compiler, name string, left, right core.ICmd) (obj core.I
 	if right != nil {
 		params = append(params, right.GetResult())
 	}
-	return getFunc(cmpl, name, params)
+	if obj = getFunc(cmpl, name, params); obj == nil {
+		obj = enumOperator(cmpl, name, params)
+	}
+	return
 }
 
 func coFuncBack(cmpl *compiler) error {
 	funcObj := cmpl.latestFunc()
-	if funcObj.Block.Result != nil {
+	if funcObj.Block.Result != nil || len(funcObj.Block.Results) > 0 {
 		if len(funcObj.Block.Children) == 0 {
 			return cmpl.Error(ErrMustReturn)
 		}
@@ -168,11 +179,16 @@ func coFuncBack(cmpl *compiler) error {
 		}
 	}
 	cmpl.owners = cmpl.owners[:0]
+	cmpl.typeParams = nil
 	return nil
 }
 
 func coFuncName(cmpl *compiler) error {
-	token := getToken(cmpl.unit.Lexeme, cmpl.pos)
+	lp := cmpl.unit.Lexeme
+	if cmpl.pos+1 < len(lp.Tokens) && lp.Tokens[cmpl.pos+1].Type == tkLess {
+		return coGenericName(cmpl)
+	}
+	token := getToken(lp, cmpl.pos)
 	if isCapital(token) {
 		return cmpl.Error(ErrCapitalLetters)
 	}
@@ -0,0 +1,55 @@
+// Copyright 2019 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package compiler
+
+import (
+	"reflect"
+
+	"github.com/gentee/gentee/core"
+)
+
+func isFuture(itype *core.TypeObject) bool {
+	return itype != nil && itype.Original == reflect.TypeOf(core.Future{})
+}
+
+// futureFunc returns wait, Get or WaitAll func for the futures and the type of the result
+func futureFunc(cmpl *compiler, name string, params []*core.TypeObject) (core.IObject,
+	*core.TypeObject) {
+	if len(params) != 1 {
+		return nil, nil
+	}
+	switch name {
+	case `wait`, `Get`:
+		if isFuture(params[0]) && params[0].IndexOf != nil {
+			valType := params[0].IndexOf
+			return chanEmbed(cmpl, core.DefWaitFuture, valType), valType
+		}
+	case `WaitAll`:
+		if params[0].Original == reflect.TypeOf(core.Array{}) && isFuture(params[0].IndexOf) &&
+			params[0].IndexOf.IndexOf != nil {
+			ret, _ := autoType(cmpl, `arr.`+params[0].IndexOf.IndexOf.GetName())
+			if ret != nil {
+				return cmpl.ws.StdLib().FindObj(core.DefWaitAllFuture), ret.(*core.TypeObject)
+			}
+		}
+	}
+	return nil, nil
+}
+
+// goReturn defines the type of the result of go block by its first return statement
+func goReturn(cmpl *compiler, block *core.CmdBlock, values []core.ICmd) {
+	if len(cmpl.goStack) == 0 || block != &cmpl.latestFunc().Block {
+		return
+	}
+	stack := &cmpl.goStack[len(cmpl.goStack)-1]
+	if stack.Closure != nil || stack.Name != cmpl.latestFunc().Name {
+		return
+	}
+	if len(values) == 0 {
+		stack.NoResult = true
+	} else if len(values) == 1 && block.Result == nil && !stack.NoResult {
+		block.Result = values[0].GetResult()
+	}
+}
@@ -0,0 +1,299 @@
+// Copyright 2019 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package compiler
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/gentee/gentee/core"
+)
+
+// genericFunc contains the instances of the generic function in the current unit
+type genericFunc struct {
+	*core.Generic
+	Local     []core.Token // the tokens of the template in the lexeme of the current unit
+	Instances map[string]*core.FuncObject
+}
+
+// genericInst is an instance of the generic function waiting for the compilation
+type genericInst struct {
+	Func  *core.FuncObject
+	Types map[string]string
+}
+
+// parseGenerics cuts the declarations of generic functions like func First<T>(arr.T a) T {...}
+// from the tokens and saves them as templates of the unit.
+func parseGenerics(cmpl *compiler) error {
+	lp := cmpl.unit.Lexeme
+	for i := 0; i+2 < len(lp.Tokens); i++ {
+		if lp.Tokens[i].Type != tkFunc || lp.Tokens[i+1].Type != tkIdent ||
+			lp.Tokens[i+2].Type != tkLess {
+			continue
+		}
+		name := getToken(lp, i+1)
+		start := i
+		if _, ok := cmpl.unit.Generics[name]; ok {
+			return cmpl.ErrorPos(i+1, ErrGenericExists, name)
+		}
+		gen := &core.Generic{Name: name, Unit: cmpl.unit}
+		for k := i - 1; k >= 0; k-- {
+			if lp.Tokens[k].Type == tkPub {
+				gen.Pub = true
+				start = k
+				break
+			} else if lp.Tokens[k].Type != tkLine {
+				break
+			}
+		}
+		j := i + 3
+		for ; j < len(lp.Tokens) && lp.Tokens[j].Type != tkGreater; j++ {
+			switch lp.Tokens[j].Type {
+			case tkComma:
+				continue
+			case tkIdent:
+				token := getToken(lp, j)
+				if strings.IndexRune(token, '.') >= 0 || isTypeParam(gen, token) {
+					return cmpl.ErrorPos(j, ErrGeneric)
+				}
+				gen.Types = append(gen.Types, token)
+			default:
+				return cmpl.ErrorPos(j, ErrGeneric)
+			}
+		}
+		if j+1 >= len(lp.Tokens) {
+			return cmpl.ErrorPos(len(lp.Tokens)-1, ErrEnd)
+		}
+		if len(gen.Types) == 0 || lp.Tokens[j+1].Type != tkLPar {
+			return cmpl.ErrorPos(j+1, ErrGeneric)
+		}
+		var parType string
+		for j += 2; j < len(lp.Tokens) && lp.Tokens[j].Type != tkRPar; j++ {
+			switch lp.Tokens[j].Type {
+			case tkLine:
+				continue
+			case tkComma:
+				parType = ``
+			case tkIdent:
+				if len(parType) == 0 {
+					parType = getToken(lp, j)
+				} else {
+					gen.Pars = append(gen.Pars, parType)
+				}
+			default:
+				return cmpl.ErrorPos(j, ErrGeneric)
+			}
+		}
+		for j++; j < len(lp.Tokens) && lp.Tokens[j].Type == tkLine; j++ {
+		}
+		if j < len(lp.Tokens) && lp.Tokens[j].Type == tkIdent {
+			gen.Result = getToken(lp, j)
+			j++
+		}
+		if j >= len(lp.Tokens) {
+			return cmpl.ErrorPos(len(lp.Tokens)-1, ErrEnd)
+		}
+		if lp.Tokens[j].Type != tkLCurly {
+			return cmpl.ErrorPos(j, ErrGeneric)
+		}
+		for depth := 0; j < len(lp.Tokens); j++ {
+			if lp.Tokens[j].Type == tkLCurly {
+				depth++
+			} else if lp.Tokens[j].Type == tkRCurly {
+				if depth--; depth == 0 {
+					break
+				}
+			}
+		}
+		if j >= len(lp.Tokens) {
+			return cmpl.ErrorPos(len(lp.Tokens)-1, ErrEnd)
+		}
+		gen.Tokens = append([]core.Token{}, lp.Tokens[i:j+1]...)
+		cmpl.unit.Generics[name] = gen
+		lp.Tokens = append(lp.Tokens[:start], lp.Tokens[j+1:]...)
+		i = start - 1
+	}
+	return nil
+}
+
+func isTypeParam(gen *core.Generic, name string) bool {
+	for _, item := range gen.Types {
+		if item == name {
+			return true
+		}
+	}
+	return false
+}
+
+// matchType binds type parameters of the pattern like arr.T to the specified type
+func matchType(cmpl *compiler, gen *core.Generic, pattern string, pType *core.TypeObject,
+	binds map[string]*core.TypeObject) bool {
+	if isTypeParam(gen, pattern) {
+		if bind, ok := binds[pattern]; ok {
+			return isEqualTypes(bind, pType)
+		}
+		binds[pattern] = pType
+		return true
+	}
+	ins := strings.SplitN(pattern, `.`, 2)
+	if len(ins) == 2 && (ins[0] == `arr` || ins[0] == `map`) {
+		original := reflect.TypeOf(core.Array{})
+		if ins[0] == `map` {
+			original = reflect.TypeOf(core.Map{})
+		}
+		if pType.Original != original || pType.IndexOf == nil {
+			return false
+		}
+		return matchType(cmpl, gen, ins[1], pType.IndexOf, binds)
+	}
+	obj, err := autoType(cmpl, pattern)
+	return err == nil && isEqualTypes(obj.(*core.TypeObject), pType)
+}
+
+// genericInstance returns the instance of the generic function for the types of parameters.
+// A new instance is compiled after the current tokens.
+func genericInstance(cmpl *compiler, name string, params []*core.TypeObject,
+	pos int) (core.IObject, error) {
+	gen := cmpl.generics[name]
+	if gen == nil {
+		tpl := cmpl.unit.Generics[name]
+		if tpl == nil {
+			return nil, nil
+		}
+		gen = &genericFunc{Generic: tpl, Instances: make(map[string]*core.FuncObject)}
+		cmpl.generics[name] = gen
+	}
+	if len(gen.Pars) != len(params) {
+		return nil, nil
+	}
+	prevParams := cmpl.typeParams
+	cmpl.typeParams = nil
+	defer func() {
+		cmpl.typeParams = prevParams
+	}()
+	binds := make(map[string]*core.TypeObject)
+	for i, par := range gen.Pars {
+		if !matchType(cmpl, gen.Generic, par, params[i], binds) {
+			return nil, nil
+		}
+	}
+	types := make(map[string]string)
+	names := make([]string, len(gen.Types))
+	for i, item := range gen.Types {
+		bind := binds[item]
+		if bind == nil {
+			return nil, cmpl.ErrorPos(pos, ErrGenericInfer, item, name)
+		}
+		types[item] = bind.GetName()
+		names[i] = types[item]
+	}
+	key := strings.Join(names, `#`)
+	if funcObj := gen.Instances[key]; funcObj != nil {
+		return funcObj, nil
+	}
+	cmpl.typeParams = types
+	funcObj := &core.FuncObject{
+		Object: core.Object{
+			Name: gen.Name,
+			Unit: cmpl.unit,
+		},
+		Block: core.CmdBlock{
+			CmdCommon: core.CmdCommon{TokenID: uint32(pos)},
+			ID:        core.StackBlock,
+			ParCount:  len(params),
+		},
+	}
+	funcObj.Block.Object = funcObj
+	for _, par := range gen.Pars {
+		obj, err := autoType(cmpl, par)
+		if err != nil {
+			return nil, cmpl.ErrorPos(pos, ErrType)
+		}
+		funcObj.Block.Vars = append(funcObj.Block.Vars, obj.(*core.TypeObject))
+	}
+	if len(gen.Result) > 0 {
+		obj, err := autoType(cmpl, gen.Result)
+		if err != nil {
+			return nil, cmpl.ErrorPos(pos, ErrType)
+		}
+		funcObj.Block.Result = obj.(*core.TypeObject)
+	}
+	curFunc := cmpl.curFunc
+	funcObj.ObjID = int32(cmpl.appendObj(funcObj))
+	cmpl.curFunc = curFunc
+	gen.Instances[key] = funcObj
+	cmpl.instances = append(cmpl.instances, genericInst{Func: funcObj, Types: types})
+	lp := cmpl.unit.Lexeme
+	if gen.Local == nil {
+		gen.Local = localTokens(lp, gen.Generic)
+	}
+	lp.Tokens = append(lp.Tokens, core.Token{Type: int32(tkLine), Offset: gen.Local[0].Offset})
+	lp.Tokens = append(lp.Tokens, gen.Local...)
+	return funcObj, nil
+}
+
+// localTokens returns the tokens of the template for the lexeme of the current unit.
+// The source and the strings of the template from another unit are appended to the lexeme.
+func localTokens(lp *core.Lex, gen *core.Generic) []core.Token {
+	src := gen.Unit.Lexeme
+	if src == lp {
+		return gen.Tokens
+	}
+	first, last := gen.Tokens[0], gen.Tokens[len(gen.Tokens)-1]
+	shift := len(lp.Source) - first.Offset
+	lp.Source = append(lp.Source, src.Source[first.Offset:last.Offset+last.Length]...)
+	lp.Lines = append(lp.Lines, first.Offset+shift)
+	for _, offset := range src.Lines {
+		if offset > first.Offset && offset < last.Offset+last.Length {
+			lp.Lines = append(lp.Lines, offset+shift)
+		}
+	}
+	tokens := make([]core.Token, len(gen.Tokens))
+	for i, token := range gen.Tokens {
+		token.Offset += shift
+		if token.Index != 0 {
+			lp.Strings = append(lp.Strings, src.Strings[token.Index])
+			token.Index = int32(len(lp.Strings) - 1)
+		}
+		tokens[i] = token
+	}
+	return tokens
+}
+
+// coGenericName starts the compilation of the next instance of the generic function
+func coGenericName(cmpl *compiler) error {
+	if cmpl.curInstance >= len(cmpl.instances) {
+		return cmpl.Error(ErrCompiler, `coGenericName`)
+	}
+	inst := cmpl.instances[cmpl.curInstance]
+	cmpl.curInstance++
+	cmpl.typeParams = inst.Types
+	block := &inst.Func.Block
+	block.Vars = nil
+	block.VarNames = nil
+	block.ParCount = 0
+	block.Result = nil
+	block.TokenID = uint32(cmpl.pos)
+	cmpl.owners = append(cmpl.owners, block)
+	cmpl.curFunc = int(inst.Func.ObjID)
+	lp := cmpl.unit.Lexeme
+	i := cmpl.pos + 1
+	for i < len(lp.Tokens) && lp.Tokens[i].Type != tkGreater {
+		i++
+	}
+	cmpl.newPos = i
+	return nil
+}
+
+// genericType replaces type parameters in the name of the type
+func genericType(cmpl *compiler, name string) string {
+	ins := strings.Split(name, `.`)
+	for i, item := range ins {
+		if bind, ok := cmpl.typeParams[item]; ok {
+			ins[i] = bind
+		}
+	}
+	return strings.Join(ins, `.`)
+}
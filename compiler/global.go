@@ -0,0 +1,57 @@
+// Copyright 2019 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package compiler
+
+import (
+	"github.com/gentee/gentee/core"
+)
+
+// coGlobal starts the declaration of global variable like var int count = 10
+func coGlobal(cmpl *compiler) error {
+	if getToken(cmpl.unit.Lexeme, cmpl.pos) != `var` {
+		return cmpl.Error(ErrDecl)
+	}
+	varObj := &core.VarObject{
+		Object: core.Object{
+			Unit: cmpl.unit,
+			Pub:  cmpl.unit.Pub != 0,
+		},
+	}
+	varObj.Block = core.CmdBlock{ID: core.StackBlock, Object: varObj,
+		CmdCommon: core.CmdCommon{TokenID: uint32(cmpl.pos)}}
+	varObj.ObjID = int32(cmpl.appendObj(varObj))
+	cmpl.owners = append(cmpl.owners, &varObj.Block)
+	return nil
+}
+
+// coGlobalName defines the name of global variable and compiles its initialization
+func coGlobalName(cmpl *compiler) error {
+	varObj := cmpl.curOwner().Object.(*core.VarObject)
+	varObj.Name = getToken(cmpl.unit.Lexeme, cmpl.pos)
+	if cmpl.unit.FindVar(varObj.Name) != nil {
+		return cmpl.Error(ErrGlobalExists, varObj.Name)
+	}
+	if err := coVarExp(cmpl); err != nil {
+		return err
+	}
+	cmpl.unit.AddVar(int(varObj.ObjID), varObj.Pub)
+	return nil
+}
+
+func coGlobalBack(cmpl *compiler) error {
+	cmpl.owners = cmpl.owners[:len(cmpl.owners)-1]
+	if cmpl.unit.Pub == core.PubOne {
+		cmpl.unit.Pub = 0
+	}
+	return nil
+}
+
+// findGlobal returns the block of global variable with the specified name
+func findGlobal(cmpl *compiler, token string) (*core.CmdBlock, int) {
+	if obj := cmpl.unit.FindVar(token); obj != nil {
+		return &obj.(*core.VarObject).Block, 0
+	}
+	return nil, 0
+}
@@ -14,6 +14,13 @@ type goStack struct {
 	LatestFunc int
 	Name       string
 	Params     []core.ICmd
+	NoResult   bool // go block has return without a value
+	// for function literals
+	Closure  *core.CmdBlock   // the block of the function literal
+	Outer    *core.CmdBlock   // the block where the function literal is defined
+	Captures []core.ICmd      // captured variables of the outer blocks
+	Type     *core.TypeObject // fn type of the function literal
+	Parallel *parallelFor     // parallel for which body is the function literal
 }
 
 func goExpPush(cmpl *compiler) string {
@@ -46,6 +53,19 @@ func coGoBack(cmpl *compiler) error {
 	cmpl.owners = cmpl.owners[:len(cmpl.owners)-1]
 	threadFunc := cmpl.latestFunc()
 	params := cmpl.goStack[len(cmpl.goStack)-1].Params
+	result := cmpl.unit.FindType(`thread`).(*core.TypeObject)
+	if threadFunc.Block.Result != nil {
+		children := threadFunc.Block.Children
+		if len(children) == 0 || children[len(children)-1].GetType() != core.CtStack ||
+			children[len(children)-1].(*core.CmdBlock).ID != core.StackReturn {
+			return cmpl.Error(ErrMustReturn)
+		}
+		future, err := autoType(cmpl, `future.`+threadFunc.Block.Result.GetName())
+		if err != nil {
+			return err
+		}
+		result = future.(*core.TypeObject)
+	}
 	goExpPop(cmpl)
 	cmpl.dynamic = &cmState{tkToken, cmExp, nil, nil, 0}
 	*cmpl.states = (*cmpl.states)[:len(*cmpl.states)-1]
@@ -58,7 +78,7 @@ func coGoBack(cmpl *compiler) error {
 
 	appendExp(cmpl, &core.CmdAnyFunc{CmdCommon: core.CmdCommon{TokenID: uint32(cmpl.pos)},
 		Children: params,
-		Object:   threadFunc, IsThread: true, Result: cmpl.unit.FindType(`thread`).(*core.TypeObject)})
+		Object:   threadFunc, IsThread: true, Result: result})
 	return coExpEnd(cmpl)
 }
 
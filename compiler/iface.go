@@ -0,0 +1,262 @@
+// Copyright 2019 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package compiler
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gentee/gentee/core"
+)
+
+// coInterface parses the declaration of interface type like
+// interface Shape { Area() float; Scale(float) }
+func coInterface(cmpl *compiler) error {
+	lp := cmpl.unit.Lexeme
+	i := cmpl.pos + 1
+	if i >= len(lp.Tokens) {
+		return cmpl.ErrorPos(len(lp.Tokens)-1, ErrEnd)
+	}
+	if lp.Tokens[i].Type != tkIdent {
+		return cmpl.ErrorPos(i, ErrName)
+	}
+	cmpl.pos = i
+	name, err := checkNewType(cmpl)
+	if err != nil {
+		return err
+	}
+	for i++; i < len(lp.Tokens) && lp.Tokens[i].Type == tkLine; i++ {
+	}
+	if i >= len(lp.Tokens) {
+		return cmpl.ErrorPos(len(lp.Tokens)-1, ErrEnd)
+	}
+	if lp.Tokens[i].Type != tkLCurly {
+		return cmpl.ErrorPos(i, ErrLCurly)
+	}
+	pType := cmpl.unit.NewType(name, reflect.TypeOf(core.Struct{}), nil).(*core.TypeObject)
+	pType.Custom = &core.StructType{
+		Fields: make(map[string]int64),
+		Types:  make([]*core.TypeObject, 0),
+	}
+	iface := &core.IfaceType{}
+	pType.Iface = iface
+	parType := func(i int) (*core.TypeObject, error) {
+		if lp.Tokens[i].Type != tkIdent {
+			return nil, cmpl.ErrorPos(i, ErrType)
+		}
+		cmpl.pos = i
+		obj, err := getType(cmpl)
+		if err != nil {
+			return nil, err
+		}
+		return obj.(*core.TypeObject), nil
+	}
+	for i++; i < len(lp.Tokens) && lp.Tokens[i].Type != tkRCurly; i++ {
+		switch lp.Tokens[i].Type {
+		case tkLine, tkComma:
+			continue
+		case tkIdent:
+		default:
+			return cmpl.ErrorPos(i, ErrName)
+		}
+		method := core.IfaceMethod{Name: getToken(lp, i)}
+		if strings.IndexRune(method.Name, '.') >= 0 {
+			return cmpl.ErrorPos(i, ErrIdent)
+		}
+		for _, item := range iface.Methods {
+			if item.Name == method.Name {
+				return cmpl.ErrorPos(i, ErrIfaceMethod, method.Name)
+			}
+		}
+		if i+1 >= len(lp.Tokens) {
+			return cmpl.ErrorPos(len(lp.Tokens)-1, ErrEnd)
+		}
+		if lp.Tokens[i+1].Type != tkLPar {
+			return cmpl.ErrorPos(i+1, ErrLPar)
+		}
+		for i += 2; i < len(lp.Tokens) && lp.Tokens[i].Type != tkRPar; i++ {
+			if lp.Tokens[i].Type == tkComma {
+				continue
+			}
+			par, err := parType(i)
+			if err != nil {
+				return err
+			}
+			method.Params = append(method.Params, par)
+		}
+		if i+1 >= len(lp.Tokens) {
+			return cmpl.ErrorPos(len(lp.Tokens)-1, ErrEnd)
+		}
+		if lp.Tokens[i+1].Type == tkIdent {
+			i++
+			if method.Result, err = parType(i); err != nil {
+				return err
+			}
+		}
+		iface.Methods = append(iface.Methods, method)
+	}
+	if i >= len(lp.Tokens) {
+		return cmpl.ErrorPos(len(lp.Tokens)-1, ErrEnd)
+	}
+	if len(iface.Methods) == 0 {
+		return cmpl.ErrorPos(i, ErrName)
+	}
+	cmpl.newPos = i
+	return nil
+}
+
+// coMethod parses the receiver of the method like func (Point p) Dist() float
+func coMethod(cmpl *compiler) error {
+	lp := cmpl.unit.Lexeme
+	i := cmpl.pos
+	if i+4 >= len(lp.Tokens) {
+		return cmpl.ErrorPos(len(lp.Tokens)-1, ErrEnd)
+	}
+	if lp.Tokens[i+1].Type != tkIdent {
+		return cmpl.ErrorPos(i+1, ErrType)
+	}
+	if lp.Tokens[i+2].Type != tkIdent {
+		return cmpl.ErrorPos(i+2, ErrName)
+	}
+	if lp.Tokens[i+3].Type != tkRPar {
+		return cmpl.ErrorPos(i+3, ErrNotRPar)
+	}
+	if lp.Tokens[i+4].Type != tkIdent {
+		return cmpl.ErrorPos(i+4, ErrName)
+	}
+	if i+5 < len(lp.Tokens) && lp.Tokens[i+5].Type == tkLess {
+		return cmpl.ErrorPos(i+5, ErrGeneric)
+	}
+	cmpl.pos = i + 1
+	obj, err := getType(cmpl)
+	if err != nil {
+		return err
+	}
+	if obj.(*core.TypeObject).Custom == nil {
+		return cmpl.Error(ErrReceiver, obj.GetName())
+	}
+	cmpl.pos = i + 4
+	if err = coFuncName(cmpl); err != nil {
+		return err
+	}
+	cmpl.curType = obj.(*core.TypeObject)
+	cmpl.pos = i + 2
+	if err = coVar(cmpl); err != nil {
+		return err
+	}
+	cmpl.newPos = i + 4
+	return nil
+}
+
+// ifaceImpl returns the functions of the struct type which implement the methods of interface
+func ifaceImpl(cmpl *compiler, iface, pType *core.TypeObject) ([]core.IObject, string) {
+	if pType.Custom == nil || pType.Iface != nil {
+		return nil, ``
+	}
+	funcs := make([]core.IObject, len(iface.Iface.Methods))
+	for i, method := range iface.Iface.Methods {
+		obj := getFunc(cmpl, method.Name, append([]*core.TypeObject{pType}, method.Params...))
+		if obj == nil || obj.GetType() != core.ObjFunc ||
+			!isEqualTypes(obj.Result(), method.Result) {
+			return nil, method.Name
+		}
+		funcs[i] = obj
+	}
+	return funcs, ``
+}
+
+// ifaceAssign checks that the struct type implements interface and registers it
+func ifaceAssign(cmpl *compiler, iface, pType *core.TypeObject) bool {
+	if iface.Iface == nil {
+		return false
+	}
+	for _, item := range iface.Iface.Impls {
+		if item.Type == pType {
+			return true
+		}
+	}
+	funcs, _ := ifaceImpl(cmpl, iface, pType)
+	if funcs == nil {
+		return false
+	}
+	iface.Iface.Impls = append(iface.Iface.Impls, core.IfaceImpl{Type: pType, Funcs: funcs})
+	return true
+}
+
+// ifaceAssignError returns the error of assigning the value to the variable of interface type
+func ifaceAssignError(cmpl *compiler, pos int, iface, pType *core.TypeObject) error {
+	if iface.Iface != nil {
+		if _, method := ifaceImpl(cmpl, iface, pType); len(method) > 0 {
+			return cmpl.ErrorPos(pos, ErrIfaceImpl, pType.GetName(), iface.GetName(), method)
+		}
+	}
+	return cmpl.ErrorPos(pos, ErrStructAssign, pType.GetName(), iface.GetName())
+}
+
+// ifaceFunc looks for the function which has interface parameters for the specified types
+func ifaceFunc(cmpl *compiler, name string, params []*core.TypeObject) core.IObject {
+	var keys []string
+	prefix := `#` + name + `#`
+	for key := range cmpl.unit.NameSpace {
+		if strings.HasPrefix(key, prefix) && strings.Count(key, `#`) == len(params)+1 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		pars := make([]*core.TypeObject, len(params))
+		isIface := false
+		for i, parName := range strings.Split(key[len(prefix):], `#`) {
+			obj := cmpl.unit.FindType(parName)
+			if obj == nil {
+				isIface = false
+				break
+			}
+			pars[i] = obj.(*core.TypeObject)
+			if pars[i] != params[i] {
+				if pars[i].Iface == nil || params[i].Custom == nil {
+					isIface = false
+					break
+				}
+				if funcs, _ := ifaceImpl(cmpl, pars[i], params[i]); funcs == nil {
+					isIface = false
+					break
+				}
+				isIface = true
+			}
+		}
+		if !isIface {
+			continue
+		}
+		for i, par := range pars {
+			if par != params[i] {
+				ifaceAssign(cmpl, par, params[i])
+			}
+		}
+		return getFunc(cmpl, name, pars)
+	}
+	return nil
+}
+
+// ifaceMethod returns the command which gets the function of the method for interface value
+func ifaceMethod(cmpl *compiler, name string, params []*core.TypeObject, pos int) core.ICmd {
+	if len(params) == 0 || params[0].Iface == nil {
+		return nil
+	}
+	for i, method := range params[0].Iface.Methods {
+		if method.Name != name || len(method.Params) != len(params)-1 {
+			continue
+		}
+		for j, par := range method.Params {
+			if !isEqualTypes(par, params[j+1]) {
+				return nil
+			}
+		}
+		return &core.CmdBlock{ID: core.StackIface, Object: params[0], ParCount: i,
+			Vars: params[1:], Result: method.Result, CmdCommon: core.CmdCommon{TokenID: uint32(pos)}}
+	}
+	return nil
+}
@@ -5,36 +5,121 @@
 package compiler
 
 import (
+	"io/fs"
 	"io/ioutil"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
 
 	"github.com/gentee/gentee/core"
 )
 
 // CompileFile compiles the source file
-func CompileFile(ws *core.Workspace, filename string) (unitID int, err error) {
+func CompileFile(ws *core.Workspace, filename string) (int, error) {
+	ws.Lock()
+	defer ws.Unlock()
+	return compileFile(ws, filename)
+}
+
+// fullName returns the unique name of the source file. It is an absolute path
+// for the OS file system and a slash-separated unrooted path for ws.FS.
+func fullName(ws *core.Workspace, filename string) (string, error) {
+	if ws.FS == nil {
+		return filepath.Abs(filename)
+	}
+	name := path.Clean(strings.TrimLeft(filepath.ToSlash(filename), `/`))
+	if !fs.ValidPath(name) {
+		return ``, &fs.PathError{Op: `open`, Path: filename, Err: fs.ErrInvalid}
+	}
+	return name, nil
+}
+
+func isAbsPath(ws *core.Workspace, filename string) bool {
+	if ws.FS == nil {
+		return filepath.IsAbs(filename)
+	}
+	return strings.HasPrefix(filepath.ToSlash(filename), `/`)
+}
+
+func dirPath(ws *core.Workspace, filename string) string {
+	if ws.FS == nil {
+		return filepath.Dir(filename)
+	}
+	return path.Dir(filepath.ToSlash(filename))
+}
+
+// joinPath joins the folder and the relative file name
+func joinPath(ws *core.Workspace, dir, filename string) string {
+	if ws.FS == nil {
+		return filepath.Join(dir, filename)
+	}
+	return path.Join(filepath.ToSlash(dir), filepath.ToSlash(filename))
+}
+
+func existFile(ws *core.Workspace, filename string) bool {
 	var (
-		absname, curDir string
-		input           []byte
+		finfo fs.FileInfo
+		err   error
 	)
-	if absname, err = filepath.Abs(filename); err != nil {
-		return
+	if ws.FS == nil {
+		finfo, err = os.Stat(filename)
+	} else if filename, err = fullName(ws, filename); err == nil {
+		finfo, err = fs.Stat(ws.FS, filename)
 	}
-	if unitID = ws.Linked[absname]; unitID != 0 {
-		return
+	return err == nil && !finfo.IsDir()
+}
+
+func readSource(ws *core.Workspace, name string) ([]byte, error) {
+	if ws.FS == nil {
+		return ioutil.ReadFile(name)
+	}
+	return fs.ReadFile(ws.FS, name)
+}
+
+// resolveFile returns the path of the include file. The relative name is looked for
+// in the folder of the current unit, in the modules of gentee.mod and in the search path.
+func resolveFile(ws *core.Workspace, unitPath, filename string) string {
+	if isAbsPath(ws, filename) {
+		return filename
+	}
+	dir := `.`
+	if len(unitPath) > 0 {
+		dir = dirPath(ws, unitPath)
+	}
+	local := joinPath(ws, dir, filename)
+	if existFile(ws, local) {
+		return local
 	}
-	if curDir, err = os.Getwd(); err != nil {
+	if modFile := findModule(ws, dir, filename); len(modFile) > 0 && existFile(ws, modFile) {
+		return modFile
+	}
+	for _, search := range ws.SearchPath {
+		if len(search) == 0 {
+			continue
+		}
+		if name := joinPath(ws, search, filename); existFile(ws, name) {
+			return name
+		}
+	}
+	return local
+}
+
+func compileFile(ws *core.Workspace, filename string) (unitID int, err error) {
+	var (
+		absname string
+		input   []byte
+	)
+	if absname, err = fullName(ws, filename); err != nil {
 		return
 	}
-	if err = os.Chdir(filepath.Dir(absname)); err != nil {
+	if unitID = ws.Linked[absname]; unitID != 0 {
 		return
 	}
-	defer os.Chdir(curDir)
-	if input, err = ioutil.ReadFile(absname); err != nil {
+	if input, err = readSource(ws, absname); err != nil {
 		return
 	}
-	unitID, err = Compile(ws, string(input), absname)
+	unitID, err = compile(ws, string(input), absname)
 	if err == nil {
 		ws.Linked[absname] = unitID
 	}
@@ -74,11 +159,17 @@ func coIncludeImport(cmpl *compiler) error {
 		}
 	}
 	includeFile := os.ExpandEnv(v.(string))
-	unitID, err = CompileFile(cmpl.ws, includeFile)
+	alias, err := coAlias(cmpl)
+	if err != nil {
+		return err
+	}
+	unitID, err = compileFile(cmpl.ws, resolveFile(cmpl.ws, lp.Path, includeFile))
 	if err != nil && unitID == 0 {
 		return cmpl.Error(ErrIncludeFile, includeFile)
 	}
-	if err == nil {
+	if err == nil && len(alias) > 0 {
+		err = cmpl.aliasNameSpace(cmpl.ws.Units[unitID], alias)
+	} else if err == nil {
 		if v, ok := cmpl.unit.Included[uint32(unitID)]; !ok || (v && !cmpl.isImport) {
 			err = cmpl.copyNameSpace(cmpl.ws.Units[unitID], cmpl.isImport)
 			cmpl.unit.Included[uint32(unitID)] = cmpl.isImport
@@ -86,3 +177,27 @@ func coIncludeImport(cmpl *compiler) error {
 	}
 	return err
 }
+
+// coAlias reads 'as alias' after the name of the imported file
+func coAlias(cmpl *compiler) (string, error) {
+	lp := cmpl.unit.Lexeme
+	if len(lp.Tokens) <= cmpl.pos+1 || lp.Tokens[cmpl.pos+1].Type != tkIdent ||
+		getToken(lp, cmpl.pos+1) != `as` {
+		return ``, nil
+	}
+	if !cmpl.isImport {
+		return ``, cmpl.ErrorPos(cmpl.pos+1, ErrAliasInclude)
+	}
+	if len(lp.Tokens) <= cmpl.pos+2 || lp.Tokens[cmpl.pos+2].Type != tkIdent {
+		return ``, cmpl.ErrorPos(cmpl.pos+2, ErrName)
+	}
+	alias := getToken(lp, cmpl.pos+2)
+	if strings.IndexRune(alias, '.') >= 0 || isCapital(alias) {
+		return ``, cmpl.ErrorPos(cmpl.pos+2, ErrIdent)
+	}
+	if cmpl.aliases[alias] {
+		return ``, cmpl.ErrorPos(cmpl.pos+2, ErrAlias, alias)
+	}
+	cmpl.newPos = cmpl.pos + 2
+	return alias, nil
+}
@@ -69,7 +69,8 @@ func coInitEnd(cmpl *compiler) error {
 				}
 			} else {
 				ind := item.(*core.CmdBinary).Left.(*core.CmdValue).Value.(int64)
-				if ownerType.Custom.Types[ind] != item.(*core.CmdBinary).Right.GetResult() {
+				if ownerType.Custom.Types[ind] != item.(*core.CmdBinary).Right.GetResult() &&
+					!ifaceAssign(cmpl, ownerType.Custom.Types[ind], item.(*core.CmdBinary).Right.GetResult()) {
 					return cmpl.ErrorPos(item.(*core.CmdBinary).Right.GetToken(),
 						ErrWrongType, ownerType.Custom.Types[ind].GetName())
 				}
@@ -77,8 +78,9 @@ func coInitEnd(cmpl *compiler) error {
 		}
 	}
 	cmpl.curType = cmpl.curOwner().Result
+	isLiteral := len(cmpl.literals) > 0 && cmpl.literals[len(cmpl.literals)-1].Block == cmpl.curOwner()
 	cmpl.owners = cmpl.owners[:len(cmpl.owners)-1]
-	if (*cmpl.states)[len(*cmpl.states)-1].State != cmInit {
+	if !isLiteral && (*cmpl.states)[len(*cmpl.states)-1].State != cmInit {
 		cmpl.owners = cmpl.owners[:len(cmpl.owners)-1]
 	}
 	cmpl.inits--
@@ -176,6 +178,11 @@ func initStructEnd(cmpl *compiler) error {
 		if ind, ok = block.GetResult().Custom.Fields[fieldName]; !ok {
 			return cmpl.ErrorPos(cmpl.pos-1, ErrWrongField, fieldName, block.GetResult().GetName())
 		}
+		for _, prev := range block.Children[:i] {
+			if prev.(*core.CmdBinary).Left.(*core.CmdValue).Value == ind {
+				return cmpl.ErrorPos(item.GetToken(), ErrStructField, fieldName)
+			}
+		}
 		item.(*core.CmdValue).Value = ind
 
 		cmd := &core.CmdBinary{CmdCommon: core.CmdCommon{TokenID: uint32(item.GetToken())},
@@ -205,3 +212,53 @@ func coInitKey(cmpl *compiler) error {
 	}
 	return nil
 }
+
+// literalInfo stores the state of the expression while the struct literal is compiled
+type literalInfo struct {
+	Block   *core.CmdBlock
+	Started bool
+	Exp     []core.ICmd
+	ExpBuf  []ExpBuf
+	CurType *core.TypeObject
+}
+
+// coExpLiteral pushes the struct literal like Point{x: 1, y: 2} into the expression
+func coExpLiteral(cmpl *compiler) error {
+	obj := cmpl.unit.FindType(getToken(cmpl.unit.Lexeme, cmpl.pos-1))
+	if obj == nil || obj.(*core.TypeObject).Custom == nil || obj.(*core.TypeObject).Iface != nil {
+		return coExpVar(cmpl)
+	}
+	cmd := &core.CmdBlock{ID: core.StackNew, CmdCommon: core.CmdCommon{
+		TokenID: uint32(cmpl.pos - 1)}, Result: obj.(*core.TypeObject), Parent: cmpl.curOwner()}
+	appendExp(cmpl, cmd)
+	cmpl.literals = append(cmpl.literals, literalInfo{Block: cmd})
+	return nil
+}
+
+// coExpCurly starts the initialization of the struct literal
+func coExpCurly(cmpl *compiler) error {
+	if len(cmpl.literals) == 0 || cmpl.literals[len(cmpl.literals)-1].Started {
+		return nil
+	}
+	literal := &cmpl.literals[len(cmpl.literals)-1]
+	literal.Started = true
+	literal.Exp = cmpl.exp
+	literal.ExpBuf = cmpl.expbuf
+	literal.CurType = cmpl.curType
+	cmpl.exp = make([]core.ICmd, 0, 32)
+	cmpl.expbuf = make([]ExpBuf, 0, 32)
+	cmpl.owners = append(cmpl.owners, literal.Block)
+	cmpl.inits++
+	cmpl.dynamic = &cmState{tkLCurly, cmInit, nil, coLiteralEnd, cfStopBack}
+	return nil
+}
+
+// coLiteralEnd restores the expression after the struct literal
+func coLiteralEnd(cmpl *compiler) error {
+	literal := cmpl.literals[len(cmpl.literals)-1]
+	cmpl.literals = cmpl.literals[:len(cmpl.literals)-1]
+	cmpl.exp = literal.Exp
+	cmpl.expbuf = literal.ExpBuf
+	cmpl.curType = literal.CurType
+	return nil
+}
@@ -68,34 +68,40 @@ const (
 
 var (
 	keywords = map[string]int{
-		`break`:    tkBreak,
-		`continue`: tkContinue,
-		`elif`:     tkElif,
-		`else`:     tkElse,
-		`false`:    tkFalse,
-		`for`:      tkFor,
-		`func`:     tkFunc,
-		`if`:       tkIf,
-		`in`:       tkIn,
-		`while`:    tkWhile,
-		`return`:   tkReturn,
-		`run`:      tkRun,
-		`true`:     tkTrue,
-		`const`:    tkConst,
-		`struct`:   tkStruct,
-		`switch`:   tkSwitch,
-		`case`:     tkCase,
-		`include`:  tkInclude,
-		`import`:   tkImport,
-		`pub`:      tkPub,
-		`fn`:       tkFn,
-		`go`:       tkGo,
-		`local`:    tkLocal,
-		`try`:      tkTry,
-		`catch`:    tkCatch,
-		`recover`:  tkRecover,
-		`retry`:    tkRetry,
-		`default`:  tkDefault,
+		`break`:     tkBreak,
+		`continue`:  tkContinue,
+		`elif`:      tkElif,
+		`else`:      tkElse,
+		`false`:     tkFalse,
+		`for`:       tkFor,
+		`func`:      tkFunc,
+		`if`:        tkIf,
+		`in`:        tkIn,
+		`while`:     tkWhile,
+		`return`:    tkReturn,
+		`run`:       tkRun,
+		`true`:      tkTrue,
+		`const`:     tkConst,
+		`struct`:    tkStruct,
+		`switch`:    tkSwitch,
+		`case`:      tkCase,
+		`include`:   tkInclude,
+		`import`:    tkImport,
+		`pub`:       tkPub,
+		`fn`:        tkFn,
+		`go`:        tkGo,
+		`local`:     tkLocal,
+		`try`:       tkTry,
+		`catch`:     tkCatch,
+		`recover`:   tkRecover,
+		`retry`:     tkRetry,
+		`default`:   tkDefault,
+		`defer`:     tkDefer,
+		`finally`:   tkFinally,
+		`enum`:      tkEnum,
+		`interface`: tkInterface,
+		`select`:    tkSelect,
+		`parallel`:  tkParallel,
 	}
 
 	charType [alphabet]int
@@ -132,6 +138,7 @@ var (
 			{'L', lexIdent, newIdent},
 			{'D', lexInt, newInt},
 			{'0', lexOctHex, newInt},
+			{'_', 0, newDiscard},
 		},
 		lexIdent: { // identifier
 			{[]rune{'L', 'D', '_', '.'}, 0, nil},
@@ -347,6 +354,16 @@ func newSymbol(lex *lexEngine, start, off int) {
 	lex.Lex.NewTokens(off, oper2tk[string(lex.Lex.Source[off:off+1])])
 }
 
+// newDiscard appends the blank identifier _ which is used to discard values
+func newDiscard(lex *lexEngine, start, off int) {
+	next := lex.Lex.Source[off+1]
+	if next == '_' || unicode.IsLetter(next) || unicode.IsDigit(next) {
+		lex.State = lexError | ErrLetter
+		return
+	}
+	lex.Lex.NewToken(tkIdent, off, 1)
+}
+
 func newOper(lex *lexEngine, start, off int) {
 	if lex.Callback {
 		return
@@ -46,6 +46,16 @@ func isCapital(name string) bool {
 	return true
 }
 
+// isAliasConst returns true if the name is alias.CONST
+func isAliasConst(cmpl *compiler, name string) bool {
+	names := strings.SplitN(name, `.`, 2)
+	if len(names) != 2 || !cmpl.aliases[names[0]] || !isCapital(names[1]) {
+		return false
+	}
+	block, _ := findVar(cmpl, names[0])
+	return block == nil
+}
+
 func unNewLine(in string) (string, error) {
 	return strconv.Unquote(`"` + strings.Replace(strings.Replace(in, "\n", `\n`, -1),
 		"\r", `\r`, -1) + `"`)
@@ -76,6 +86,23 @@ func (cmpl *compiler) copyNameSpace(srcUnit *core.Unit, imported bool) error {
 		}
 		cmpl.unit.NameSpace[key] = item
 	}
+	for name, gen := range srcUnit.Generics {
+		if gen.Imported || (imported && !gen.Pub) {
+			continue
+		}
+		if _, ok := cmpl.unit.Included[gen.Unit.Index]; ok {
+			continue
+		}
+		if _, ok := cmpl.unit.Generics[name]; ok {
+			return cmpl.Error(ErrGenericExists, name)
+		}
+		if imported {
+			copyGen := *gen
+			copyGen.Imported = true
+			gen = &copyGen
+		}
+		cmpl.unit.Generics[name] = gen
+	}
 	for index, itype := range srcUnit.Included {
 		if itype {
 			continue
@@ -86,3 +113,43 @@ func (cmpl *compiler) copyNameSpace(srcUnit *core.Unit, imported bool) error {
 	}
 	return nil
 }
+
+// aliasNameSpace appends public objects of the unit with the alias prefix
+func (cmpl *compiler) aliasNameSpace(srcUnit *core.Unit, alias string) error {
+	for key, item := range srcUnit.NameSpace {
+		if (item&core.NSImported) != 0 || (item&core.NSPub) == 0 {
+			continue
+		}
+		key = key[:1] + alias + `.` + key[1:]
+		if ind, ok := cmpl.unit.NameSpace[key]; ok {
+			return cmpl.Error(ErrDupObject, cmpl.unit.GetObj(ind).GetName())
+		}
+		cmpl.unit.NameSpace[key] = (item & core.NSIndex) | core.NSImported
+	}
+	for name, gen := range srcUnit.Generics {
+		if gen.Imported || !gen.Pub {
+			continue
+		}
+		name = alias + `.` + name
+		if _, ok := cmpl.unit.Generics[name]; ok {
+			return cmpl.Error(ErrGenericExists, name)
+		}
+		copyGen := *gen
+		copyGen.Imported = true
+		cmpl.unit.Generics[name] = &copyGen
+	}
+	cmpl.aliases[alias] = true
+	return nil
+}
+
+// aliasName is the value of the placeholder for the alias in alias.Func()
+type aliasName string
+
+// isAlias returns the alias if the first command is its placeholder
+func isAlias(cmds []core.ICmd) (string, bool) {
+	if len(cmds) == 0 || cmds[0].GetType() != core.CtValue {
+		return ``, false
+	}
+	alias, ok := cmds[0].(*core.CmdValue).Value.(aliasName)
+	return string(alias), ok
+}
@@ -17,13 +17,16 @@ import (
 type BlockInfo struct {
 	Block   *core.CmdBlock
 	Vars    []int
+	Cells   map[int]int // the indexes of the heap cells of the captured variables
 	IsLocal bool
 }
 
 // Linker is the main structure of the linker
 type Linker struct {
-	Blocks []BlockInfo
-	Lex    *core.Lex
+	Blocks   []BlockInfo
+	Lex      *core.Lex
+	Optimize bool         // the bytecode optimizer is enabled
+	jumps    map[int]bool // the positions of the jumps for the jump threading
 }
 
 // Int32Slice is a slice of int32
@@ -36,6 +39,8 @@ func (p Int32Slice) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
 // Link creates a bytecode
 func Link(ws *core.Workspace, unitID int) (*core.Exec, error) {
 	var exec *core.Exec
+	ws.Lock()
+	defer ws.Unlock()
 	if unitID < 0 || unitID >= len(ws.Units) {
 		return nil, fmt.Errorf(errText[ErrLinkIndex], unitID)
 	}
@@ -70,6 +75,9 @@ func Link(ws *core.Workspace, unitID int) (*core.Exec, error) {
 		for _, id := range usedCode.Init {
 			exec.Init = append(exec.Init, id)
 		}
+		if ws.Objects[ikey].GetType() == core.ObjVar {
+			exec.Globals = append(exec.Globals, ikey)
+		}
 		var retype []uint16
 		rebuild := make([]uint16, len(usedCode.Strings))
 		if len(usedCode.Structs) > 0 {
@@ -131,6 +139,7 @@ func Link(ws *core.Workspace, unitID int) (*core.Exec, error) {
 		}
 	}
 	sort.Sort(Int32Slice(exec.Init))
+	sort.Sort(Int32Slice(exec.Globals))
 	if len(exec.Init) > 0 && exec.Init[0] != ws.IotaID {
 		exec.Init = append([]int32{ws.IotaID}, exec.Init...)
 	}
@@ -156,6 +165,30 @@ func copyUsed(src, dest *core.Bytecode) {
 	}
 }
 
+func usedFunc(funcObj *core.FuncObject, out *core.Bytecode) {
+	id := funcObj.ObjID
+	if out.Used == nil {
+		out.Used = make(map[int32]byte)
+	}
+	if out.Used[id] == 0 {
+		genBytecode(funcObj.Unit.VM, id)
+		copyUsed(&funcObj.BCode, out)
+		out.Used[id] = 1
+	}
+}
+
+func usedGlobal(varObj *core.VarObject, out *core.Bytecode) {
+	id := varObj.ObjID
+	if out.Used == nil {
+		out.Used = make(map[int32]byte)
+	}
+	if out.Used[id] == 0 {
+		genBytecode(varObj.Unit.VM, id)
+		copyUsed(&varObj.BCode, out)
+		out.Used[id] = 1
+	}
+}
+
 func structOffset(out *core.Bytecode, shift int) {
 	out.StructsOffset = append(out.StructsOffset, int32(shift))
 }
@@ -192,17 +225,27 @@ func initBlock(linker *Linker, cmd *core.CmdBlock, out *core.Bytecode) (BlockInf
 	if flags&core.BlRetry != 0 {
 		push(0)
 	}
+	// the cells of the captured variables are appended to the variables of the block
+	var cells int
+	for _, captured := range cmd.Heap {
+		if !captured {
+			cells++
+		}
+	}
 	if cmd.ParCount > 0 || flags&core.BlVars != 0 {
-		push(core.Bcode(cmd.ParCount<<16 | len(cmd.Vars)))
+		push(core.Bcode(cmd.ParCount<<16 | (len(cmd.Vars) + cells)))
 	}
 	var types []core.Bcode
 	if len(cmd.Vars) > 0 {
-		types = make([]core.Bcode, len(cmd.Vars))
+		types = make([]core.Bcode, len(cmd.Vars), len(cmd.Vars)+cells)
 		var sInt, sStr, sFloat, sAny int
 		bInfo.Vars = make([]int, len(cmd.Vars))
 		lenCode := len(out.Code)
 		for i, ivar := range cmd.Vars {
 			types[i] = type2Code(ivar, out)
+			if cmd.Heap[i] {
+				types[i] = core.TYPEBOX
+			}
 			switch types[i] & 0xf {
 			case core.STACKSTR:
 				bInfo.Vars[i] = sStr
@@ -221,6 +264,22 @@ func initBlock(linker *Linker, cmd *core.CmdBlock, out *core.Bytecode) (BlockInf
 				sInt++
 			}
 		}
+		if len(cmd.Heap) > 0 {
+			bInfo.Cells = make(map[int]int)
+			for i := range cmd.Vars {
+				captured, ok := cmd.Heap[i]
+				if !ok {
+					continue
+				}
+				if captured {
+					bInfo.Cells[i] = bInfo.Vars[i]
+				} else {
+					types = append(types, core.Bcode(i<<16)|core.TYPEBOX)
+					bInfo.Cells[i] = sAny
+					sAny++
+				}
+			}
+		}
 		push(types...)
 	}
 	linker.Blocks = append(linker.Blocks, bInfo)
@@ -251,6 +310,12 @@ func type2Code(itype *core.TypeObject, out *core.Bytecode) (retType core.Bcode)
 		retType = core.TYPEFILE
 	case reflect.TypeOf(nil):
 		retType = core.TYPEHANDLE
+	case reflect.TypeOf(core.Chan{}):
+		retType = core.TYPECHAN
+	case reflect.TypeOf(core.Future{}):
+		retType = core.TYPEFUTURE
+	case reflect.TypeOf(core.Sync{}):
+		retType = core.TYPESYNC
 	case reflect.TypeOf(core.Fn{}):
 		retType = core.TYPEFUNC
 	case reflect.TypeOf(core.RuntimeError{}):
@@ -282,6 +347,22 @@ func type2Code(itype *core.TypeObject, out *core.Bytecode) (retType core.Bcode)
 			for name, i := range itype.Custom.Fields {
 				sInfo.Keys[i] = name
 			}
+			if len(itype.Custom.Defaults) > 0 {
+				sInfo.Defaults = make([]interface{}, len(itype.Custom.Types))
+				for i, v := range itype.Custom.Defaults {
+					switch value := v.(type) {
+					case bool:
+						if value {
+							v = int64(1)
+						} else {
+							v = int64(0)
+						}
+					case rune:
+						v = int64(value)
+					}
+					sInfo.Defaults[i] = v
+				}
+			}
 			ind = uint16(len(out.StructsList))
 			if ind == 0 {
 				out.Structs = make(map[string]uint16)
@@ -330,6 +411,7 @@ func getPos(linker *Linker, cmd core.ICmd, out *core.Bytecode) {
 func genBytecode(ws *core.Workspace, idObj int32) *core.Bytecode {
 	var (
 		block   core.ICmd
+		varObj  *core.VarObject
 		isConst bool
 	)
 	bcode := ws.Objects[idObj].GetCode()
@@ -350,13 +432,27 @@ func genBytecode(ws *core.Workspace, idObj int32) *core.Bytecode {
 			bcode.Code = append(bcode.Code, core.Bcode((constObj.Iota+1)<<16)|core.IOTA)
 		}
 		isConst = true
+	case core.ObjVar:
+		varObj = ws.Objects[idObj].(*core.VarObject)
 	}
 	type2Code(ws.StdLib().FindType(`trace`).(*core.TypeObject), bcode)
 	type2Code(ws.StdLib().FindType(`time`).(*core.TypeObject), bcode)
 	type2Code(ws.StdLib().FindType(`finfo`).(*core.TypeObject), bcode)
 	type2Code(ws.StdLib().FindType(`hinfo`).(*core.TypeObject), bcode)
 
-	cmd2Code(&Linker{Lex: ws.Objects[idObj].GetLex()}, block, bcode)
+	linker := &Linker{Lex: ws.Objects[idObj].GetLex(), Optimize: !ws.NoOptimize}
+	if varObj != nil {
+		varType := type2Code(varObj.Result(), bcode)
+		bcode.Code = append(bcode.Code, core.GLOBAL, core.Bcode(idObj), varType)
+		if varType >= core.TYPESTRUCT {
+			structOffset(bcode, len(bcode.Code)-1)
+		}
+		for _, item := range varObj.Block.Children {
+			cmd2Code(linker, item, bcode)
+		}
+	} else {
+		cmd2Code(linker, block, bcode)
+	}
 	if isConst {
 		resType := type2Code(block.GetResult(), bcode)
 		bcode.Code = append(bcode.Code, (resType<<16)|core.RET)
@@ -366,6 +462,9 @@ func genBytecode(ws *core.Workspace, idObj int32) *core.Bytecode {
 	} else {
 		bcode.Code = append(bcode.Code, core.END)
 	}
+	if linker.Optimize {
+		threadJumps(linker, bcode)
+	}
 	//	fmt.Println(`CODE`, bcode.Code)
 	return bcode
 }
@@ -10,11 +10,23 @@ import (
 	"github.com/gentee/gentee/core"
 )
 
+// funcOwners returns the owners of the current function. Function literals and go blocks
+// are compiled as separate functions.
+func funcOwners(cmpl *compiler) []core.ICmd {
+	for i := len(cmpl.owners) - 1; i > 0; i-- {
+		if cmpl.owners[i].(*core.CmdBlock).Object != nil {
+			return cmpl.owners[i:]
+		}
+	}
+	return cmpl.owners
+}
+
 func getLocalBlock(cmpl *compiler) *core.CmdBlock {
-	for i := len(cmpl.owners) - 1; i >= 0; i-- {
-		block := cmpl.owners[i].(*core.CmdBlock)
+	owners := funcOwners(cmpl)
+	for i := len(owners) - 1; i >= 0; i-- {
+		block := owners[i].(*core.CmdBlock)
 		if block.ID == core.StackBlock && block.Parent != nil && block.Parent.ID == core.StackLocal {
-			return cmpl.owners[i].(*core.CmdBlock)
+			return owners[i].(*core.CmdBlock)
 		}
 	}
 	return nil
@@ -88,7 +100,7 @@ func coLocalStart(cmpl *compiler) error {
 }
 
 func getLocal(cmpl *compiler, name string, params []*core.TypeObject) (cmd core.ICmd) {
-	for _, owner := range cmpl.owners {
+	for _, owner := range funcOwners(cmpl) {
 		block := owner.(*core.CmdBlock)
 		if ind, ok := block.LocalNames[name]; ok {
 			local := block.Locals[ind].(*core.CmdBlock)
@@ -0,0 +1,111 @@
+// Copyright 2019 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package compiler
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/gentee/gentee/core"
+)
+
+var reVersion = regexp.MustCompile(`^v\d+(\.\d+)*(-[\w\.]+)?$`)
+
+// modCache returns the folder of the module cache
+func modCache() string {
+	if dir := os.Getenv(core.EnvModCache); len(dir) > 0 {
+		return dir
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ``
+	}
+	return filepath.Join(dir, `gentee`, `mod`)
+}
+
+// readModFile reads the module list of gentee.mod file. Each line of the file contains
+// the name of the module and the path to its folder (or the main file) or the version
+// of the module in the module cache. Lines beginning with # or // are comments.
+func readModFile(ws *core.Workspace, dir string) (map[string]string, bool) {
+	modFile := joinPath(ws, dir, core.ModFile)
+	if !existFile(ws, modFile) {
+		return nil, false
+	}
+	name, err := fullName(ws, modFile)
+	if err != nil {
+		return nil, false
+	}
+	input, err := readSource(ws, name)
+	if err != nil {
+		return nil, false
+	}
+	mods := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(input))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || line[0] == '#' || strings.HasPrefix(line, `//`) {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		target := fields[1]
+		if reVersion.MatchString(target) {
+			// versions are stored in the module cache of the OS file system
+			if ws.FS != nil || len(modCache()) == 0 {
+				continue
+			}
+			target = filepath.Join(modCache(), fields[0]+`@`+target)
+		} else if !isAbsPath(ws, target) {
+			target = joinPath(ws, dir, target)
+		}
+		mods[fields[0]] = target
+	}
+	return mods, true
+}
+
+// findModule returns the path of the file from the module. The nearest gentee.mod file
+// is searched in the folder and its parents.
+func findModule(ws *core.Workspace, dir, filename string) string {
+	var mods map[string]string
+
+	names := strings.SplitN(filepath.ToSlash(filename), `/`, 2)
+	if names[0] == `.` || names[0] == `..` {
+		return ``
+	}
+	if ws.FS == nil {
+		var err error
+		if dir, err = filepath.Abs(dir); err != nil {
+			return ``
+		}
+	}
+	for {
+		var ok bool
+		if mods, ok = readModFile(ws, dir); ok {
+			break
+		}
+		parent := dirPath(ws, dir)
+		if parent == dir {
+			return ``
+		}
+		dir = parent
+	}
+	target, ok := mods[names[0]]
+	if !ok {
+		return ``
+	}
+	if len(names) == 2 {
+		return joinPath(ws, target, names[1])
+	}
+	if strings.HasSuffix(target, `.g`) {
+		return target
+	}
+	return joinPath(ws, target, names[0]+`.g`)
+}
@@ -0,0 +1,186 @@
+// Copyright 2019 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package compiler
+
+import (
+	"strings"
+
+	"github.com/gentee/gentee/core"
+)
+
+func coParams(cmpl *compiler) error {
+	// func name(pars) (result types)
+	if cmpl.pos > 0 && cmpl.unit.Lexeme.Tokens[cmpl.pos-1].Type == tkRPar {
+		cmpl.dynamic = &cmState{tkLPar, cmResults, nil, nil, 0}
+	}
+	return nil
+}
+
+func coResultType(cmpl *compiler) error {
+	obj, err := getType(cmpl)
+	if err != nil {
+		return err
+	}
+	funcObj := cmpl.latestFunc()
+	funcObj.Block.Results = append(funcObj.Block.Results, obj.(*core.TypeObject))
+	return nil
+}
+
+func coResultsEnd(cmpl *compiler) error {
+	block := &cmpl.latestFunc().Block
+	switch len(block.Results) {
+	case 0:
+		return cmpl.Error(ErrType)
+	case 1:
+		block.Result = block.Results[0]
+		block.Results = nil
+	}
+	return coFuncStart(cmpl)
+}
+
+func isReturn(cmpl *compiler) bool {
+	parent := cmpl.owners[len(cmpl.owners)-1]
+	return parent.GetType() == core.CtStack && parent.(*core.CmdBlock).ID == core.StackReturn
+}
+
+// getResults returns the types of the values returned by the function call
+func getResults(cmd core.ICmd) []*core.TypeObject {
+	if cmd.GetType() != core.CtFunc || cmd.GetObject() == nil {
+		return nil
+	}
+	switch obj := cmd.GetObject().(type) {
+	case *core.FuncObject:
+		return obj.Block.Results
+	case *core.EmbedObject:
+		return obj.Results
+	}
+	return nil
+}
+
+// checkMultiValue returns an error if any command is the call of the function with several
+// results. Such calls can be used only in the assignment of several variables.
+func checkMultiValue(cmpl *compiler, cmds ...core.ICmd) error {
+	for _, cmd := range cmds {
+		if results := getResults(cmd); len(results) > 0 {
+			return cmpl.ErrorPos(cmd.GetToken(), ErrMultiValue, cmd.GetObject().GetName(),
+				len(results))
+		}
+	}
+	return nil
+}
+
+// isMultiTarget returns true if the statement starting with the item of array or map
+// like x[0], x[1] = myfunc() is the assignment of several values
+func isMultiTarget(lp *core.Lex, pos int) bool {
+	var depth int
+	for i := pos; i < len(lp.Tokens); i++ {
+		switch lp.Tokens[i].Type {
+		case tkLPar, tkLSBracket, tkLCurly:
+			depth++
+		case tkRPar, tkRSBracket, tkRCurly:
+			depth--
+		case tkComma:
+			if depth == 0 {
+				return true
+			}
+		case tkLine, tkAssign:
+			if depth == 0 {
+				return false
+			}
+		}
+	}
+	return false
+}
+
+// coMultiAssign parses the list of variables if the statement assigns several values
+// like a, _, b = myfunc(). Only variables can be on the left side.
+func coMultiAssign(cmpl *compiler) error {
+	coExpStart(cmpl)
+	lp := cmpl.unit.Lexeme
+	if cmpl.pos+1 < len(lp.Tokens) && lp.Tokens[cmpl.pos+1].Type == tkLSBracket &&
+		isMultiTarget(lp, cmpl.pos+1) {
+		return cmpl.ErrorPos(cmpl.pos, ErrMultiTarget)
+	}
+	if cmpl.pos+1 == len(lp.Tokens) || lp.Tokens[cmpl.pos+1].Type != tkComma {
+		return nil
+	}
+	cmd := &core.CmdBlock{ID: core.StackMultiAssign, CmdCommon: core.CmdCommon{
+		TokenID: uint32(cmpl.pos)}}
+	// Children contains the variables and the expression
+	i := cmpl.pos
+	for ; ; i += 2 {
+		if i >= len(lp.Tokens) {
+			return cmpl.ErrorPos(len(lp.Tokens)-1, ErrEnd)
+		}
+		if lp.Tokens[i].Type != tkIdent {
+			return cmpl.ErrorPos(i, ErrName)
+		}
+		token := getToken(lp, i)
+		if token == `_` {
+			cmd.Children = append(cmd.Children, &core.CmdCommand{ID: core.RcDiscard,
+				CmdCommon: core.CmdCommon{TokenID: uint32(i)}})
+		} else {
+			block, ind := findVar(cmpl, token)
+			if block == nil {
+				if names := strings.SplitN(token, `.`, 2); len(names) == 2 {
+					if block, _ = findVar(cmpl, names[0]); block != nil {
+						return cmpl.ErrorPos(i, ErrMultiTarget)
+					}
+				}
+				return cmpl.ErrorPos(i, ErrUnknownIdent, token)
+			}
+			cmdVar := &core.CmdVar{Block: block, Index: ind,
+				CmdCommon: core.CmdCommon{TokenID: uint32(i)}}
+			if err := checkCaptureAssign(cmpl, cmdVar, i); err != nil {
+				return err
+			}
+			cmd.Children = append(cmd.Children, cmdVar)
+		}
+		if i+1 == len(lp.Tokens) {
+			return cmpl.ErrorPos(i+1, ErrEnd)
+		}
+		if lp.Tokens[i+1].Type == tkAssign {
+			break
+		}
+		if lp.Tokens[i+1].Type == tkLSBracket {
+			return cmpl.ErrorPos(i, ErrMultiTarget)
+		}
+		if lp.Tokens[i+1].Type != tkComma {
+			return cmpl.ErrorPos(i+1, ErrMustAssign)
+		}
+	}
+	cmd.ParCount = len(cmd.Children)
+	appendCmd(cmpl, cmd)
+	cmpl.owners = append(cmpl.owners, cmd)
+	cmpl.newPos = i + 2
+	return nil
+}
+
+func coMultiAssignBack(cmpl *compiler) error {
+	cmd := cmpl.curOwner()
+	if cmd.ID != core.StackMultiAssign {
+		return nil
+	}
+	cmpl.owners = cmpl.owners[:len(cmpl.owners)-1]
+	count := cmd.ParCount
+	if len(cmd.Children) != count+1 {
+		return cmpl.ErrorPos(int(cmd.TokenID), ErrMultiFunc)
+	}
+	call := cmd.Children[count]
+	results := getResults(call)
+	if len(results) == 0 {
+		return cmpl.ErrorPos(call.GetToken(), ErrMultiFunc)
+	}
+	if len(results) != count {
+		return cmpl.ErrorPos(int(cmd.TokenID), ErrMultiCount, count, len(results))
+	}
+	for i, item := range cmd.Children[:count] {
+		if item.GetType() == core.CtVar && !isEqualTypes(item.GetResult(), results[i]) {
+			return cmpl.ErrorPos(item.GetToken(), ErrStructAssign, results[i].GetName(),
+				item.GetResult().GetName())
+		}
+	}
+	return nil
+}
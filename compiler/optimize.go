@@ -0,0 +1,330 @@
+// Copyright 2019 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package compiler
+
+import (
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/gentee/gentee/core"
+)
+
+// constInt returns the value of int, bool or char literal
+func constInt(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case int64:
+		return v, true
+	case rune:
+		return int64(v), true
+	case bool:
+		if v {
+			return 1, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+func bool2Int(value bool) int64 {
+	if value {
+		return 1
+	}
+	return 0
+}
+
+// evalConst calculates the result of the bytecode command over the literal values. It returns
+// false if the command is not supported or it can raise a runtime error.
+func evalConst(op core.Bcode, values []interface{}) (interface{}, bool) {
+	switch op {
+	case core.NOP, core.BITNOT, core.SIGN, core.NOT:
+		if len(values) != 1 {
+			return nil, false
+		}
+		x, ok := constInt(values[0])
+		if !ok {
+			return nil, false
+		}
+		switch op {
+		case core.BITNOT:
+			x = ^x
+		case core.SIGN:
+			x = -x
+		case core.NOT:
+			x = bool2Int(x == 0)
+		}
+		return x, true
+	case core.ADD, core.SUB, core.MUL, core.DIV, core.MOD, core.BITOR, core.BITXOR,
+		core.BITAND, core.LSHIFT, core.RSHIFT, core.EQ, core.LT, core.GT:
+		if len(values) != 2 {
+			return nil, false
+		}
+		x, okx := constInt(values[0])
+		y, oky := constInt(values[1])
+		if !okx || !oky {
+			return nil, false
+		}
+		switch op {
+		case core.ADD:
+			return x + y, true
+		case core.SUB:
+			return x - y, true
+		case core.MUL:
+			return x * y, true
+		case core.DIV, core.MOD:
+			if y == 0 {
+				return nil, false
+			}
+			if op == core.DIV {
+				return x / y, true
+			}
+			return x % y, true
+		case core.BITOR:
+			return x | y, true
+		case core.BITXOR:
+			return x ^ y, true
+		case core.BITAND:
+			return x & y, true
+		case core.LSHIFT, core.RSHIFT:
+			if y < 0 {
+				return nil, false
+			}
+			if op == core.LSHIFT {
+				return x << uint32(y), true
+			}
+			return x >> uint32(y), true
+		case core.EQ:
+			return bool2Int(x == y), true
+		case core.LT:
+			return bool2Int(x < y), true
+		default:
+			return bool2Int(x > y), true
+		}
+	case core.SIGNFLOAT:
+		if x, ok := values[0].(float64); ok {
+			return -x, true
+		}
+	case core.ADDFLOAT, core.SUBFLOAT, core.MULFLOAT, core.DIVFLOAT, core.EQFLOAT, core.LTFLOAT,
+		core.GTFLOAT:
+		x, okx := values[0].(float64)
+		y, oky := values[1].(float64)
+		if !okx || !oky {
+			return nil, false
+		}
+		switch op {
+		case core.ADDFLOAT:
+			return x + y, true
+		case core.SUBFLOAT:
+			return x - y, true
+		case core.MULFLOAT:
+			return x * y, true
+		case core.DIVFLOAT:
+			if y == 0.0 {
+				return nil, false
+			}
+			return x / y, true
+		case core.EQFLOAT:
+			return bool2Int(x == y), true
+		case core.LTFLOAT:
+			return bool2Int(x < y), true
+		default:
+			return bool2Int(x > y), true
+		}
+	case core.ADDSTR, core.EQSTR, core.LTSTR, core.GTSTR:
+		x, okx := values[0].(string)
+		y, oky := values[1].(string)
+		if !okx || !oky {
+			return nil, false
+		}
+		switch op {
+		case core.ADDSTR:
+			return x + y, true
+		case core.EQSTR:
+			return bool2Int(x == y), true
+		case core.LTSTR:
+			return bool2Int(x < y), true
+		default:
+			return bool2Int(x > y), true
+		}
+	}
+	return nil, false
+}
+
+// foldConst returns the literal value if cmd is the literal or the operator over literal
+// operands. Otherwise, it returns nil.
+func foldConst(cmd core.ICmd) *core.CmdValue {
+	var operands []core.ICmd
+	switch v := cmd.(type) {
+	case *core.CmdValue:
+		return v
+	case *core.CmdBinary:
+		operands = []core.ICmd{v.Left, v.Right}
+	case *core.CmdUnary:
+		operands = []core.ICmd{v.Operand}
+	default:
+		return nil
+	}
+	embed, ok := cmd.GetObject().(*core.EmbedObject)
+	if !ok || len(embed.BCode.Code) == 0 {
+		return nil
+	}
+	values := make([]interface{}, len(operands))
+	for i, operand := range operands {
+		value := foldConst(operand)
+		if value == nil {
+			return nil
+		}
+		values[i] = value.Value
+	}
+	result, ok := evalConst(embed.BCode.Code[0], values)
+	if !ok {
+		return nil
+	}
+	switch cmd.GetResult().Original {
+	case reflect.TypeOf(true):
+		if x, ok := result.(int64); ok {
+			result = x != 0
+		}
+	case reflect.TypeOf('a'):
+		if x, ok := result.(int64); ok && x >= math.MinInt32 && x <= math.MaxInt32 {
+			result = rune(x)
+		}
+	}
+	if reflect.TypeOf(result) != cmd.GetResult().Original {
+		return nil
+	}
+	return &core.CmdValue{Value: result, Result: cmd.GetResult(),
+		CmdCommon: core.CmdCommon{TokenID: uint32(cmd.GetToken())}}
+}
+
+// constBool returns the value of the condition if it is constant
+func constBool(cmd core.ICmd) (value bool, ok bool) {
+	if cmdValue := foldConst(cmd); cmdValue != nil {
+		value, ok = cmdValue.Value.(bool)
+	}
+	return
+}
+
+// liveBranches removes the branches of if statement with constant false conditions. The branch
+// with constant true condition becomes else branch and the next branches are removed.
+func liveBranches(children []core.ICmd) []core.ICmd {
+	live := make([]core.ICmd, 0, len(children))
+	for k := 0; k+1 < len(children); k += 2 {
+		value, ok := constBool(children[k])
+		if !ok {
+			live = append(live, children[k], children[k+1])
+		} else if value {
+			return append(live, children[k+1])
+		}
+	}
+	if len(children)&1 == 1 {
+		live = append(live, children[len(children)-1])
+	}
+	return live
+}
+
+// incVar checks if the assignment adds or subtracts the integer constant to the local int
+// variable and returns the variable and the value for INCVAR command
+func incVar(cmdStack *core.CmdBlock) (*core.CmdVar, int64, bool) {
+	cmdVar := cmdStack.Children[0].(*core.CmdVar)
+	_, global := cmdVar.Block.Object.(*core.VarObject)
+	if _, heap := cmdVar.Block.Heap[cmdVar.Index]; global || heap || len(cmdVar.Indexes) > 0 ||
+		!isIntResult(cmdVar) {
+		return nil, 0, false
+	}
+	embed, ok := cmdStack.GetObject().(*core.EmbedObject)
+	if !ok {
+		return nil, 0, false
+	}
+	var (
+		right core.ICmd
+		sub   bool
+	)
+	if len(embed.BCode.Code) > 0 && embed.BCode.Code[0] == core.ASSIGN {
+		// var = var + value or var = var - value
+		binary, ok := cmdStack.Children[1].(*core.CmdBinary)
+		if !ok {
+			return nil, 0, false
+		}
+		op, ok := binary.Object.(*core.EmbedObject)
+		if !ok || len(op.BCode.Code) == 0 || (op.BCode.Code[0] != core.ADD &&
+			op.BCode.Code[0] != core.SUB) {
+			return nil, 0, false
+		}
+		left, ok := binary.Left.(*core.CmdVar)
+		if !ok || left.Block != cmdVar.Block || left.Index != cmdVar.Index ||
+			len(left.Indexes) > 0 {
+			return nil, 0, false
+		}
+		right = binary.Right
+		sub = op.BCode.Code[0] == core.SUB
+	} else if name := embed.GetName(); (name == `AssignAdd` || name == `AssignSub`) &&
+		isIntResult(cmdStack.Children[1]) {
+		// var += value or var -= value
+		right = cmdStack.Children[1]
+		sub = strings.HasSuffix(name, `Sub`)
+	} else {
+		return nil, 0, false
+	}
+	cmdValue := foldConst(right)
+	if cmdValue == nil {
+		return nil, 0, false
+	}
+	value, ok := cmdValue.Value.(int64)
+	if sub {
+		value = -value
+	}
+	if !ok || value < math.MinInt32 || value > math.MaxInt32 {
+		return nil, 0, false
+	}
+	return cmdVar, value, true
+}
+
+// pushJump appends the relative jump command and remembers it for the jump threading.
+// dup must be true if the checked value is duplicated by DUP before the jump.
+func pushJump(linker *Linker, out *core.Bytecode, cmd core.Bcode, dup bool) int {
+	pos := len(out.Code)
+	out.Code = append(out.Code, cmd, 0)
+	if linker.Optimize {
+		if linker.jumps == nil {
+			linker.jumps = make(map[int]bool)
+		}
+		linker.jumps[pos] = dup
+	}
+	return pos
+}
+
+// popAssigned removes the value of the previous assignment from the stack. The optimizer
+// marks the assignment with AssignDrop flag instead of POP command.
+func popAssigned(linker *Linker, out *core.Bytecode, assign int, ptype core.Bcode) {
+	if linker.Optimize {
+		out.Code[assign] |= core.AssignDrop
+		return
+	}
+	out.Code = append(out.Code, ptype<<16|core.POP)
+}
+
+// threadJumps redirects the jumps to the final targets if they jump to JMP commands.
+// The conditional jump of the logical operator jumps over the same jump of the outer
+// logical operator because the checked value does not change.
+func threadJumps(linker *Linker, out *core.Bytecode) {
+	code := out.Code
+	for pos, dup := range linker.jumps {
+		target := pos + int(int16(code[pos+1]))
+		for i := 0; i < len(linker.jumps); i++ {
+			if _, ok := linker.jumps[target]; ok && code[target] == core.JMP {
+				target += int(int16(code[target+1]))
+			} else if next, ok := linker.jumps[target+1]; ok && dup && next &&
+				code[target] == core.DUP && code[target+1] == code[pos] {
+				target += 1 + int(int16(code[target+2]))
+			} else {
+				break
+			}
+		}
+		if off := target - pos; off >= math.MinInt16 && off <= math.MaxInt16 {
+			code[pos+1] = core.Bcode(off)
+		}
+	}
+}
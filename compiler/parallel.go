@@ -0,0 +1,136 @@
+// Copyright 2019 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package compiler
+
+import (
+	"reflect"
+
+	"github.com/gentee/gentee/core"
+)
+
+type parallelFor struct {
+	Holder *core.CmdBlock // the temporary block for the count of threads
+	Count  core.ICmd      // the count of threads, nil means the default count
+	For    *core.CmdBlock // for statement
+}
+
+// isParallelCount returns true if the compiler parses the count of parallel statement
+func isParallelCount(cmpl *compiler) bool {
+	return cmpl.parallel != nil && cmpl.parallel.For == nil && cmpl.parallel.Holder != nil &&
+		cmpl.curOwner() == cmpl.parallel.Holder
+}
+
+func coParallel(cmpl *compiler) error {
+	lp := cmpl.unit.Lexeme
+	if len(lp.Tokens) == cmpl.pos+1 || lp.Tokens[cmpl.pos+1].Type != tkLPar {
+		return cmpl.Error(ErrParallel)
+	}
+	coExpStart(cmpl)
+	holder := &core.CmdBlock{ID: core.StackBlock, Parent: cmpl.curOwner(),
+		CmdCommon: core.CmdCommon{TokenID: uint32(cmpl.pos)}}
+	cmpl.owners = append(cmpl.owners, holder)
+	cmpl.parallel = &parallelFor{Holder: holder}
+	return nil
+}
+
+func coParallelBack(cmpl *compiler) error {
+	holder := cmpl.curOwner()
+	cmpl.owners = cmpl.owners[:len(cmpl.owners)-1]
+	lp := cmpl.unit.Lexeme
+	if lp.Tokens[cmpl.pos].Type != tkFor || len(holder.Children) != 1 {
+		cmpl.parallel = nil
+		return cmpl.Error(ErrParallel)
+	}
+	if !isIntResult(holder.Children[0]) {
+		cmpl.parallel = nil
+		return cmpl.ErrorPos(holder.Children[0].GetToken(), ErrWrongType, `int`)
+	}
+	cmpl.parallel.Count = holder.Children[0]
+	return nil
+}
+
+// coParallelEnd finishes the count of threads before for statement
+func coParallelEnd(cmpl *compiler) error {
+	if !isParallelCount(cmpl) {
+		return cmpl.Error(ErrValue)
+	}
+	return coExpEnd(cmpl)
+}
+
+func coParallelOper(cmpl *compiler) error {
+	if !isParallelCount(cmpl) {
+		return cmpl.Error(ErrOper)
+	}
+	return nil
+}
+
+// coGoFor starts go for statement or go expression
+func coGoFor(cmpl *compiler) error {
+	lp := cmpl.unit.Lexeme
+	if len(lp.Tokens) > cmpl.pos+1 && lp.Tokens[cmpl.pos+1].Type == tkFor {
+		cmpl.parallel = &parallelFor{}
+		return nil
+	}
+	coExpStart(cmpl)
+	cmpl.dynamic = &cmState{tkToken, cmExp, nil, nil, cfStay | cfStopBack}
+	return nil
+}
+
+// parallelStart compiles the body of parallel for as a function literal with item and
+// index parameters
+func parallelStart(cmpl *compiler, cmd *core.CmdBlock) error {
+	src := cmd.Children[0].GetResult()
+	switch src.Original {
+	case reflect.TypeOf(core.Array{}), reflect.TypeOf(core.Map{}), reflect.TypeOf(core.Range{}),
+		reflect.TypeOf(core.Buffer{}), reflect.TypeOf(core.Set{}):
+	default:
+		return cmpl.ErrorPos(cmd.Children[0].GetToken(), ErrParallelFor, src.GetName())
+	}
+	par := cmpl.parallel
+	cmpl.parallel = nil
+	newClosure(cmpl)
+	block := &cmpl.latestFunc().Block
+	block.Vars = append(block.Vars, cmd.Vars...)
+	block.VarNames = make(map[string]int)
+	for name, ind := range cmd.VarNames {
+		block.VarNames[name] = ind
+	}
+	cmpl.goStack[len(cmpl.goStack)-1].Parallel = par
+	if err := coClosureStart(cmpl); err != nil {
+		return err
+	}
+	cmpl.dynamic = &cmState{tkLCurly, cmLCurly, nil, nil, 0}
+	return nil
+}
+
+// isParallelBody returns true if block is the body of parallel for
+func isParallelBody(cmpl *compiler, block *core.CmdBlock) bool {
+	if len(cmpl.goStack) == 0 {
+		return false
+	}
+	stack := cmpl.goStack[len(cmpl.goStack)-1]
+	return stack.Parallel != nil && stack.Closure != nil && stack.Closure == block.Parent
+}
+
+// parallelEnd replaces parallel for statement with the call of ParallelºFor
+func parallelEnd(cmpl *compiler) error {
+	par := cmpl.goStack[len(cmpl.goStack)-1].Parallel
+	value, err := closureEnd(cmpl)
+	if err != nil {
+		return err
+	}
+	cmd := par.For
+	count := par.Count
+	if count == nil {
+		count = &core.CmdValue{Value: int64(0), Result: cmpl.getIntType(),
+			CmdCommon: core.CmdCommon{TokenID: cmd.TokenID}}
+	}
+	call := &core.CmdAnyFunc{CmdCommon: core.CmdCommon{TokenID: cmd.TokenID},
+		Object:   cmpl.ws.StdLib().FindObj(core.DefParallelFor),
+		Children: []core.ICmd{count, cmd.Children[0], value}}
+	cmd.Parent.Children[len(cmd.Parent.Children)-1] = call
+	cmpl.owners = cmpl.owners[:len(cmpl.owners)-1]
+	return nil
+}
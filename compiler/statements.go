@@ -9,6 +9,9 @@ import (
 )
 
 func coReturn(cmpl *compiler) error {
+	if isInFinally(cmpl) {
+		return cmpl.Error(ErrFinally)
+	}
 	coExpStart(cmpl)
 	id := uint32(core.StackReturn)
 	if local := getLocalBlock(cmpl); local != nil {
@@ -26,13 +29,34 @@ func coReturnBack(cmpl *compiler) error {
 	if block == nil {
 		block = &cmpl.latestFunc().Block
 	}
+	if len(owner.Children) == 1 && len(block.Results) == 0 {
+		if err := checkMultiValue(cmpl, owner.Children[0]); err != nil {
+			return err
+		}
+	}
+	goReturn(cmpl, block, owner.Children)
 
 	switch len(owner.Children) {
 	case 0:
-		if block.Result != nil {
+		if block.Result != nil || len(block.Results) > 0 {
 			return cmpl.Error(ErrMustReturn)
 		}
 	case 1:
+		if results := getResults(owner.Children[0]); len(results) > 0 {
+			// the results of the function call are returned as they are
+			if len(block.Results) != len(results) {
+				return cmpl.Error(ErrReturnCount, len(block.Results))
+			}
+			for i, item := range results {
+				if !isEqualTypes(block.Results[i], item) {
+					return cmpl.ErrorPos(owner.Children[0].GetToken(), ErrReturnType)
+				}
+			}
+			break
+		}
+		if len(block.Results) > 0 {
+			return cmpl.Error(ErrReturnCount, len(block.Results))
+		}
 		if block.Result == nil {
 			return cmpl.Error(ErrReturn)
 		}
@@ -40,7 +64,20 @@ func coReturnBack(cmpl *compiler) error {
 			return cmpl.Error(ErrReturnType)
 		}
 	default:
-		return cmpl.Error(ErrCompiler, `coReturn 1`)
+		if len(block.Results) == 0 {
+			if block.Result == nil {
+				return cmpl.Error(ErrReturn)
+			}
+			return cmpl.Error(ErrReturnType)
+		}
+		if len(block.Results) != len(owner.Children) {
+			return cmpl.Error(ErrReturnCount, len(block.Results))
+		}
+		for i, item := range owner.Children {
+			if !isEqualTypes(block.Results[i], item.GetResult()) {
+				return cmpl.ErrorPos(item.GetToken(), ErrReturnType)
+			}
+		}
 	}
 	cmpl.owners = cmpl.owners[:len(cmpl.owners)-1]
 	return nil
@@ -153,6 +190,9 @@ func coFor(cmpl *compiler) error {
 	cmd := core.CmdBlock{ID: core.StackFor, CmdCommon: core.CmdCommon{TokenID: uint32(cmpl.pos)}}
 	appendCmd(cmpl, &cmd)
 	cmpl.owners = append(cmpl.owners, &cmd)
+	if cmpl.parallel != nil && cmpl.parallel.For == nil {
+		cmpl.parallel.For = &cmd
+	}
 	lp := cmpl.unit.Lexeme
 	cmpl.newPos = cmpl.pos + 1
 	if lp.Tokens[cmpl.newPos].Type != tkIdent {
@@ -190,18 +230,26 @@ func coForBack(cmpl *compiler) error {
 	cmd := cmpl.curOwner()
 	if cmd.ID == core.StackFor {
 		if len(cmd.Children) == 1 {
-			if !isIndexResult(cmd.Children[0]) {
+			if !isIndexResult(cmd.Children[0]) || isFuture(cmd.Children[0].GetResult()) {
 				return cmpl.ErrorPos(cmd.Children[0].GetToken(), ErrSupportIndex,
 					cmd.Children[0].GetResult().GetName())
 			}
 			cmd.Vars[0] = cmd.Children[0].GetResult().IndexOf
 			cmd.Vars[1] = cmpl.getIntType()
+			if cmpl.parallel != nil && cmpl.parallel.For == cmd {
+				return parallelStart(cmpl, cmd)
+			}
 			cmdFor := core.CmdBlock{ID: core.StackBlock, Parent: cmd,
 				CmdCommon: core.CmdCommon{TokenID: uint32(cmpl.pos)}}
 			cmd.Children = append(cmd.Children, &cmdFor)
+			if isChan(cmd.Children[0].GetResult()) {
+				chanFor(cmpl, cmd)
+			}
 			cmpl.owners = append(cmpl.owners, &cmdFor)
 			cmpl.dynamic = &cmState{tkLCurly, cmLCurly, nil, nil, 0}
 		}
+	} else if isParallelBody(cmpl, cmd) {
+		return parallelEnd(cmpl)
 	} else {
 		cmpl.owners = cmpl.owners[:len(cmpl.owners)-2]
 	}
@@ -220,12 +268,13 @@ func coSwitchBack(cmpl *compiler) error {
 	cmd := cmpl.curOwner()
 	if cmd.ID == core.StackSwitch {
 		if len(cmd.Children) == 1 {
-			if !isBaseResult(cmd.Children[0]) {
+			if !isBaseResult(cmd.Children[0]) && cmd.Children[0].GetResult().Enum == nil {
 				return cmpl.ErrorPos(cmd.Children[0].GetToken(), ErrSwitchType,
 					cmd.Children[0].GetResult().GetName())
 			}
 			cmpl.dynamic = &cmState{tkCase, cmCaseMust, nil, nil, 0}
 		} else {
+			checkEnumSwitch(cmpl, cmd)
 			cmpl.owners = cmpl.owners[:len(cmpl.owners)-1]
 		}
 	}
@@ -244,16 +293,30 @@ func coCaseBack(cmpl *compiler) error {
 	cmd := cmpl.curOwner()
 	if cmd.ID == core.StackCase {
 		if len(cmd.Children) >= 1 {
-			switchType := cmd.Parent.Children[0].GetResult()
-			for _, cmdExp := range cmd.Children {
-				if switchType != cmdExp.GetResult() {
-					return cmpl.ErrorPos(cmdExp.GetToken(), ErrWrongType,
-						switchType.GetName())
+			var (
+				assign core.ICmd
+				err    error
+			)
+			if cmd.Parent.ID == core.StackSelect {
+				if assign, err = selectCase(cmpl, cmd); err != nil {
+					return err
+				}
+			} else {
+				switchType := cmd.Parent.Children[0].GetResult()
+				for _, cmdExp := range cmd.Children {
+					if switchType != cmdExp.GetResult() {
+						return cmpl.ErrorPos(cmdExp.GetToken(), ErrWrongType,
+							switchType.GetName())
 
+					}
 				}
 			}
 			cmdIf := core.CmdBlock{ID: core.StackBlock, Parent: cmd,
 				CmdCommon: core.CmdCommon{TokenID: uint32(cmpl.pos)}}
+			if assign != nil {
+				assign.(*core.CmdBlock).Parent = &cmdIf
+				cmdIf.Children = append(cmdIf.Children, assign)
+			}
 			cmd.Children = append(cmd.Children, &cmdIf)
 			cmpl.owners = append(cmpl.owners, &cmdIf)
 			cmpl.dynamic = &cmState{tkLCurly, cmLCurly, nil, nil, 0}
@@ -7,6 +7,7 @@ package compiler
 import (
 	"fmt"
 	"hash/crc64"
+	"runtime"
 
 	"github.com/gentee/gentee/core"
 	"github.com/gentee/gentee/vm"
@@ -41,6 +42,8 @@ func InitStdlib(ws *core.Workspace) {
 	stdlib.NewConst(core.ConstCycle, int64(16000000), true)
 	stdlib.NewConst(core.ConstScript, ``, true)
 	stdlib.NewConst(core.ConstVersion, core.Version, false)
+	stdlib.NewConst(core.ConstOS, runtime.GOOS, false)
+	stdlib.NewConst(core.ConstArch, runtime.GOARCH, false)
 
 	// For flag param of ReadDir(str, int, str)
 	stdlib.NewConst(core.ConstRecursive, int64(vm.Recursive), false)
@@ -79,6 +79,57 @@ func coStructName(cmpl *compiler) error {
 	return nil
 }
 
+// coStructDefault sets the default value of the field like int port = 8080
+func coStructDefault(cmpl *compiler) error {
+	lp := cmpl.unit.Lexeme
+	fields := cmpl.curType.Custom
+	if len(fields.Fields) != len(fields.Types) {
+		return cmpl.Error(ErrName)
+	}
+	i := cmpl.pos + 1
+	if i < len(lp.Tokens) && lp.Tokens[i].Type == tkSub {
+		i++
+	}
+	if i >= len(lp.Tokens) {
+		return cmpl.ErrorPos(len(lp.Tokens)-1, ErrEnd)
+	}
+	switch lp.Tokens[i].Type {
+	case tkInt, tkFloat, tkStr, tkChar, tkTrue, tkFalse:
+	default:
+		return cmpl.ErrorPos(i, ErrFieldDefault)
+	}
+	cmpl.pos = i
+	coExpStart(cmpl)
+	if err := coPush(cmpl); err != nil {
+		return err
+	}
+	value := cmpl.exp[0].(*core.CmdValue)
+	coExpStart(cmpl)
+	if lp.Tokens[i-1].Type == tkSub {
+		switch v := value.Value.(type) {
+		case int64:
+			value.Value = -v
+		case float64:
+			value.Value = -v
+		default:
+			return cmpl.ErrorPos(i, ErrFieldDefault)
+		}
+	}
+	if i+1 < len(lp.Tokens) && lp.Tokens[i+1].Type != tkLine && lp.Tokens[i+1].Type != tkRCurly {
+		return cmpl.ErrorPos(i+1, ErrFieldDefault)
+	}
+	ind := int64(len(fields.Types) - 1)
+	if fields.Types[ind] != value.Result {
+		return cmpl.ErrorPos(i, ErrWrongType, fields.Types[ind].GetName())
+	}
+	if fields.Defaults == nil {
+		fields.Defaults = make(map[int64]interface{})
+	}
+	fields.Defaults[ind] = value.Value
+	cmpl.newPos = i
+	return nil
+}
+
 func structIndex(cmpl *compiler, typeObj *core.TypeObject, field string) (int64, *core.TypeObject, error) {
 	var (
 		indField int64
@@ -101,6 +101,12 @@ const (
 	tkRecover
 	tkRetry
 	tkDefault
+	tkDefer
+	tkFinally
+	tkEnum
+	tkInterface
+	tkSelect
+	tkParallel
 	tkToken // is used for preCompileTable
 )
 
@@ -5,7 +5,10 @@
 package compiler
 
 import (
+	"strconv"
+
 	"github.com/gentee/gentee/core"
+	"github.com/gentee/gentee/vm"
 )
 
 func coTry(cmpl *compiler) error {
@@ -22,12 +25,19 @@ func coTry(cmpl *compiler) error {
 func coTryBack(cmpl *compiler) error {
 	cmpl.owners = cmpl.owners[:len(cmpl.owners)-1]
 	cmd := cmpl.curOwner()
-	if cmd.ID == core.StackTry {
+	switch cmd.ID {
+	case core.StackTry:
 		if len(cmd.Children) == 1 {
 			cmpl.dynamic = &cmState{tkLCurly, cmCatch, nil, nil, 0}
 		} else {
 			cmpl.owners = cmpl.owners[:len(cmpl.owners)-1]
 		}
+	case core.StackFinally:
+		cmpl.owners = cmpl.owners[:len(cmpl.owners)-1]
+	default:
+		if isFinallyNext(cmpl) {
+			cmpl.dynamic = &cmState{tkLCurly, cmFinally, nil, nil, 0}
+		}
 	}
 	return nil
 }
@@ -42,6 +52,95 @@ func coCatch(cmpl *compiler) error {
 		VarNames: map[string]int{token: 0}}
 	appendCmd(cmpl, &cmd)
 	cmpl.owners = append(cmpl.owners, &cmd)
+	return catchFilter(cmpl)
+}
+
+// catchFilter parses the list of error ids, ranges and categories after the name of
+// the variable like catch err ErrFile, 100..199 {
+func catchFilter(cmpl *compiler) error {
+	lp := cmpl.unit.Lexeme
+	i := cmpl.pos + 1
+	isEnd := func() bool {
+		if i >= len(lp.Tokens) {
+			return true
+		}
+		switch lp.Tokens[i].Type {
+		case tkLCurly, tkColon, tkLine:
+			return true
+		}
+		return false
+	}
+	if isEnd() {
+		return nil
+	}
+	cmd := &core.CmdBlock{ID: core.StackCatchID, CmdCommon: core.CmdCommon{TokenID: uint32(i)}}
+	errID := func() (core.ICmd, error) {
+		if i >= len(lp.Tokens) {
+			return nil, cmpl.ErrorPos(len(lp.Tokens)-1, ErrEnd)
+		}
+		token := getToken(lp, i)
+		switch lp.Tokens[i].Type {
+		case tkInt:
+			v, err := strconv.ParseInt(token, 0, 64)
+			if err != nil {
+				return nil, cmpl.ErrorPos(i, ErrOutOfRange, token)
+			}
+			return &core.CmdValue{Value: v, CmdCommon: core.CmdCommon{TokenID: uint32(i)},
+				Result: cmpl.getIntType()}, nil
+		case tkIdent:
+			if isCapital(token) {
+				if constObj := cmpl.unit.FindConst(token); constObj != nil {
+					cmdConst := &core.CmdConst{Object: constObj,
+						CmdCommon: core.CmdCommon{TokenID: uint32(i)}}
+					if isIntResult(cmdConst) {
+						return cmdConst, nil
+					}
+				}
+			}
+		}
+		return nil, cmpl.ErrorPos(i, ErrCatchFilter)
+	}
+	for {
+		var (
+			ranges []vm.ErrRange
+			ok     bool
+		)
+		if i < len(lp.Tokens) && lp.Tokens[i].Type == tkIdent {
+			ranges, ok = vm.ErrCategories[getToken(lp, i)]
+		}
+		if ok {
+			for _, item := range ranges {
+				for _, id := range []int{item.Min, item.Max} {
+					cmd.Children = append(cmd.Children, &core.CmdValue{Value: int64(id),
+						CmdCommon: core.CmdCommon{TokenID: uint32(i)}, Result: cmpl.getIntType()})
+				}
+			}
+			i++
+		} else {
+			from, err := errID()
+			if err != nil {
+				return err
+			}
+			to := from
+			if i++; i < len(lp.Tokens) && lp.Tokens[i].Type == tkRange {
+				i++
+				if to, err = errID(); err != nil {
+					return err
+				}
+				i++
+			}
+			cmd.Children = append(cmd.Children, from, to)
+		}
+		if i >= len(lp.Tokens) || lp.Tokens[i].Type != tkComma {
+			break
+		}
+		i++
+	}
+	if !isEnd() {
+		return cmpl.ErrorPos(i, ErrCatchFilter)
+	}
+	appendCmd(cmpl, cmd)
+	cmpl.newPos = i - 1
 	return nil
 }
 
@@ -51,16 +150,20 @@ func coCatchBack(cmpl *compiler) error {
 }
 
 func isInCatch(cmpl *compiler) bool {
+	var ret bool
 	for _, item := range cmpl.owners {
 		if item.GetType() == core.CtStack {
-			parent := item.(*core.CmdBlock).Parent
+			block := item.(*core.CmdBlock)
+			parent := block.Parent
 			if parent != nil && parent.ID == core.StackTry && len(parent.Children) == 2 &&
 				item == parent.Children[1] {
-				return true
+				ret = true
+			} else if block.ID == core.StackFinally || block.Object != nil {
+				ret = false
 			}
 		}
 	}
-	return false
+	return ret
 }
 
 func coRecover(cmpl *compiler) error {
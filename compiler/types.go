@@ -49,6 +49,14 @@ func InitTypes(ws *core.Workspace) {
 		{`map.str`, typeMap, `str`},
 		{`map.int`, typeMap, `int`},
 		{`map.bool`, typeMap, `bool`},
+		// chan* is for embedded channel funcs. It means channel of any type
+		{`chan*`, reflect.TypeOf(core.Chan{}), ``},
+		{`chan.int`, reflect.TypeOf(core.Chan{}), `int`},
+		// future* is for embedded future funcs. It means future of any type
+		{`future*`, reflect.TypeOf(core.Future{}), ``},
+		{`mutex`, reflect.TypeOf(core.Sync{}), ``},
+		{`rwmutex`, reflect.TypeOf(core.Sync{}), ``},
+		{`semaphore`, reflect.TypeOf(core.Sync{}), ``},
 	} {
 		var indexOf core.IObject
 		if len(item.index) > 0 {
@@ -35,15 +35,17 @@ type CodePos struct {
 }
 
 type StructInfo struct {
-	Name   string
-	Fields []uint16 // types
-	Keys   []string
+	Name     string
+	Fields   []uint16 // types
+	Keys     []string
+	Defaults []interface{} // default values of fields
 }
 
 type Exec struct {
 	Code    []Bcode
 	Funcs   map[int32]int32
 	Init    []int32  // offsets of init funcs (initializing constants)
+	Globals []int32  // identifiers of global variables
 	Strings []string // string resources
 	Structs []StructInfo
 	Pos     []CodePos
@@ -61,10 +63,12 @@ type Embed struct {
 	Code     uint32      // Bytecode (if Func == nil) or function index
 	Func     interface{} // golang function
 	Return   uint16      // the type of the result
+	Returns  []uint16    // the types of several results
 	Params   []uint16    // the types of parameters
 	Variadic bool        // variadic function
 	Runtime  bool        // the first parameter is rt
 	CanError bool        // can generate error
+	Platform bool        // it is not supported on the current platform
 }
 
 type AssignIntFunc func(*int64, int64) (int64, error)
@@ -91,6 +95,10 @@ const (
 	TYPEOBJ    = 0x084
 	TYPEFILE   = 0x094
 	TYPEHANDLE = 0x0A4
+	TYPECHAN   = 0x0B4
+	TYPEFUTURE = 0x0C4
+	TYPESYNC   = 0x0D4
+	TYPEBOX    = 0x0E4 // the heap cell of the captured variable, & (idvar<<16) in INITVARS
 	TYPESTRUCT = 0x104
 
 	BlBreak    = 0x0001
@@ -100,6 +108,13 @@ const (
 	BlTry      = 0x0010
 	BlRecover  = 0x0020
 	BlRetry    = 0x0040
+
+	// GlobalShift is the block shift of GETVAR and SETVAR for global variables
+	GlobalShift = 0x0e00
+	// BoxShift is the flag of the block shift of GETVAR and SETVAR for the variables in heap cells
+	BoxShift = 0x1000
+	// AssignDrop is the flag of the assign command when the assigned value is not pushed
+	AssignDrop = 0x8000
 )
 
 const (
@@ -169,15 +184,26 @@ const (
 	RECOVER   // recover
 	RETRY     // retry
 	RET       // & (type<<16) return from function
+	RETMULTI  // & (count<<16) return several values from function + int32 types
 	END       // end of the function
 	CONSTBYID // + int32 id of the object
 	CALLBYID  // & (par count<<16) + int32 id of the object
-	GOBYID    // & (par count<<16) + int32 id of the object new thread + int32 type of pars   60
+	GOBYID    // & (par count<<16) [| 0x8000 future] + int32 id of the object new thread + int32 type of pars   60
 	EMBED     // & (embed id << 16) calls embedded func + int32 count for variadic funcs
 	// + [variadic types]
 	LOCAL // & (par count << 16)+ int32 offset
 	CATCH
-	IOTA // & (iota<<16)
+	IOTA    // & (iota<<16)
+	CLOSURE // & (count<<16) + int32 id func + int32 types of captured values
+	DEFER   // defers the call of fn value
+	FINALLY // + int32 offset of finally block
+	CATCHID // & (count<<16) rethrows the error if its id is out of the ranges
+	ENUMSTR // & (count<<16) converts enum value to the name, the names are in the stack
+	ENUMVAL // & (count<<16) converts the name to enum value, the names are in the stack
+	ENUMINT // & (count<<16) checks that int value is a valid enum value
+	IFACE   // & (shift<<16) + int32 count + int32 iface type + {int32 type, int32 id} pushes fn of the method
+	GLOBAL  // + int32 id of the object + int32 type creates the global variable
+	INCVAR  // & (block shift<<16) + int32 index + int32 value adds value to int variable
 
 	INDEX        // & (int32 count) + {(type input<<16) + result type}
 	ASSIGNPTR    // & (int16 type << 16)
@@ -25,7 +25,11 @@ const (
 	// ConstScript is the script path
 	ConstScript = `SCRIPT`
 	// ConstVersion is the version of Gentee compiler
-	ConstVersion   = `VERSION`
+	ConstVersion = `VERSION`
+	// ConstOS is the operating system
+	ConstOS = `OS`
+	// ConstArch is the architecture
+	ConstArch      = `ARCH`
 	ConstRecursive = `RECURSIVE`
 	ConstOnlyFiles = `ONLYFILES`
 	ConstOnlyDirs  = `ONLYDIRS`
@@ -41,6 +41,8 @@ const (
 	RcRecover
 	// RcRetry means retry command
 	RcRetry
+	// RcDiscard means _ in the assignment of several values
+	RcDiscard
 )
 
 const (
@@ -88,6 +90,20 @@ const (
 	StackTry
 	// StackCatch is the try statement
 	StackCatch
+	// StackMultiAssign assigns several values returned by the function
+	StackMultiAssign
+	// StackDefer is the defer statement
+	StackDefer
+	// StackFinally is the finally block of try statement
+	StackFinally
+	// StackCatchID is the filter of errors in catch
+	StackCatchID
+	// StackEnum converts enum value to int, str and back
+	StackEnum
+	// StackIface gets the function of the method for interface value
+	StackIface
+	// StackSelect is the select statement
+	StackSelect
 )
 
 // Token is a lexical token.
@@ -166,9 +182,13 @@ type CmdBlock struct {
 	VarNames   map[string]int
 	Optional   map[string]int
 	Result     *TypeObject
+	Results    []*TypeObject // types of several return values
 	Locals     []ICmd
 	LocalNames map[string]int
 	Children   []ICmd
+	// Heap contains the variables captured by function literals. They are kept in heap cells.
+	// The value is true if the variable is the cell passed to the function literal.
+	Heap map[int]bool
 }
 
 // CmdUnary calls an unary function
@@ -16,6 +16,8 @@ const (
 	DefAssignAddArrArr = `AssignAddºArrArr`
 	// DefAssignAddMap appends the map to array
 	DefAssignAddMap = `AssignAddºArrMap`
+	// DefAssignAddFuture appends the future to array
+	DefAssignAddFuture = `AssignAddºArrFuture`
 	// DefAssignArr assigns one array to another
 	DefAssignArr = `AssignºArrArr`
 	// DefAssignMap assigns one map to another
@@ -44,6 +46,26 @@ const (
 	DefNewKeyValue = `NewKeyValue`
 	// DefGetEnv returns an environment variable
 	DefGetEnv = `GetEnv`
+	// DefAssignChan assigns one channel to another
+	DefAssignChan = `AssignºChanChan`
+	// DefSendChan sends a value to the channel. It is used with Int, Float, Str, Any suffixes
+	DefSendChan = `SendºChan`
+	// DefReceiveChan receives a value from the channel. It is used with type suffixes
+	DefReceiveChan = `ReceiveºChan`
+	// DefReceivedChan returns the value received by select or for. It is used with type suffixes
+	DefReceivedChan = `ReceivedºChan`
+	// DefNextChan receives the next value of the channel in for statement
+	DefNextChan = `NextºChan`
+	// DefSelectChan waits for one of the cases of select statement
+	DefSelectChan = `SelectºChan`
+	// DefAssignFuture assigns one future to another
+	DefAssignFuture = `AssignºFutureFuture`
+	// DefWaitFuture waits for the result of the future. It is used with type suffixes
+	DefWaitFuture = `WaitºFuture`
+	// DefWaitAllFuture waits for the results of the array of futures
+	DefWaitAllFuture = `WaitAllºFuture`
+	// DefParallelFor runs the body of parallel for in several threads
+	DefParallelFor = `ParallelºFor`
 )
 
 var (
@@ -51,6 +73,7 @@ var (
 		DefAssignAddArr:             true,
 		DefAssignAddArrArr:          true,
 		DefAssignAddMap:             true,
+		DefAssignAddFuture:          true,
 		DefAssignArr:                true,
 		DefAssignMap:                true,
 		DefLenArr:                   true,
@@ -65,9 +88,25 @@ var (
 		DefAssignBitAndMapMap:       true,
 		DefNewKeyValue:              true,
 		DefGetEnv:                   true,
+		DefAssignChan:               true,
+		DefNextChan:                 true,
+		DefSelectChan:               true,
+		DefAssignFuture:             true,
+		DefWaitAllFuture:            true,
+		DefParallelFor:              true,
 	}
+	// ChanSuffixes are the suffixes of channel functions for the different stacks
+	ChanSuffixes = []string{`Int`, `Float`, `Str`, `Any`}
 )
 
+func init() {
+	for _, name := range []string{DefSendChan, DefReceiveChan, DefReceivedChan, DefWaitFuture} {
+		for _, suffix := range ChanSuffixes {
+			defFuncs[name+suffix] = true
+		}
+	}
+}
+
 // NameToType searches the type by its name. It accepts names like name.name.name.
 // It creates a new type if it absents.
 func (unit *Unit) NameToType(name string) IObject {
@@ -85,6 +124,16 @@ func (unit *Unit) NameToType(name string) IObject {
 				if indexOf != nil {
 					obj = unit.NewType(name, reflect.TypeOf(Map{}), indexOf.(*TypeObject))
 				}
+			} else if ins[0] == `chan` {
+				indexOf := unit.NameToType(ins[1])
+				if indexOf != nil {
+					obj = unit.NewType(name, reflect.TypeOf(Chan{}), indexOf.(*TypeObject))
+				}
+			} else if ins[0] == `future` {
+				indexOf := unit.NameToType(ins[1])
+				if indexOf != nil {
+					obj = unit.NewType(name, reflect.TypeOf(Future{}), indexOf.(*TypeObject))
+				}
 			}
 		}
 	}
@@ -96,6 +145,7 @@ func (unit *Unit) ImportEmbed(embed Embed) {
 	var (
 		code     []Bcode
 		retType  *TypeObject
+		results  []*TypeObject
 		parTypes []*TypeObject
 		fnc      interface{}
 	)
@@ -104,7 +154,11 @@ func (unit *Unit) ImportEmbed(embed Embed) {
 	} else {
 		fnc = int32(embed.Code)
 	}
-	if len(embed.Ret) > 0 {
+	if strings.IndexByte(embed.Ret, ',') >= 0 {
+		for _, item := range strings.Split(embed.Ret, `,`) {
+			results = append(results, unit.NameToType(strings.TrimSpace(item)).(*TypeObject))
+		}
+	} else if len(embed.Ret) > 0 {
 		retType = unit.NameToType(embed.Ret).(*TypeObject)
 	}
 	if len(embed.Pars) > 0 {
@@ -124,10 +178,12 @@ func (unit *Unit) ImportEmbed(embed Embed) {
 		},
 		Func:     fnc,
 		Return:   retType,
+		Results:  results,
 		Params:   parTypes,
 		Variadic: embed.Variadic,
 		Runtime:  embed.Runtime,
 		CanError: embed.CanError,
+		Platform: embed.Platform,
 	})
 	ind := len(unit.VM.Objects) - 1
 	if defFuncs[embed.Name] {
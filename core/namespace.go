@@ -12,6 +12,7 @@ import (
 const (
 	npType     = `@`
 	npConst    = `$`
+	npVar      = `%`
 	npVariadic = `?`
 	npFunc     = `#`
 
@@ -46,6 +47,11 @@ func (unit *Unit) FindConst(name string) IObject {
 	return unit.FindObj(npConst + name)
 }
 
+// FindVar returns the global variable with the specified name
+func (unit *Unit) FindVar(name string) IObject {
+	return unit.FindObj(npVar + name)
+}
+
 // FindFunc returns the function with the specified name and parameters
 func (unit *Unit) FindFunc(name string, params []*TypeObject) (IObject, bool) {
 	var isStruct bool
@@ -61,6 +67,8 @@ func (unit *Unit) FindFunc(name string, params []*TypeObject) (IObject, bool) {
 			keyAny += npFunc + `arr*`
 		} else if strings.HasPrefix(parName, `map.`) {
 			keyAny += npFunc + `map*`
+		} else if strings.HasPrefix(parName, `chan.`) {
+			keyAny += npFunc + `chan*`
 		} else {
 			keyAny += npFunc + parName
 		}
@@ -102,6 +110,14 @@ func (unit *Unit) AddConst(name string) {
 	unit.NameSpace[npConst+name] = ind
 }
 
+// AddVar appends a global variable to NameSpace
+func (unit *Unit) AddVar(ind int, pub bool) {
+	if pub {
+		ind |= NSPub
+	}
+	unit.NameSpace[npVar+unit.GetObj(uint32(ind)).GetName()] = uint32(ind)
+}
+
 // AddFunc appends func to NameSpace
 func (unit *Unit) AddFunc(ind int, obj IObject, pub bool) {
 	var key string
@@ -20,6 +20,8 @@ const (
 	ObjFunc
 	// ObjConst is a constant
 	ObjConst
+	// ObjVar is a global variable
+	ObjVar
 )
 
 // IObject describes interface for all objects
@@ -50,6 +52,8 @@ type TypeObject struct {
 	IndexOf  *TypeObject  // consists of elements
 	Custom   *StructType  // for custom struct type
 	Func     *FnType      // for func type
+	Enum     *EnumType    // for enum type
+	Iface    *IfaceType   // for interface type
 }
 
 // EmbedObject contains information about the golang function
@@ -57,10 +61,12 @@ type EmbedObject struct {
 	Object
 	Func     interface{}   // golang function
 	Return   *TypeObject   // the type of the result
+	Results  []*TypeObject // the types of several results
 	Params   []*TypeObject // the types of parameters
 	Variadic bool          // variadic function
 	Runtime  bool          // the first parameter is rt
 	CanError bool          // can generate error
+	Platform bool          // it is not supported on the current platform
 }
 
 // FuncObject contains information about the function
@@ -78,6 +84,12 @@ type ConstObject struct {
 	Iota      int64       // iota
 }
 
+// VarObject contains information about the global variable
+type VarObject struct {
+	Object
+	Block CmdBlock // the block with the variable and its initialization
+}
+
 // GetName returns the name of the object
 func (typeObj *TypeObject) GetName() (ret string) {
 	ret = typeObj.Name
@@ -242,6 +254,46 @@ func (constObj *ConstObject) GetCode() *Bytecode {
 	return &constObj.BCode
 }
 
+// GetName returns the name of the object
+func (varObj *VarObject) GetName() string {
+	return varObj.Name
+}
+
+// GetLex returns the lex structure of the object
+func (varObj *VarObject) GetLex() *Lex {
+	return varObj.Object.Unit.Lexeme
+}
+
+// GetType returns ObjVar
+func (varObj *VarObject) GetType() ObjectType {
+	return ObjVar
+}
+
+// Result returns the type of the variable
+func (varObj *VarObject) Result() *TypeObject {
+	return varObj.Block.Vars[0]
+}
+
+// GetParams returns the slice of parameters
+func (varObj *VarObject) GetParams() []*TypeObject {
+	return nil
+}
+
+// SetPub set Pub state
+func (varObj *VarObject) SetPub() {
+	varObj.Pub = true
+}
+
+// GetUnitIndex returns the index of the unit of this object
+func (varObj *VarObject) GetUnitIndex() uint32 {
+	return varObj.Unit.Index
+}
+
+// GetCode returns the Bytecode structure
+func (varObj *VarObject) GetCode() *Bytecode {
+	return &varObj.BCode
+}
+
 // NewObject adds a new IObject to Unit
 func (unit *Unit) NewObject(obj IObject) IObject {
 	if unit.Pub > 0 {
@@ -6,8 +6,10 @@ package core
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"strings"
+	"sync"
 )
 
 const (
@@ -48,10 +50,46 @@ type Set struct {
 	Data []uint64
 }
 
+// Chan is a channel for passing values between threads
+type Chan struct {
+	Data   chan interface{}
+	Closed bool
+	Mutex  sync.Mutex // protects Closed
+}
+
+// Future is the result of the thread which has been started by go
+type Future struct {
+	ThreadID int64
+	Done     chan struct{} // it is closed when the thread has finished
+	Result   interface{}
+	Err      error
+}
+
+// Sync is the state of mutex, rwmutex or semaphore
+type Sync struct {
+	Mutex   sync.Mutex    // protects the fields
+	Size    int64         // the count of the slots of semaphore
+	Writer  bool          // the exclusive lock is held
+	Writers int64         // the count of threads waiting for the exclusive lock
+	Readers int64         // the count of shared locks or acquired slots
+	Wake    chan struct{} // it is closed when the lock has been released
+}
+
+// FileHandle is the opened file of the file system
+type FileHandle interface {
+	io.Reader
+	io.ReaderAt
+	io.Writer
+	io.Seeker
+	io.Closer
+	Stat() (os.FileInfo, error)
+	Truncate(size int64) error
+}
+
 // File is a file structure
 type File struct {
 	Name   string
-	Handle *os.File
+	Handle FileHandle
 }
 
 // Map is a map
@@ -68,13 +106,40 @@ type FnType struct {
 
 // Fn is used for custom func types
 type Fn struct {
-	Func IObject
+	Func     IObject
+	Captures []ICmd // variables captured by the function literal
 }
 
 // StructType is used for custom struct types
 type StructType struct {
-	Fields map[string]int64 // Names of fields with indexes of the order
-	Types  []*TypeObject    // Types of fields
+	Fields   map[string]int64      // Names of fields with indexes of the order
+	Types    []*TypeObject         // Types of fields
+	Defaults map[int64]interface{} // Default values of fields
+}
+
+// EnumType is used for enum types
+type EnumType struct {
+	Names []string    // Names of the members
+	Range *TypeObject // Type for iterating over the members
+}
+
+// IfaceMethod is a method of interface
+type IfaceMethod struct {
+	Name   string
+	Params []*TypeObject // Types of parameters except the receiver
+	Result *TypeObject   // Type of return value
+}
+
+// IfaceImpl is a struct type which implements interface
+type IfaceImpl struct {
+	Type  *TypeObject
+	Funcs []IObject // Functions in the order of the methods
+}
+
+// IfaceType is used for interface types
+type IfaceType struct {
+	Methods []IfaceMethod
+	Impls   []IfaceImpl // Struct types assigned to the interface
 }
 
 // Struct is used for custom struct types
@@ -337,6 +402,27 @@ func NewSet() *Set {
 	}
 }
 
+// NewChan creates a new channel with the specified size of the buffer
+func NewChan(size int) *Chan {
+	return &Chan{
+		Data: make(chan interface{}, size),
+	}
+}
+
+// NewFuture creates a new future for the thread
+func NewFuture() *Future {
+	return &Future{
+		Done: make(chan struct{}),
+	}
+}
+
+// NewSemaphore creates a new semaphore with the specified count of slots
+func NewSemaphore(size int64) *Sync {
+	return &Sync{
+		Size: size,
+	}
+}
+
 /*
 // NewStruct creates a new struct object
 func NewStruct(ptype *TypeObject) *Struct {
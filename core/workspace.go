@@ -5,10 +5,14 @@
 package core
 
 import (
+	"io/fs"
 	"math/rand"
+	"os"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -20,11 +24,24 @@ type Workspace struct {
 	Linked    map[string]int // compiled files
 	IotaID    int32
 	Embedded  []Embed
+	FS        fs.FS // source files. If it is nil then the OS file system is used
+	// SearchPath is the list of folders for include and import files.
+	// By default, it is taken from GENTEE_PATH environment variable
+	SearchPath []string
+	Tags       []string   // tags for #if tag(name) directives
+	NoOptimize bool       // disables the bytecode optimizer, it must be set before compiling
+	mutex      sync.Mutex // guards the workspace during compilation and linking
 }
 
 const (
 	// DefName is the key name for stdlib
 	DefName = `stdlib`
+	// EnvPath is the environment variable with the search path of include files
+	EnvPath = `GENTEE_PATH`
+	// EnvModCache is the environment variable with the folder of the module cache
+	EnvModCache = `GENTEE_MODCACHE`
+	// ModFile is the name of the manifest file with the list of modules
+	ModFile = `gentee.mod`
 
 	// PubOne means the only next object is public
 	PubOne = 1
@@ -42,6 +59,20 @@ type Unit struct {
 	RunID     int               // The index of run function. Undefined (-1) - run has not yet been defined
 	Name      string            // The name of the unit
 	Pub       int               // Public mode
+	Warnings  []string          // Compiler warnings
+	Generics  map[string]*Generic
+}
+
+// Generic is a template of the generic function like func First<T>(arr.T a) T {...}
+type Generic struct {
+	Name     string
+	Types    []string // names of type parameters
+	Pars     []string // types of parameters
+	Result   string   // type of the result
+	Tokens   []Token
+	Unit     *Unit // the unit where the template has been declared
+	Pub      bool
+	Imported bool
 }
 
 func init() {
@@ -68,9 +99,22 @@ func NewVM(Embedded []Embed) *Workspace {
 		Linked:    make(map[string]int),
 		Embedded:  Embedded,
 	}
+	if len(os.Getenv(EnvPath)) > 0 {
+		ws.SearchPath = filepath.SplitList(os.Getenv(EnvPath))
+	}
 	return &ws
 }
 
+// Lock locks the workspace for compiling or linking
+func (ws *Workspace) Lock() {
+	ws.mutex.Lock()
+}
+
+// Unlock unlocks the workspace
+func (ws *Workspace) Unlock() {
+	ws.mutex.Unlock()
+}
+
 // InitUnit initialize a unit structure
 func (ws *Workspace) InitUnit() *Unit {
 	return &Unit{
@@ -78,6 +122,7 @@ func (ws *Workspace) InitUnit() *Unit {
 		RunID:     Undefined,
 		NameSpace: make(map[string]uint32),
 		Included:  make(map[uint32]bool),
+		Generics:  make(map[string]*Generic),
 	}
 }
 
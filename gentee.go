@@ -20,6 +20,13 @@ const (
 	SysSuspend   = vm.SysSuspend
 	SysResume    = vm.SysResume
 	SysTerminate = vm.SysTerminate
+
+	CapRead    = vm.CapRead
+	CapWrite   = vm.CapWrite
+	CapExec    = vm.CapExec
+	CapNetwork = vm.CapNetwork
+	CapEnv     = vm.CapEnv
+	CapThread  = vm.CapThread
 )
 
 // Exec is a structure with a bytecode that is ready to run
@@ -55,6 +62,24 @@ type Custom struct {
 
 type Progress = vm.Progress
 type ProgressFunc = vm.ProgressFunc
+type Policy = vm.Policy
+type Rule = vm.Rule
+type Action = vm.Action
+type AuditEvent = vm.AuditEvent
+type AuditLog = vm.AuditLog
+type FileSystem = vm.FileSystem
+type MemFS = vm.MemFS
+
+// NewAuditLog opens or creates the file for writing the audit events in JSON lines format
+func NewAuditLog(filename string) (*AuditLog, error) {
+	return vm.NewAuditLog(filename)
+}
+
+// NewMemFS returns the file system in memory, it is the overlay on the base directory if it is
+// not empty
+func NewMemFS(base string) (*MemFS, error) {
+	return vm.NewMemFS(base)
+}
 
 func str2type(in string) (ret uint16) {
 	switch in {
@@ -86,11 +111,17 @@ func str2type(in string) (ret uint16) {
 		ret = core.TYPESET
 	case `obj`:
 		ret = core.TYPEOBJ
+	case `mutex`, `rwmutex`, `semaphore`:
+		ret = core.TYPESYNC
 	default:
 		if in == `arr` || strings.HasPrefix(in, `arr.`) {
 			ret = core.TYPEARR
 		} else if in == `map` || strings.HasPrefix(in, `map.`) {
 			ret = core.TYPEMAP
+		} else if strings.HasPrefix(in, `chan`) {
+			ret = core.TYPECHAN
+		} else if strings.HasPrefix(in, `future`) {
+			ret = core.TYPEFUTURE
 		} else {
 			ret = core.TYPESTRUCT
 		}
@@ -109,7 +140,7 @@ func str2pars(in string) (types []uint16) {
 }
 
 func Customize(custom *Custom) error {
-	re, err := regexp.Compile(`^([\wº]+)\(([\w ,\.\*]*)\)\s*([\w\.\*]*)?`)
+	re, err := regexp.Compile(`^([\wº]+)\(([\w ,\.\*]*)\)\s*(\([\w ,\.\*]*\)|[\w\.\*]*)?`)
 	if err != nil {
 		return err
 	}
@@ -124,18 +155,27 @@ func Customize(custom *Custom) error {
 			return fmt.Errorf("%s %v", vm.ErrorText(vm.ErrCustom), v)
 		}
 		t := reflect.TypeOf(v.Object)
+		ret := strings.TrimSuffix(strings.TrimPrefix(vals[3], `(`), `)`)
+		var returns []uint16
+		// the function returns several values like (str, int)
+		if strings.IndexByte(ret, ',') >= 0 {
+			returns = str2pars(ret)
+		}
 		embed := core.Embed{
 			Name:     vals[1],
 			Pars:     vals[2],
-			Ret:      vals[3],
+			Ret:      ret,
 			Code:     uint32(len(vm.EmbedFuncs)),
 			Func:     v.Object,
-			Return:   str2type(vals[3]),
 			Params:   str2pars(vals[2]),
+			Returns:  returns,
 			Variadic: t.IsVariadic(),
 			Runtime:  t.NumIn() > 0 && t.In(0) == reflect.TypeOf(&vm.Runtime{}),
 			CanError: t.NumOut() >= 1 && t.Out(t.NumOut()-1).String() == `error`,
 		}
+		if len(returns) == 0 {
+			embed.Return = str2type(ret)
+		}
 		vm.EmbedFuncs = append(vm.EmbedFuncs, embed)
 	}
 	return nil
@@ -181,6 +221,12 @@ func (g *Gentee) CompileFile(filename string) (*Exec, int, error) {
 	return &Exec{Exec: exec}, unitID, err
 }
 
+// Define defines a compile-time constant for the next compilations.
+// The constant can be used in the source code and in #if directives.
+func (g *Gentee) Define(name, value string) error {
+	return compiler.Define(g.Workspace, name, value)
+}
+
 // Unit returns the unit structure by its index.
 func (g *Gentee) Unit(unitID int) Unit {
 	return Unit{Unit: g.Units[unitID]}
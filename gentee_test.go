@@ -5,12 +5,26 @@
 package gentee
 
 import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
 	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/gentee/gentee/vm"
 )
 
 // Source contains source code and result value
@@ -69,44 +83,46 @@ func loadTest(filename string) (src []Source, err error) {
 	return
 }
 
-func TestGentee(t *testing.T) {
-	workspace := New()
-
-	testFile := func(filename string) error {
-		src, err := loadTest(filename)
+// testFile compiles and runs the test sources of the file and compares the results
+func testFile(workspace *Gentee, filename string) error {
+	src, err := loadTest(filename)
+	if err != nil {
+		return err
+	}
+	for i := len(src) - 1; i >= 0; i-- {
+		testErr := func(err error) error {
+			return fmt.Errorf(`[%d] of %s  %v`, src[i].Line, filename, err)
+		}
+		exec, _, err := workspace.Compile(src[i].Src, ``)
+		if err != nil && err.Error() != src[i].Want {
+			return testErr(err)
+		}
 		if err != nil {
-			return err
+			continue
 		}
-		for i := len(src) - 1; i >= 0; i-- {
-			testErr := func(err error) error {
-				return fmt.Errorf(`[%d] of %s  %v`, src[i].Line, filename, err)
-			}
-			exec, _, err := workspace.Compile(src[i].Src, ``)
-			if err != nil && err.Error() != src[i].Want {
-				return testErr(err)
-			}
-			if err != nil {
-				continue
-			}
-			var settings Settings
-			if filename == `err_test` {
-				settings.Cycle = 1000000
-			}
-			//			fmt.Println(`i`, src[i].Line, filename)
-			result, err := exec.Run(settings)
-			//			result, err := workspace.Run(unitID)
-			if err == nil {
-				if err = getWant(result, src[i].Want); err != nil {
-					return testErr(err)
-				}
-			} else if err.Error() != src[i].Want {
+		var settings Settings
+		if filename == `err_test` {
+			settings.Cycle = 1000000
+		}
+		//			fmt.Println(`i`, src[i].Line, filename)
+		result, err := exec.Run(settings)
+		//			result, err := workspace.Run(unitID)
+		if err == nil {
+			if err = getWant(result, src[i].Want); err != nil {
 				return testErr(err)
 			}
+		} else if err.Error() != src[i].Want {
+			return testErr(err)
 		}
-		return nil
 	}
+	return nil
+}
+
+func TestGentee(t *testing.T) {
+	workspace := New()
+
 	for _, name := range []string{`run_test`, `err_test`} {
-		if err := testFile(name); err != nil {
+		if err := testFile(workspace, name); err != nil {
 			t.Error(err)
 			return
 		}
@@ -121,14 +137,14 @@ func TestGentee(t *testing.T) {
 		return
 	}
 	for _, file := range files {
-		if err := testFile(filepath.Join(`stdlib`, file.Name())); err != nil {
+		if err := testFile(workspace, filepath.Join(`stdlib`, file.Name())); err != nil {
 			t.Error(err)
 			return
 		}
 	}
 	if runtime.GOOS == `linux` {
 		for _, name := range []string{`linux_test`} {
-			if err := testFile(name); err != nil {
+			if err := testFile(workspace, name); err != nil {
 				t.Error(err)
 				return
 			}
@@ -155,3 +171,956 @@ func TestGentee(t *testing.T) {
 		return
 	}
 }
+
+func TestConcurrentCompile(t *testing.T) {
+	scripts := map[string]string{
+		`a.g`: `47`,
+		`d.g`: `30`,
+		`f.g`: `26`,
+	}
+	shared := New()
+	workspaces := []*Gentee{shared, New(), shared, New()}
+	var wg sync.WaitGroup
+	errs := make(chan error, 64)
+	for _, workspace := range workspaces {
+		for name, want := range scripts {
+			wg.Add(1)
+			go func(name, want string, workspace *Gentee) {
+				defer wg.Done()
+				exec, _, err := workspace.CompileFile(filepath.Join(`tests`, `scripts`, name))
+				if err != nil {
+					errs <- err
+					return
+				}
+				result, err := exec.Run(Settings{})
+				if err == nil {
+					err = getWant(result, want)
+				}
+				if err != nil {
+					errs <- fmt.Errorf(`%s: %v`, name, err)
+				}
+			}(name, want, workspace)
+		}
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestFS(t *testing.T) {
+	workspace := New()
+	workspace.FS = fstest.MapFS{
+		`main.g`: {Data: []byte(`include : "lib/math.g"
+import : "/lib/str.g"
+run str : return Hello(str(Sum(20, 22)))`)},
+		`lib/math.g`: {Data: []byte(`include : "base.g"
+func Sum(int a b) int : return a + b + Base()`)},
+		`lib/base.g`: {Data: []byte(`func Base() int : return 0`)},
+		`lib/str.g`:  {Data: []byte(`pub func Hello(str name) str : return "Hello, " + name`)},
+	}
+	exec, _, err := workspace.CompileFile(`main.g`)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	result, err := exec.Run(Settings{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if err = getWant(result, `Hello, 42`); err != nil {
+		t.Error(err)
+	}
+	if _, _, err = workspace.CompileFile(`../main.g`); err == nil {
+		t.Error(`invalid path has been compiled`)
+	}
+	if _, _, err = workspace.Compile(`include : "unknown.g"`, ``); err == nil {
+		t.Error(`unknown file has been included`)
+	}
+}
+
+func TestModules(t *testing.T) {
+	workspace := New()
+	workspace.FS = fstest.MapFS{
+		`app/gentee.mod`: {Data: []byte(`# modules
+mylib ../libs/mylib
+second libs/second/main.g`)},
+		`app/cmd/main.g`: {Data: []byte(`import {
+	"mylib" as my
+	"second" as sec
+	"mylib/extra.g"
+	"util.g"
+}
+struct Pair {
+	int NAME
+}
+func Calc(Pair p, int a b) int : return p.NAME + a - b
+func shadow() str {
+	Pair my = {NAME: 10}
+	int sec = 3
+	return str(my.Calc(2, 3)) + str(my.NAME) + str(sec)
+}
+run str : return str(my.Calc(2, 3)) + str(sec.Calc(2, 3)) + my.NAME + Extra() + Util() + shadow()`)},
+		`libs/mylib/mylib.g`:     {Data: []byte("pub func Calc(int a b) int : return a + b\npub const : NAME = `my`")},
+		`libs/mylib/extra.g`:     {Data: []byte(`pub func Extra() str : return "extra"`)},
+		`app/libs/second/main.g`: {Data: []byte(`pub func Calc(int a b) int : return a * b`)},
+		`search/util.g`:          {Data: []byte(`pub func Util() str : return "util"`)},
+	}
+	workspace.SearchPath = []string{`search`}
+	exec, _, err := workspace.CompileFile(`app/cmd/main.g`)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	result, err := exec.Run(Settings{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if err = getWant(result, `56myextrautil9103`); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDirectives(t *testing.T) {
+	workspace := New()
+	workspace.Tags = []string{`prod`}
+	if err := workspace.Define(`LEVEL`, `7`); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := workspace.Define(`level`, `7`); err == nil {
+		t.Error(`wrong constant name has been defined`)
+	}
+	exec, _, err := workspace.Compile(`run str {
+#if tag(prod) && LEVEL
+	return "prod" + str(LEVEL)
+#else
+	return "dev"
+#end
+}`, ``)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	result, err := exec.Run(Settings{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if err = getWant(result, `prod7`); err != nil {
+		t.Error(err)
+	}
+	// the functions which aren't supported on the platform are excluded with #if
+	for i, embed := range vm.EmbedFuncs {
+		if embed.Name == `Open` {
+			vm.EmbedFuncs[i].Platform = true
+			defer func() { vm.EmbedFuncs[i].Platform = false }()
+		}
+	}
+	workspace = New()
+	if _, _, err = workspace.Compile(`run : Open("a.txt")`, ``); err == nil ||
+		err.Error() != `[1:7] function Open is not supported on `+runtime.GOOS+`, exclude it with #if` {
+		t.Errorf(`wrong platform error %v`, err)
+	}
+	if _, _, err = workspace.Compile(`run {
+#if !`+runtime.GOOS+`
+	Open("a.txt")
+#end
+}`, ``); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestEnumWarnings(t *testing.T) {
+	workspace := New()
+	exec, unitID, err := workspace.Compile(`enum State { Wait, Run, Done }
+run str {
+	State s = State.Run
+	switch s
+	case State.Wait, State.Run: return "active"
+	return "done"
+}`, ``)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	warnings := workspace.Unit(unitID).Warnings
+	if len(warnings) != 1 || warnings[0] != `[4:2] switch over State doesn't cover Done` {
+		t.Errorf(`wrong warnings %v`, warnings)
+	}
+	result, err := exec.Run(Settings{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if err = getWant(result, `active`); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMaxThreads(t *testing.T) {
+	workspace := New()
+	exec, _, err := workspace.Compile(`run str {
+  chan.int started = NewChan(20)
+  for i in 1..5 {
+    go (started: started) {
+      Send(started, 1)
+      sleep(200)
+    }
+  }
+  sleep(100)
+  int running = Len(started)
+  arr.int items = {1, 2, 3, 4, 5, 6, 7}
+  go for v in items : Send(started, v)
+  return "\{running} \{Len(started)}"
+}`, ``)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	var settings Settings
+	settings.MaxThreads = 2
+	result, err := exec.Run(settings)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if err = getWant(result, `2 12`); err != nil {
+		t.Error(err)
+	}
+	// the waiting threads are not counted so the queued threads can start
+	exec, _, err = workspace.Compile(`run str {
+  thread th = go {
+    arr.int items = {1, 2, 3}
+    parallel(2) for v in items : Print(v)
+  }
+  wait(th)
+  chan.int ch = NewChan(1)
+  go (ch: ch) { Send(ch, 7) }
+  return "\{Receive(ch)}"
+}`, ``)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	settings.MaxThreads = 1
+	done := make(chan error, 1)
+	go func() {
+		result, err := exec.Run(settings)
+		if err == nil {
+			err = getWant(result, `7`)
+		}
+		done <- err
+	}()
+	select {
+	case err = <-done:
+		if err != nil {
+			t.Error(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error(`deadlock of the waiting threads`)
+	}
+}
+
+func TestStackSize(t *testing.T) {
+	workspace := New()
+	exec, _, err := workspace.Compile(`func sum(int n) int {
+  int a = n
+  int b = 1
+  int c = 2
+  int d = 3
+  str s = str(n)
+  if n == 0 : return 0
+  return sum(n - 1) + a + b + c + d - 6 + int(s) - n
+}
+run int : return sum(300)`, ``)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	result, err := exec.Run(Settings{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if err = getWant(result, `45150`); err != nil {
+		t.Error(err)
+	}
+	var settings Settings
+	settings.StackSize = 1000
+	if _, err = exec.Run(settings); err == nil ||
+		!strings.Contains(err.Error(), `maximum size of the stack has been reached`) {
+		t.Errorf(`wrong stack error %v`, err)
+	}
+	// the variables of the block are allocated at once
+	vars := make([]string, 100)
+	for i := range vars {
+		vars[i] = fmt.Sprintf("  int v%d = %d\n", i, i)
+	}
+	exec, _, err = workspace.Compile("run int {\n"+strings.Join(vars, ``)+"  return v99\n}", ``)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	settings.StackSize = 50
+	if _, err = exec.Run(settings); err == nil ||
+		!strings.Contains(err.Error(), `maximum size of the stack has been reached`) {
+		t.Errorf(`wrong stack error of variables %v`, err)
+	}
+}
+
+// BenchmarkLoop measures the speed of the commands which don't call any functions
+func BenchmarkLoop(b *testing.B) {
+	workspace := New()
+	exec, _, err := workspace.Compile(`run int {
+  int s
+  for i in 1..1000000 { s += i % 7 }
+  return s
+}`, ``)
+	if err != nil {
+		b.Fatal(err)
+	}
+	for n := 0; n < b.N; n++ {
+		result, err := exec.Run(Settings{})
+		if err != nil {
+			b.Fatal(err)
+		}
+		if result.(int64) != 2999998 {
+			b.Fatalf(`wrong result %v`, result)
+		}
+	}
+}
+
+func TestMemoryLimit(t *testing.T) {
+	var settings Settings
+	settings.MemoryLimit = 1 << 20
+	workspace := New()
+	for _, src := range []string{
+		`run str : return Repeat("abc", 1000000)`,
+		`run int {
+  arr.str list
+  for i in 1..100000 : list += "item \{i}"
+  return *list
+}`,
+		`run int {
+  buf b
+  for i in 1..10000 : b += Repeat("x", 200)
+  return *b
+}`,
+		`run str {
+  str s
+  str part = Repeat("0123456789", 100)
+  for i in 1..1500 : s += part
+  return s
+}`,
+		`var arr.str g
+run int {
+  for i in 1..2000 : g += Repeat("x", 1000)
+  return *g
+}`,
+		`run int {
+  arr.arr.str all
+  for i in 1..20 {
+    future.arr.str f = go {
+      arr.str list
+      for j in 1..400 : list += Repeat("x", 1000)
+      return list
+    }
+    all += f.Get()
+  }
+  return *all
+}`,
+		`run int {
+  arr.arr.str all
+  for i in 1..20 {
+    chan.arr.str ch = NewChan(1)
+    thread th = go (ch: ch) {
+      arr.str list
+      for j in 1..400 : list += Repeat("x", 1000)
+      Send(ch, list)
+    }
+    wait(th)
+    all += Receive(ch)
+  }
+  return *all
+}`,
+	} {
+		exec, _, err := workspace.Compile(src, ``)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if _, err = exec.Run(Settings{}); err != nil {
+			t.Error(err)
+			return
+		}
+		if _, err = exec.Run(settings); err == nil ||
+			!strings.Contains(err.Error(), `memory limit has been exceeded`) {
+			t.Errorf(`wrong memory error %v`, err)
+		}
+	}
+	// the released memory is not counted
+	for _, src := range []string{
+		`run {
+  for i in 1..2000 {
+    str s = Repeat("x", 1000)
+  }
+}`,
+		`run int {
+  arr.int a b
+  for i in 1..1000 : a += i
+  for i in 1..2000 : b = a
+  return *b
+}`,
+		`var str g
+run {
+  for i in 1..2000 : g = Repeat("x", 1000)
+}`,
+	} {
+		exec, _, err := workspace.Compile(src, ``)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if _, err = exec.Run(settings); err != nil {
+			t.Error(err)
+		}
+	}
+	exec, _, err := workspace.Compile(`run str {
+  arr.int list
+  for i in 1..1000 : list += i
+  str s = Repeat("ab", 100)
+  try {
+    s = Repeat(s, 100000)
+  } catch err {
+    s = ErrText(err)
+    recover
+  }
+  return "\{*list} \{s}"
+}`, ``)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	result, err := exec.Run(settings)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if err = getWant(result, `1000 memory limit has been exceeded`); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPolicy(t *testing.T) {
+	dir := t.TempDir()
+	public := filepath.Join(dir, `public`)
+	secret := filepath.Join(public, `secret`)
+	var settings Settings
+	settings.Policy = &Policy{Rules: []Rule{
+		{Cap: CapRead, Target: secret, Deny: true},
+		{Cap: CapRead, Target: dir},
+		{Cap: CapWrite, Target: public},
+		{Cap: CapExec, Target: `echo`},
+		{Cap: CapNetwork, Target: `example.com`},
+		{Cap: CapEnv, Target: `GENTEE_POLICY`},
+	}}
+	workspace := New()
+	for _, item := range []struct {
+		Src  string
+		Want string
+	}{
+		{`run : CreateDir(PATH)`, `permission denied: write ` + dir},
+		{`run str {
+  CreateDir(PATH + "/public/secret")
+  WriteFile(PATH + "/public/secret/a.txt", "ok")
+  WriteFile(PATH + "/public/a.txt", "public")
+  return ReadFile(PATH + "/public/a.txt")
+}`, `public`},
+		{`run str : return ReadFile(PATH + "/public/secret/a.txt")`,
+			`permission denied: read ` + filepath.Join(secret, `a.txt`)},
+		{`run str : return str(*ReadDir(PATH))`, `1`},
+		{`run : CopyFile(PATH + "/public/a.txt", PATH + "/b.txt")`,
+			`permission denied: write ` + filepath.Join(dir, `b.txt`)},
+		{`run int {
+  file f = OpenFile(PATH + "/public/a.txt", READONLY)
+  CloseFile(f)
+  f = OpenFile(PATH + "/public/a.txt", 0)
+  CloseFile(f)
+  return 1
+}`, `1`},
+		{"run str : return $ echo ok\n", `ok`},
+		{"run str : return $ ls\n", `permission denied: exec ls`},
+		{`run str : return $GENTEE_POLICY = "ok"`, `ok`},
+		{`run str : return $GENTEE_OTHER = "ok"`, `permission denied: env GENTEE_OTHER`},
+		{`run str : return HTTPPage("https://www.example.org/")`,
+			`permission denied: network www.example.org`},
+		{`run {
+  go {
+    Println("thread")
+  }
+}`, `permission denied: thread`},
+		{`run int {
+  go for i in 1..3 {
+    Print(i)
+  }
+  return 1
+}`, `permission denied: thread`},
+		{`run str {
+  try {
+    Remove(PATH + "/public/secret/a.txt")
+  } catch err {
+    recover
+  }
+  return "ok"
+}`, `ok`},
+	} {
+		src := strings.ReplaceAll(item.Src, `PATH`, `"`+filepath.ToSlash(dir)+`"`)
+		exec, _, err := workspace.Compile(src, ``)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		result, err := exec.Run(settings)
+		if err != nil {
+			result = err.Error()
+		}
+		if !strings.HasSuffix(strings.TrimSpace(fmt.Sprint(result)), item.Want) {
+			t.Errorf("wrong result %v for\n%s", result, src)
+		}
+	}
+}
+
+func TestUnpackPolicy(t *testing.T) {
+	dir := t.TempDir()
+	sandbox := filepath.Join(dir, `sandbox`)
+	if err := os.MkdirAll(sandbox, 0755); err != nil {
+		t.Error(err)
+		return
+	}
+	var zbuf, tbuf bytes.Buffer
+	zw := zip.NewWriter(&zbuf)
+	gw := gzip.NewWriter(&tbuf)
+	tw := tar.NewWriter(gw)
+	for _, name := range []string{`../../outside/pwned.txt`, `sub/../../../outside/pwned.txt`,
+		`secret/a.txt`} {
+		w, err := zw.Create(name)
+		if err == nil {
+			_, err = w.Write([]byte(`pwned`))
+		}
+		if err == nil {
+			err = tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: 5,
+				Typeflag: tar.TypeReg})
+		}
+		if err == nil {
+			_, err = tw.Write([]byte(`pwned`))
+		}
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	zw.Close()
+	tw.Close()
+	gw.Close()
+	if err := ioutil.WriteFile(filepath.Join(sandbox, `a.zip`), zbuf.Bytes(), 0644); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := ioutil.WriteFile(filepath.Join(sandbox, `a.tar.gz`), tbuf.Bytes(), 0644); err != nil {
+		t.Error(err)
+		return
+	}
+	var settings Settings
+	settings.Policy = &Policy{Rules: []Rule{
+		{Cap: CapRead, Target: dir},
+		{Cap: CapWrite, Target: filepath.Join(sandbox, `x`, `secret`), Deny: true},
+		{Cap: CapWrite, Target: sandbox},
+	}}
+	denied := `permission denied: write ` + filepath.Join(sandbox, `x`, `secret`, `a.txt`)
+	workspace := New()
+	for _, item := range []struct {
+		Func    string
+		Pattern string
+		Want    string
+	}{
+		{`UnpackZip`, ``, `outside of the destination folder: ../../outside/pwned.txt`},
+		{`UnpackTarGz`, ``, `outside of the destination folder: ../../outside/pwned.txt`},
+		{`UnpackZip`, `/^sub/`, `outside of the destination folder: sub/../../../outside/pwned.txt`},
+		{`UnpackTarGz`, `/^sub/`, `outside of the destination folder: sub/../../../outside/pwned.txt`},
+		{`UnpackZip`, `/^secret/`, denied},
+		{`UnpackTarGz`, `/^secret/`, denied},
+	} {
+		ext := `.zip`
+		if item.Func == `UnpackTarGz` {
+			ext = `.tar.gz`
+		}
+		src := fmt.Sprintf(`run {
+  arr.str patterns = {"%s"}
+  arr.str ignore
+  %s("%s/a%s", "%[3]s/x", patterns, ignore)
+}`, item.Pattern, item.Func, filepath.ToSlash(sandbox), ext)
+		exec, _, err := workspace.Compile(src, ``)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		_, err = exec.Run(settings)
+		if err == nil || !strings.HasSuffix(err.Error(), item.Want) {
+			t.Errorf("wrong error %v for\n%s", err, src)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, `outside`)); !os.IsNotExist(err) {
+		t.Errorf(`the file has been unpacked outside of the destination folder`)
+	}
+	if _, err := os.Stat(filepath.Join(sandbox, `x`, `secret`)); !os.IsNotExist(err) {
+		t.Errorf(`the denied folder has been created`)
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	dir := filepath.ToSlash(t.TempDir())
+	workspace := New()
+	exec, _, err := workspace.Compile(strings.ReplaceAll(`run str {
+  WriteFile("DIR/a.txt", "data")
+  int size = CopyFile("DIR/a.txt", "DIR/b.txt")
+  str out = $ echo ok
+  Run("echo", "run")
+  CreateDir("DIR/sub")
+  Remove("DIR/a.txt")
+  file f = OpenFile("DIR/c.txt", CREATE)
+  Write(f, buf("xyz"))
+  CloseFile(f)
+  handle zf = CreateZip("DIR/d.zip")
+  CompressFile(zf, "DIR/b.txt", "b.txt")
+  CloseZip(zf)
+  str tmp = TempDir("DIR", "tmp")
+  $GENTEE_DRY = "on"
+  UnsetEnv("PATH")
+  return "\{ExistFile("DIR/a.txt")} \{size} [\{out}] [\{$GENTEE_DRY}] \{*$PATH > 0}"
+}`, `DIR`, dir), ``)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	var (
+		settings Settings
+		actions  []string
+	)
+	settings.DryRun = true
+	settings.ActionHandle = func(action Action) {
+		actions = append(actions, action.String())
+	}
+	result, err := exec.Run(settings)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if err = getWant(result, `false 0 [] [] true`); err != nil {
+		t.Error(err)
+	}
+	want := []string{
+		`[2:3] WriteFile("DIR/a.txt", "data")`,
+		`[3:14] CopyFile("DIR/a.txt", "DIR/b.txt")`,
+		`[4:13] CommandOutput("echo ok")`,
+		`[5:3] Run("echo", "run")`,
+		`[6:3] CreateDir("DIR/sub")`,
+		`[7:3] Remove("DIR/a.txt")`,
+		`[8:12] OpenFile("DIR/c.txt", 1)`,
+		`[9:3] Write("DIR/c.txt", buf[3])`,
+		`[11:15] CreateZip("DIR/d.zip")`,
+		`[12:3] CompressFile("DIR/d.zip", "DIR/b.txt", "b.txt")`,
+		`[14:13] TempDir("DIR", "tmp")`,
+		`[15:15] SetEnv("GENTEE_DRY", "on")`,
+		`[16:3] UnsetEnv("PATH")`,
+	}
+	if len(actions) != len(want) {
+		t.Errorf(`wrong actions %v`, actions)
+		return
+	}
+	for i, item := range want {
+		if item = strings.ReplaceAll(item, `DIR`, dir); actions[i] != item {
+			t.Errorf(`wrong action %s != %s`, actions[i], item)
+		}
+	}
+	if files, _ := ioutil.ReadDir(dir); len(files) != 0 {
+		t.Errorf(`dry run has created %d files`, len(files))
+	}
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		w.Write([]byte(`ok`))
+	}))
+	defer server.Close()
+	exec, _, err = workspace.Compile(strings.ReplaceAll(`run str {
+  map.str empty
+  str get = HTTPRequest("URL", "GET", empty, empty)
+  str del = HTTPRequest("URL", "delete", empty, empty)
+  return get + "|" + del
+}`, `URL`, server.URL), ``)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	actions = actions[:0]
+	if result, err = exec.Run(settings); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = getWant(result, `ok|`); err != nil {
+		t.Error(err)
+	}
+	if len(methods) != 1 || methods[0] != `GET` {
+		t.Errorf(`wrong requests %v`, methods)
+	}
+	if want := `[4:13] HTTPRequest("` + server.URL + `", "delete", map[], map[])`; len(actions) != 1 ||
+		actions[0] != want {
+		t.Errorf(`wrong actions %v != %s`, actions, want)
+	}
+}
+
+func TestAudit(t *testing.T) {
+	dir := filepath.ToSlash(t.TempDir())
+	workspace := New()
+	exec, _, err := workspace.Compile(strings.ReplaceAll(`run str {
+  WriteFile("DIR/a.txt", "data")
+  str out = $ echo ok
+  thread th = go {
+    CopyFile("DIR/a.txt", "DIR/b.txt")
+  }
+  wait(th)
+  $GENTEE_AUDIT = "on"
+  ReadFile("DIR/a.txt")
+  try {
+    Remove("DIR/none/a.txt")
+  } catch err {
+    recover
+  }
+  return out
+}`, `DIR`, dir), ``)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	logfile := filepath.Join(dir, `audit.log`)
+	audit, err := NewAuditLog(logfile)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	var settings Settings
+	settings.AuditHandle = audit.Handle
+	_, err = exec.Run(settings)
+	audit.Close()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	data, err := ioutil.ReadFile(logfile)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	want := []string{
+		`file WriteFile 0 2:3 [DIR/a.txt data]`,
+		`process CommandOutput 0 3:13 [echo ok]`,
+		`file CopyFile 1 5:5 [DIR/a.txt DIR/b.txt]`,
+		`env SetEnv 0 8:17 [GENTEE_AUDIT on]`,
+		`file Remove 0 11:5 [DIR/none/a.txt] error`,
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != len(want) {
+		t.Errorf(`wrong audit log %s`, data)
+		return
+	}
+	for i, line := range lines {
+		var event AuditEvent
+		if err = json.Unmarshal([]byte(line), &event); err != nil {
+			t.Error(err)
+			return
+		}
+		get := fmt.Sprintf(`%s %s %d %d:%d %v`, event.Type, event.Func, event.ThreadID,
+			event.Line, event.Pos, event.Args)
+		if len(event.Error) > 0 {
+			get += ` error`
+		}
+		if item := strings.ReplaceAll(want[i], `DIR`, dir); get != item {
+			t.Errorf(`wrong audit event %s != %s`, get, item)
+		}
+	}
+}
+
+func TestAuditLogError(t *testing.T) {
+	dir, err := ioutil.TempDir(``, `gentee`)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer os.RemoveAll(dir)
+	audit, err := NewAuditLog(filepath.Join(dir, `audit.log`))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	audit.Handle(AuditEvent{Type: vm.AuditFile, Func: `Remove`})
+	audit.Handle(AuditEvent{Type: vm.AuditFile, Func: `Write`, Args: []interface{}{math.NaN()}})
+	audit.Handle(AuditEvent{Type: vm.AuditFile, Func: `Remove`})
+	if err = audit.Close(); err == nil || !strings.Contains(err.Error(), `NaN`) {
+		t.Errorf(`wrong audit error %v`, err)
+	}
+	if _, err = os.Stat(`/dev/full`); err != nil {
+		return
+	}
+	if audit, err = NewAuditLog(`/dev/full`); err != nil {
+		t.Error(err)
+		return
+	}
+	audit.Handle(AuditEvent{Type: vm.AuditFile, Func: `Remove`})
+	if err = audit.Close(); err == nil {
+		t.Error(`the error of writing has been lost`)
+	}
+}
+
+func TestMemFS(t *testing.T) {
+	workspace := New()
+	exec, _, err := workspace.Compile(`run str {
+  CreateDir("/work/src/sub")
+  ChDir("/work")
+  WriteFile("src/a.txt", "alpha")
+  AppendFile("src/a.txt", "-beta")
+  WriteFile("src/sub/b.txt", "beta")
+  CopyFile("src/a.txt", "src/sub/c.txt")
+  Zip("/work/src.zip", "src")
+  UnpackZip("/work/src.zip", "dst")
+  Rename("dst/sub/c.txt", "dst/d.txt")
+  Remove("dst/a.txt")
+  RemoveDir("src/sub")
+  str ret = GetCurDir() + " " + ReadFile("dst/d.txt")
+  for item in ReadDir("/work/dst") {
+    ret += " " + item.Name
+  }
+  return ret
+}`, ``)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	mfs, err := NewMemFS(``)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	var settings Settings
+	settings.FS = mfs
+	result, err := exec.Run(settings)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if result.(string) != `/work alpha-beta d.txt sub` {
+		t.Errorf(`wrong result %s`, result)
+	}
+	files := strings.Join(mfs.Files(), ` `)
+	if files != `/work/ /work/dst/ /work/dst/d.txt /work/dst/sub/ /work/dst/sub/b.txt `+
+		`/work/src/ /work/src/a.txt /work/src.zip` {
+		t.Errorf(`wrong files %s`, files)
+	}
+	if data, err := mfs.ReadFile(`/work/dst/sub/b.txt`); err != nil || string(data) != `beta` {
+		t.Errorf(`wrong file %s %v`, data, err)
+	}
+	if _, err = os.Stat(`/work`); !os.IsNotExist(err) {
+		t.Errorf(`/work has been created on the disk`)
+	}
+
+	dir := t.TempDir()
+	if err = ioutil.WriteFile(filepath.Join(dir, `a.txt`), []byte(`disk`), 0644); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = os.Mkdir(filepath.Join(dir, `old`), 0755); err != nil {
+		t.Error(err)
+		return
+	}
+	exec, _, err = workspace.Compile(`run str {
+  str ret = ReadFile("/a.txt")
+  AppendFile("/a.txt", "+mem")
+  WriteFile("/b.txt", "new")
+  RemoveDir("/old")
+  return ret + " " + ReadFile("/a.txt")
+}`, ``)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if settings.FS, err = NewMemFS(dir); err != nil {
+		t.Error(err)
+		return
+	}
+	if result, err = exec.Run(settings); err != nil {
+		t.Error(err)
+		return
+	}
+	if result.(string) != `disk disk+mem` {
+		t.Errorf(`wrong result %s`, result)
+	}
+	if files = strings.Join(settings.FS.(*MemFS).Files(), ` `); files != `/a.txt /b.txt` {
+		t.Errorf(`wrong overlay files %s`, files)
+	}
+	list, err := ioutil.ReadDir(dir)
+	if err != nil || len(list) != 2 || list[0].Name() != `a.txt` || list[1].Name() != `old` {
+		t.Errorf(`the base directory has been changed`)
+	}
+	if data, _ := ioutil.ReadFile(filepath.Join(dir, `a.txt`)); string(data) != `disk` {
+		t.Errorf(`the base file has been changed %s`, data)
+	}
+}
+
+func TestNoOptimize(t *testing.T) {
+	src := `run str {
+  int i sum
+  while i < 10 {
+    i = i + 1
+    sum += 2 * 3
+    if 1 > 2 {
+      sum = 0
+    } elif i > 4 && i < 8 && i != 6 {
+      sum--
+    }
+  }
+  return ?(true, "\{sum} \{i}", "")
+}`
+	var codes []int
+	for _, noOptimize := range []bool{false, true} {
+		workspace := New()
+		workspace.NoOptimize = noOptimize
+		exec, _, err := workspace.Compile(src, ``)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		result, err := exec.Run(Settings{})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if err = getWant(result, `58 10`); err != nil {
+			t.Error(err)
+			return
+		}
+		codes = append(codes, len(exec.Code))
+	}
+	if codes[0] >= codes[1] {
+		t.Errorf(`optimized bytecode %d >= %d`, codes[0], codes[1])
+	}
+	workspace := New()
+	workspace.NoOptimize = true
+	for _, name := range []string{`run_test`, `err_test`} {
+		if err := testFile(workspace, name); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+}
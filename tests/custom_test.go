@@ -103,6 +103,13 @@ func custErr(s string) (string, error) {
 	return ``, fmt.Errorf("string %s is too long", s)
 }
 
+func splitNum(s string) (string, int64, error) {
+	if len(s) == 0 {
+		return ``, 0, fmt.Errorf("empty string")
+	}
+	return s[1:], int64(s[0]), nil
+}
+
 func mustErr() error {
 	return fmt.Errorf("custom error")
 }
@@ -215,6 +222,7 @@ var customLib = []gentee.EmbedItem{
 	{Prototype: `varPar(str) str`, Object: varPar},
 	{Prototype: `custErr(str) str`, Object: custErr},
 	{Prototype: `mustErr()`, Object: mustErr},
+	{Prototype: `splitNum(str) (str, int)`, Object: splitNum},
 	{Prototype: `rtStrStack(str) arr.str`, Object: rtStrStack},
 }
 
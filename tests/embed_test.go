@@ -0,0 +1,68 @@
+// Copyright 2019 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package test
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	gentee "github.com/gentee/gentee"
+	"github.com/gentee/gentee/vm"
+)
+
+var reflectOnce sync.Once
+
+// benchScript uses string and array functions. FUNC is replaced with the prefix of the names.
+const benchScript = `run int {
+  int total
+  for i in 1..2000 {
+    str s = FUNCUpper(FUNCRepeat("ab", 8))
+    s = FUNCReplace(s, "AB", "a,b,")
+    arr.str list = FUNCSplit(s, ",")
+    total += *FUNCJoin(list, "-")
+  }
+  return total
+}`
+
+// benchEmbed runs the script where the stdlib functions are called with the typed stubs or
+// the same functions are called as custom functions with reflect
+func benchEmbed(b *testing.B, prefix string) {
+	reflectOnce.Do(func() {
+		err := gentee.Customize(&gentee.Custom{
+			Embedded: []gentee.EmbedItem{
+				{Prototype: `ReflectUpper(str) str`, Object: vm.UpperºStr},
+				{Prototype: `ReflectRepeat(str,int) str`, Object: vm.RepeatºStrInt},
+				{Prototype: `ReflectReplace(str,str,str) str`, Object: vm.ReplaceºStrStrStr},
+				{Prototype: `ReflectSplit(str,str) arr.str`, Object: vm.SplitºStrStr},
+				{Prototype: `ReflectJoin(arr.str,str) str`, Object: vm.JoinºArrStr},
+			},
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+	})
+	workspace := gentee.New()
+	exec, _, err := workspace.Compile(strings.ReplaceAll(benchScript, `FUNC`, prefix), ``)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		result, err := exec.Run(gentee.Settings{})
+		if err != nil {
+			b.Fatal(err)
+		}
+		if result.(int64) != 2000*32 {
+			b.Fatalf(`wrong result %v`, result)
+		}
+	}
+}
+
+func BenchmarkEmbed(b *testing.B) {
+	b.Run(`typed`, func(b *testing.B) { benchEmbed(b, ``) })
+	b.Run(`reflect`, func(b *testing.B) { benchEmbed(b, `Reflect`) })
+}
@@ -0,0 +1,14 @@
+pub func Last<T>(arr.T a) T {
+  return a[*a-1]
+}
+
+pub
+func Pair<T>(T a b) str {
+  return "\{a}" + ` & ` + "\{b}"
+}
+
+func Reverse<T>(arr.T a) arr.T {
+  arr.T ret
+  for i in 1..*a : ret += a[*a-i]
+  return ret
+}
@@ -0,0 +1,7 @@
+pub var int hits = 1
+var str prefix = "#"
+
+pub func hit() str {
+    hits++
+    return prefix + str(hits)
+}
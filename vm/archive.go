@@ -20,25 +20,26 @@ import (
 
 type UnzipFile struct {
 	Name   string
-	Reader *zip.ReadCloser
+	File   core.FileHandle
+	Reader *zip.Reader
 }
 
 type ZipFile struct {
 	Name   string
-	File   *os.File
+	File   core.FileHandle
 	Writer *zip.Writer
 }
 
 type GzFile struct {
 	Name      string
-	File      *os.File
+	File      core.FileHandle
 	GzWriter  *gzip.Writer
 	TarWriter *tar.Writer
 }
 
 type UntargzFile struct {
 	Name      string
-	File      *os.File
+	File      core.FileHandle
 	GzReader  *gzip.Reader
 	TarReader *tar.Reader
 }
@@ -104,7 +105,7 @@ func CompressFile(rt *Runtime, pack Pack, filename, packname string) error {
 			return err
 		}
 	}
-	finfo, err := os.Stat(filename)
+	finfo, err := rt.Owner.Settings.FS.Stat(filename)
 	if err != nil {
 		return err
 	}
@@ -120,7 +121,7 @@ func CompressFile(rt *Runtime, pack Pack, filename, packname string) error {
 	if finfo.IsDir() {
 		return nil
 	}
-	file, err := os.Open(filename)
+	file, err := rt.Owner.Settings.FS.OpenFile(filename, os.O_RDONLY, 0)
 	if err != nil {
 		return err
 	}
@@ -168,15 +169,20 @@ func CreateZip(rt *Runtime, filename string) (*ZipFile, error) {
 			return nil, err
 		}
 	}
-	zipfile, err := os.Create(filename)
+	zipfile, err := rt.Owner.Settings.FS.OpenFile(filename, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0666)
 	if err != nil {
 		return nil, err
 	}
+	return newZipFile(filename, zipfile), nil
+}
+
+// newZipFile returns the handle of zip archive which is written to the file
+func newZipFile(filename string, zipfile core.FileHandle) *ZipFile {
 	archive := zip.NewWriter(zipfile)
 	archive.RegisterCompressor(zip.Deflate, func(out io.Writer) (io.WriteCloser, error) {
 		return flate.NewWriter(out, flate.BestCompression)
 	})
-	return &ZipFile{Name: filename, File: zipfile, Writer: archive}, nil
+	return &ZipFile{Name: filename, File: zipfile, Writer: archive}
 }
 
 // CreateTarGz creates tar.gz file and returns its handle
@@ -186,12 +192,17 @@ func CreateTarGz(rt *Runtime, filename string) (*GzFile, error) {
 			return nil, err
 		}
 	}
-	gzfile, err := os.Create(filename)
+	gzfile, err := rt.Owner.Settings.FS.OpenFile(filename, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0666)
 	if err != nil {
 		return nil, err
 	}
-	var gw *gzip.Writer
-	if gw, err = gzip.NewWriterLevel(gzfile, gzip.BestCompression); err != nil {
+	return newGzFile(filename, gzfile)
+}
+
+// newGzFile returns the handle of tar.gz archive which is written to the file
+func newGzFile(filename string, gzfile core.FileHandle) (*GzFile, error) {
+	gw, err := gzip.NewWriterLevel(gzfile, gzip.BestCompression)
+	if err != nil {
 		return nil, err
 	}
 	return &GzFile{Name: filename, File: gzfile, GzWriter: gw, TarWriter: tar.NewWriter(gw)}, nil
@@ -204,16 +215,26 @@ func openUnzip(rt *Runtime, zipfile string) (*UnzipFile, error) {
 			return nil, err
 		}
 	}
-	archive, err := zip.OpenReader(zipfile)
+	file, err := rt.Owner.Settings.FS.OpenFile(zipfile, os.O_RDONLY, 0)
+	if err != nil {
+		return nil, err
+	}
+	finfo, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	archive, err := zip.NewReader(file, finfo.Size())
 	if err != nil {
+		file.Close()
 		return nil, err
 	}
-	return &UnzipFile{Name: zipfile, Reader: archive}, nil
+	return &UnzipFile{Name: zipfile, File: file, Reader: archive}, nil
 }
 
 // closeUnzip closes the opened zip file
 func closeUnzip(zfile *UnzipFile) (err error) {
-	return zfile.Reader.Close()
+	return zfile.File.Close()
 }
 
 // openUntargz opens tar.gz file and returns its handle
@@ -223,7 +244,7 @@ func openUntargz(rt *Runtime, gzfile string) (*UntargzFile, error) {
 			return nil, err
 		}
 	}
-	file, err := os.Open(gzfile)
+	file, err := rt.Owner.Settings.FS.OpenFile(gzfile, os.O_RDONLY, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -292,7 +313,7 @@ func TarGz(rt *Runtime, targzfile string, path string) error {
 		err  error
 		list *core.Array
 	)
-	if path, err = filepath.Abs(path); err != nil {
+	if path, err = rt.Owner.Settings.FS.Abs(path); err != nil {
 		return err
 	}
 	if list, err = archiveList(rt, path); err != nil {
@@ -317,6 +338,10 @@ func UnpackTarGz(rt *Runtime, filename string, dir string) error {
 // UnpackTarGzºStr unpacks a .tar.gz file to the specified folder
 func UnpackTarGzºStr(rt *Runtime, filename string, dir string, patterns *core.Array,
 	ignore *core.Array) error {
+	var err error
+	if dir, err = rt.Owner.Settings.FS.Abs(dir); err != nil {
+		return err
+	}
 	gzfile, err := openUntargz(rt, filename)
 	if err != nil {
 		return err
@@ -369,7 +394,7 @@ func UnpackZipºStr(rt *Runtime, zipfile string, dir string, patterns *core.Arra
 			return err
 		}
 	}
-	if dir, err = filepath.Abs(dir); err != nil {
+	if dir, err = rt.Owner.Settings.FS.Abs(dir); err != nil {
 		return err
 	}
 	zfile, err := openUnzip(rt, zipfile)
@@ -419,7 +444,7 @@ func ZipºStr(rt *Runtime, zipfile string, path string) error {
 		err  error
 		list *core.Array
 	)
-	if path, err = filepath.Abs(path); err != nil {
+	if path, err = rt.Owner.Settings.FS.Abs(path); err != nil {
 		return err
 	}
 	if list, err = archiveList(rt, path); err != nil {
@@ -446,10 +471,10 @@ func archiveList(rt *Runtime, path string) (*core.Array, error) {
 		}
 	}
 	list := core.NewArray()
-	if path, err = filepath.Abs(path); err != nil {
+	if path, err = rt.Owner.Settings.FS.Abs(path); err != nil {
 		return nil, err
 	}
-	if finfo, err = os.Stat(path); err != nil {
+	if finfo, err = rt.Owner.Settings.FS.Stat(path); err != nil {
 		return nil, err
 	}
 	if finfo.IsDir() {
@@ -527,6 +552,15 @@ func packFiles(rt *Runtime, pack Pack, list *core.Array, path string) error {
 func prepareDecompress(rt *Runtime, filename string, finfo os.FileInfo, dir string,
 	created map[string]bool) (path string, err error) {
 	folder := filepath.Dir(strings.TrimRight(filename, `/`))
+	dest := filepath.Join(dir, folder, finfo.Name())
+	if !insidePath(dir, dest) {
+		return ``, newError(ErrArchivePath, filename)
+	}
+	if rt.Owner.Settings.Policy != nil {
+		if err = checkCapability(&rt.Owner.Settings, CapWrite, dest); err != nil {
+			return
+		}
+	}
 	path = dir
 	if len(folder) > 0 {
 		path = filepath.Join(dir, folder)
@@ -548,15 +582,15 @@ func prepareDecompress(rt *Runtime, filename string, finfo os.FileInfo, dir stri
 
 func unpackFile(rt *Runtime, finfo os.FileInfo, reader io.Reader, dest string) error {
 	if finfo.IsDir() {
-		return os.MkdirAll(dest, finfo.Mode())
+		return rt.Owner.Settings.FS.MkdirAll(dest, finfo.Mode())
 	}
-	target, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, finfo.Mode())
+	target, err := rt.Owner.Settings.FS.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, finfo.Mode())
 	if err != nil {
 		return err
 	}
 	defer func() {
 		target.Close()
-		os.Chtimes(dest, finfo.ModTime(), finfo.ModTime())
+		rt.Owner.Settings.FS.Chtimes(dest, finfo.ModTime(), finfo.ModTime())
 	}()
 	var (
 		prog   *Progress
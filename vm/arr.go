@@ -5,7 +5,6 @@
 package vm
 
 import (
-	"fmt"
 	"sort"
 	"strings"
 
@@ -55,7 +54,7 @@ func ReverseºArr(arr *core.Array) *core.Array {
 func SliceºArr(rt *Runtime, arr *core.Array, start, end int64) (*core.Array, error) {
 	ret := core.NewArray()
 	if start < 0 || end > int64(len(arr.Data)) {
-		return ret, fmt.Errorf(ErrorText(ErrInvalidParam))
+		return ret, newError(ErrInvalidParam)
 	}
 	if end == 0 {
 		end = int64(len(arr.Data))
@@ -0,0 +1,218 @@
+// Copyright 2019 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package vm
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gentee/gentee/core"
+)
+
+// Types of the audit events
+const (
+	AuditFile    = `file`    // writing or deleting files
+	AuditProcess = `process` // starting processes
+	AuditNetwork = `network` // HTTP requests
+	AuditEnv     = `env`     // changing environment variables
+	AuditArchive = `archive` // creating and unpacking archives
+)
+
+// AuditEvent describes the side-effecting operation of the script
+type AuditEvent struct {
+	Type     string        `json:"type"`
+	Func     string        `json:"func"`
+	Args     []interface{} `json:"args"`
+	ThreadID int64         `json:"thread"`
+	Path     string        `json:"path"`
+	Line     int64         `json:"line"`
+	Pos      int64         `json:"pos"`
+	Start    time.Time     `json:"start"`
+	Duration time.Duration `json:"duration"` // in nanoseconds
+	Error    string        `json:"error,omitempty"`
+
+	level int // the level of the call in the trace
+}
+
+// AuditFunc gets the audit events. It can be called from different threads at the same time.
+type AuditFunc func(AuditEvent)
+
+// sideEffect describes the embedded function which changes something outside of the script.
+// Such functions are reported to Settings.AuditHandle and they are not called in DryRun mode.
+type sideEffect struct {
+	Event   string                          // the type of the audit event
+	Get     bool                            // the function only gets data if Changes is nil or false
+	Changes func([]interface{}) bool        // it checks the parameters if the call changes something
+	DryRun  func([]interface{}) interface{} // the result in DryRun mode instead of the default value
+}
+
+// auditFuncs contains the embedded functions with side effects
+var auditFuncs = map[string]sideEffect{
+	`AppendFile(str,buf)`:                  {Event: AuditFile},
+	`AppendFile(str,str)`:                  {Event: AuditFile},
+	`ChMode(str,int)`:                      {Event: AuditFile},
+	`CopyFile(str,str)`:                    {Event: AuditFile},
+	`CreateDir(str)`:                       {Event: AuditFile},
+	`CreateFile(str,bool)`:                 {Event: AuditFile},
+	`OpenFile(str,int)`:                    {Event: AuditFile, Changes: openChanges, DryRun: dryOpenFile},
+	`Remove(str)`:                          {Event: AuditFile},
+	`RemoveDir(str)`:                       {Event: AuditFile},
+	`Rename(str,str)`:                      {Event: AuditFile},
+	`SetFileTime(str,time)`:                {Event: AuditFile},
+	`TempDir(str,str)`:                     {Event: AuditFile},
+	`Write(file,buf)`:                      {Event: AuditFile, DryRun: dryFirstArg},
+	`WriteFile(str,buf)`:                   {Event: AuditFile},
+	`WriteFile(str,str)`:                   {Event: AuditFile},
+	`Command(str)`:                         {Event: AuditProcess},
+	`CommandOutput(str)`:                   {Event: AuditProcess},
+	`Open(str)`:                            {Event: AuditProcess},
+	`OpenWith(str,str)`:                    {Event: AuditProcess},
+	`sysRun(str,bool,buf,buf,buf,arr.str)`: {Event: AuditProcess},
+	`Download(str,str)`:                    {Event: AuditNetwork},
+	`HeadInfo(str)`:                        {Event: AuditNetwork, Get: true},
+	`HTTPGet(str)`:                         {Event: AuditNetwork, Get: true},
+	`HTTPPage(str)`:                        {Event: AuditNetwork, Get: true},
+	`HTTPRequest(str,str,map.str,map.str)`: {Event: AuditNetwork, Get: true, Changes: requestChanges},
+	`SetEnv(str,bool)`:                     {Event: AuditEnv},
+	`SetEnv(str,int)`:                      {Event: AuditEnv},
+	`SetEnv(str,str)`:                      {Event: AuditEnv},
+	`UnsetEnv(str)`:                        {Event: AuditEnv},
+	`CompressFile(handle,str,str)`:         {Event: AuditArchive},
+	`CreateTarGz(str)`:                     {Event: AuditArchive, DryRun: dryCreateTarGz},
+	`CreateZip(str)`:                       {Event: AuditArchive, DryRun: dryCreateZip},
+	`TarGz(str,str)`:                       {Event: AuditArchive},
+	`UnpackTarGz(str,str)`:                 {Event: AuditArchive},
+	`UnpackTarGz(str,str,arr.str,arr.str)`: {Event: AuditArchive},
+	`UnpackZip(str,str)`:                   {Event: AuditArchive},
+	`UnpackZip(str,str,arr.str,arr.str)`:   {Event: AuditArchive},
+	`Zip(str,str)`:                         {Event: AuditArchive},
+}
+
+// embedEffects contains the side effects of EmbedFuncs by their indexes
+var embedEffects []*sideEffect
+
+func init() {
+	embedEffects = make([]*sideEffect, len(EmbedFuncs))
+	for i, embed := range EmbedFuncs {
+		if effect, ok := auditFuncs[embedKey(embed)]; ok {
+			embedEffects[i] = &effect
+		}
+	}
+}
+
+// openChanges returns true if OpenFile creates or truncates the file
+func openChanges(args []interface{}) bool {
+	return args[1].(int64)&(FileCreate|FileTrunc) != 0
+}
+
+// requestChanges returns true if HTTPRequest uses the method which can change something
+func requestChanges(args []interface{}) bool {
+	method := strings.ToUpper(args[1].(string))
+	return method != `GET` && method != `HEAD`
+}
+
+// auditArg converts the parameter to the value which can be marshaled to JSON
+func auditArg(value interface{}) interface{} {
+	switch v := value.(type) {
+	case int64, float64:
+		return v
+	case string:
+		if runes := []rune(v); len(runes) > 1024 {
+			v = string(runes[:1024]) + `...`
+		}
+		return v
+	case *core.Buffer:
+		return fmt.Sprintf(`buf[%d]`, len(v.Data))
+	case *core.File:
+		return v.Name
+	case *core.Array:
+		ret := make([]interface{}, len(v.Data))
+		for i, item := range v.Data {
+			ret[i] = auditArg(item)
+		}
+		return ret
+	case Pack:
+		return v.FileName()
+	}
+	return fmt.Sprint(value)
+}
+
+// startAudit returns the event of the embedded function before its calling. It returns nil
+// if the call doesn't change anything.
+func startAudit(rt *Runtime, top Call, idEmbed uint16) *AuditEvent {
+	embed := EmbedFuncs[idEmbed]
+	effect := embedEffects[idEmbed]
+	args, _ := embedArgs(rt, top, embed.Params)
+	if effect.Changes != nil && !effect.Get && !effect.Changes(args) {
+		return nil
+	}
+	event := AuditEvent{
+		Type:     effect.Event,
+		ThreadID: rt.ThreadID,
+		Start:    time.Now(),
+	}
+	event.Func, args, event.level = embedCall(embed, args)
+	event.Args = make([]interface{}, len(args))
+	for i, arg := range args {
+		event.Args[i] = auditArg(arg)
+	}
+	return &event
+}
+
+// finishAudit sends the event of the called embedded function to Settings.AuditHandle
+func finishAudit(rt *Runtime, event *AuditEvent, pos int64, err error) {
+	event.Duration = time.Since(event.Start)
+	if err != nil {
+		event.Error = err.Error()
+	}
+	event.Path, event.Line, event.Pos = callPosition(rt, pos, event.level)
+	rt.Owner.Settings.AuditHandle(*event)
+}
+
+// AuditLog is the sink which appends the audit events to the file in JSON lines format
+type AuditLog struct {
+	mutex sync.Mutex
+	file  *os.File
+	err   error // the first error of writing the events
+}
+
+// NewAuditLog opens or creates the file for the audit events
+func NewAuditLog(filename string) (*AuditLog, error) {
+	file, err := os.OpenFile(filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &AuditLog{file: file}, nil
+}
+
+// Handle writes the event to the file. It can be assigned to Settings.AuditHandle.
+// The first error is kept and returned by Close.
+func (audit *AuditLog) Handle(event AuditEvent) {
+	data, err := json.Marshal(event)
+	audit.mutex.Lock()
+	defer audit.mutex.Unlock()
+	if err == nil {
+		_, err = audit.file.Write(append(data, '\n'))
+	}
+	if err != nil && audit.err == nil {
+		audit.err = err
+	}
+}
+
+// Close closes the file of the audit log. It returns the first error of writing the events
+// if there was any.
+func (audit *AuditLog) Close() error {
+	err := audit.file.Close()
+	audit.mutex.Lock()
+	defer audit.mutex.Unlock()
+	if audit.err != nil {
+		return audit.err
+	}
+	return err
+}
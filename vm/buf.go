@@ -37,7 +37,7 @@ func AssignAddºBufChar(buf interface{}, value interface{}) (interface{}, error)
 // AssignAddºBufInt appends one byte to buffer
 func AssignAddºBufInt(buf interface{}, value interface{}) (interface{}, error) {
 	if uint64(value.(int64)) > 255 {
-		return nil, fmt.Errorf(ErrorText(ErrByteOut))
+		return nil, newError(ErrByteOut)
 	}
 	buf.(*core.Buffer).Data = append(buf.(*core.Buffer).Data, byte(value.(int64)))
 	return buf, nil
@@ -73,7 +73,7 @@ func bufºStr(value string) *core.Buffer {
 func DelºBufIntInt(buf *core.Buffer, off, length int64) (*core.Buffer, error) {
 	size := int64(len(buf.Data))
 	if off < 0 || off > size {
-		return buf, fmt.Errorf(ErrorText(ErrInvalidParam))
+		return buf, newError(ErrInvalidParam)
 	}
 	if length < 0 {
 		off += length
@@ -100,7 +100,7 @@ func EncodeºBufInt(buf *core.Buffer, i int64) *core.Buffer {
 // DecodeºBufInt decodes int from buf
 func DecodeºBufInt(buf *core.Buffer, offset int64) (int64, error) {
 	if offset < 0 || offset+8 > int64(len(buf.Data)) {
-		return 0, fmt.Errorf(ErrorText(ErrDecode))
+		return 0, newError(ErrDecode)
 	}
 	return int64(binary.LittleEndian.Uint64(buf.Data[offset : offset+8])), nil
 }
@@ -114,21 +114,24 @@ func HexºBuf(buf *core.Buffer) string {
 func InsertºBufIntBuf(buf *core.Buffer, off int64, b *core.Buffer) (*core.Buffer, error) {
 	size := int64(len(buf.Data))
 	if off < 0 || off > size {
-		return buf, fmt.Errorf(ErrorText(ErrInvalidParam))
+		return buf, newError(ErrInvalidParam)
 	}
 	buf.Data = append(buf.Data[:off], append(b.Data, buf.Data[off:]...)...)
 	return buf, nil
 }
 
 // SetLenºBuf sets the length of the buffer
-func SetLenºBuf(buf *core.Buffer, size int64) (*core.Buffer, error) {
+func SetLenºBuf(rt *Runtime, buf *core.Buffer, size int64) (*core.Buffer, error) {
 	if size < 0 {
-		return buf, fmt.Errorf(ErrorText(ErrInvalidParam))
+		return buf, newError(ErrInvalidParam)
 	}
 	length := int64(len(buf.Data))
 	if size < length {
 		buf.Data = buf.Data[:size]
 	} else if size > length {
+		if err := checkMemory(rt, size-length); err != nil {
+			return buf, err
+		}
 		buf.Data = append(buf.Data, make([]byte, size-length)...)
 	}
 	return buf, nil
@@ -176,7 +179,7 @@ func WriteºBuf(buf *core.Buffer, offset int64, input *core.Buffer) (*core.Buffe
 	length := int64(len(buf.Data))
 	ilen := int64(len(input.Data))
 	if offset < 0 || offset > length {
-		return buf, fmt.Errorf(ErrorText(ErrInvalidParam))
+		return buf, newError(ErrInvalidParam)
 	}
 	count := ilen
 	if offset+ilen > length {
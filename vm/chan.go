@@ -0,0 +1,255 @@
+// Copyright 2019 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package vm
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/gentee/gentee/core"
+)
+
+const (
+	// ChanRecv is the receive case of select statement
+	ChanRecv = iota
+	// ChanSend is the send case of select statement
+	ChanSend
+)
+
+// errThread is the error of another thread which has been received by the main thread
+// while waiting for the channel
+type errThread struct {
+	error
+}
+
+// chanSelect waits until one of the cases is ready. The thread gets ThWait status while it
+// is waiting so suspend and terminate work as usual. It returns -1 if isDefault is true
+// and there are not any ready cases.
+func chanSelect(rt *Runtime, cases []reflect.SelectCase, isDefault bool) (chosen int,
+	value interface{}, ok bool, err error) {
+	var recv reflect.Value
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = newError(ErrChanClosed)
+			rt.Owner.ThreadMutex.Lock()
+			if rt.Thread.Status == ThWait {
+				rt.Owner.switchStatus(rt.Thread, ThWork)
+			}
+			rt.Owner.ThreadMutex.Unlock()
+		}
+	}()
+	count := len(cases)
+	chosen, recv, ok = reflect.Select(append(cases, reflect.SelectCase{Dir: reflect.SelectDefault}))
+	if chosen == count {
+		if isDefault {
+			return -1, nil, false, nil
+		}
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv,
+			Chan: reflect.ValueOf(rt.Thread.Chan)})
+		if rt.ThreadID == 0 {
+			cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv,
+				Chan: reflect.ValueOf(rt.Owner.ChError)})
+		}
+		rt.setStatus(ThWait)
+		for {
+			chosen, recv, ok = reflect.Select(cases)
+			if chosen < count {
+				break
+			}
+			if chosen == count {
+				if recv.Int() == ThCmdClose {
+					rt.setStatus(ThClosed)
+					return -1, nil, false, newError(ErrThreadClosed)
+				}
+				// the thread has been resumed after suspending
+				rt.setStatus(ThWait)
+				continue
+			}
+			rt.setStatus(ThWork)
+			return -1, nil, false, errThread{recv.Interface().(error)}
+		}
+		rt.Owner.ThreadMutex.Lock()
+		if rt.Thread.Status == ThWait {
+			rt.Owner.switchStatus(rt.Thread, ThWork)
+		}
+		rt.Owner.ThreadMutex.Unlock()
+	}
+	if cases[chosen].Dir == reflect.SelectRecv && ok {
+		value = recv.Interface()
+		err = receivedMemory(rt, value)
+	}
+	return
+}
+
+func chanSend(rt *Runtime, ch *core.Chan, value interface{}) error {
+	_, _, _, err := chanSelect(rt, []reflect.SelectCase{{Dir: reflect.SelectSend,
+		Chan: reflect.ValueOf(ch.Data), Send: reflect.ValueOf(&value).Elem()}}, false)
+	return err
+}
+
+func chanReceive(rt *Runtime, ch *core.Chan) (interface{}, error) {
+	_, value, ok, err := chanSelect(rt, []reflect.SelectCase{{Dir: reflect.SelectRecv,
+		Chan: reflect.ValueOf(ch.Data)}}, false)
+	if err == nil && !ok {
+		err = newError(ErrChanClosed)
+	}
+	return value, err
+}
+
+// NewChanºInt returns a new channel with the specified size of the buffer
+func NewChanºInt(size int64) (*core.Chan, error) {
+	if size < 0 {
+		return nil, newError(ErrInvalidParam)
+	}
+	return core.NewChan(int(size)), nil
+}
+
+// closeºChan closes the channel
+func closeºChan(ch *core.Chan) error {
+	ch.Mutex.Lock()
+	defer ch.Mutex.Unlock()
+	if ch.Closed {
+		return newError(ErrChanClosed)
+	}
+	ch.Closed = true
+	close(ch.Data)
+	return nil
+}
+
+// LenºChan returns the number of values in the buffer of the channel
+func LenºChan(ch *core.Chan) int64 {
+	return int64(len(ch.Data))
+}
+
+// CapºChan returns the size of the buffer of the channel
+func CapºChan(ch *core.Chan) int64 {
+	return int64(cap(ch.Data))
+}
+
+// AfterºInt returns the channel which receives the duration after it has elapsed
+func AfterºInt(d int64) *core.Chan {
+	ch := core.NewChan(1)
+	time.AfterFunc(time.Duration(d)*time.Millisecond, func() {
+		ch.Data <- d
+	})
+	return ch
+}
+
+// SendºChanInt sends int value to the channel
+func SendºChanInt(rt *Runtime, ch *core.Chan, value int64) error {
+	return chanSend(rt, ch, value)
+}
+
+// SendºChanFloat sends float value to the channel
+func SendºChanFloat(rt *Runtime, ch *core.Chan, value float64) error {
+	return chanSend(rt, ch, value)
+}
+
+// SendºChanStr sends str value to the channel
+func SendºChanStr(rt *Runtime, ch *core.Chan, value string) error {
+	return chanSend(rt, ch, value)
+}
+
+// SendºChanAny sends the copy of the value to the channel
+func SendºChanAny(rt *Runtime, ch *core.Chan, value interface{}) error {
+	var copied interface{}
+	CopyVar(rt, &copied, value)
+	return chanSend(rt, ch, copied)
+}
+
+// ReceiveºChanInt receives int value from the channel
+func ReceiveºChanInt(rt *Runtime, ch *core.Chan) (int64, error) {
+	value, err := chanReceive(rt, ch)
+	if err != nil {
+		return 0, err
+	}
+	return value.(int64), nil
+}
+
+// ReceiveºChanFloat receives float value from the channel
+func ReceiveºChanFloat(rt *Runtime, ch *core.Chan) (float64, error) {
+	value, err := chanReceive(rt, ch)
+	if err != nil {
+		return 0, err
+	}
+	return value.(float64), nil
+}
+
+// ReceiveºChanStr receives str value from the channel
+func ReceiveºChanStr(rt *Runtime, ch *core.Chan) (string, error) {
+	value, err := chanReceive(rt, ch)
+	if err != nil {
+		return ``, err
+	}
+	return value.(string), nil
+}
+
+// ReceiveºChanAny receives a value from the channel
+func ReceiveºChanAny(rt *Runtime, ch *core.Chan) (interface{}, error) {
+	return chanReceive(rt, ch)
+}
+
+// ReceivedºChanInt returns int value received by select or for statement
+func ReceivedºChanInt(rt *Runtime) int64 {
+	return rt.Received.(int64)
+}
+
+// ReceivedºChanFloat returns float value received by select or for statement
+func ReceivedºChanFloat(rt *Runtime) float64 {
+	return rt.Received.(float64)
+}
+
+// ReceivedºChanStr returns str value received by select or for statement
+func ReceivedºChanStr(rt *Runtime) string {
+	return rt.Received.(string)
+}
+
+// ReceivedºChanAny returns the value received by select or for statement
+func ReceivedºChanAny(rt *Runtime) interface{} {
+	return rt.Received
+}
+
+// NextºChan receives the next value for for statement. It returns false if the channel
+// has been closed
+func NextºChan(rt *Runtime, ch *core.Chan) (int64, error) {
+	_, value, ok, err := chanSelect(rt, []reflect.SelectCase{{Dir: reflect.SelectRecv,
+		Chan: reflect.ValueOf(ch.Data)}}, false)
+	if err != nil || !ok {
+		return 0, err
+	}
+	rt.Received = value
+	return 1, nil
+}
+
+// SelectºChan waits for one of the cases of select statement. The parameters are the sequences
+// of the kind of the case, the channel and the value for sending. It returns the number of
+// the chosen case or 0 for default case.
+func SelectºChan(rt *Runtime, isDefault int64, pars ...interface{}) (int64, error) {
+	cases := make([]reflect.SelectCase, 0, len(pars)/2)
+	for i := 0; i < len(pars); i += 2 {
+		selCase := reflect.SelectCase{Dir: reflect.SelectRecv,
+			Chan: reflect.ValueOf(pars[i+1].(*core.Chan).Data)}
+		if pars[i].(int64) == ChanSend {
+			var value interface{}
+			CopyVar(rt, &value, pars[i+2])
+			selCase.Dir = reflect.SelectSend
+			selCase.Send = reflect.ValueOf(&value).Elem()
+			i++
+		}
+		cases = append(cases, selCase)
+	}
+	chosen, value, ok, err := chanSelect(rt, cases, isDefault != 0)
+	if err != nil || chosen < 0 {
+		return 0, err
+	}
+	if cases[chosen].Dir == reflect.SelectRecv {
+		if !ok {
+			return 0, newError(ErrChanClosed)
+		}
+		rt.Received = value
+	}
+	return int64(chosen + 1), nil
+}
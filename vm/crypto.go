@@ -120,8 +120,11 @@ func AESDecrypt(passphrase, data []byte) ([]byte, error) {
 }
 
 // Random(int size) buf
-func RandomBuf(size int64) (buf *core.Buffer, err error) {
+func RandomBuf(rt *Runtime, size int64) (buf *core.Buffer, err error) {
 	var salt []byte
+	if err = checkMemory(rt, size); err != nil {
+		return
+	}
 	if salt, err = RandomBytes(int(size)); err != nil {
 		return
 	}
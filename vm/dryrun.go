@@ -0,0 +1,183 @@
+// Copyright 2019 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package vm
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gentee/gentee/core"
+)
+
+// Action is the side effect which has been skipped in DryRun mode
+type Action struct {
+	Kind string        // the name of the function
+	Args []interface{} // the values of the parameters
+	Path string        // the source file
+	Line int64
+	Pos  int64
+}
+
+// ActionFunc gets the skipped actions in DryRun mode
+type ActionFunc func(Action)
+
+// dryRunFile returns the empty file in memory which is used instead of the created file
+func dryRunFile() core.FileHandle {
+	mfs, _ := NewMemFS(``)
+	handle, _ := mfs.OpenFile(`/dry-run`, os.O_CREATE|os.O_RDWR, 0644)
+	return handle
+}
+
+// dryOpenFile returns the file in memory for OpenFile which creates or truncates the file
+func dryOpenFile(args []interface{}) interface{} {
+	file := core.NewFile()
+	file.Name = args[0].(string)
+	file.Handle = dryRunFile()
+	return file
+}
+
+// dryCreateZip returns the zip archive in memory
+func dryCreateZip(args []interface{}) interface{} {
+	return newZipFile(args[0].(string), dryRunFile())
+}
+
+// dryCreateTarGz returns the tar.gz archive in memory
+func dryCreateTarGz(args []interface{}) interface{} {
+	gzfile, _ := newGzFile(args[0].(string), dryRunFile())
+	return gzfile
+}
+
+// dryFirstArg returns the first parameter which is changed and returned by the function
+func dryFirstArg(args []interface{}) interface{} {
+	return args[0]
+}
+
+// actionArg returns the short text representation of the parameter
+func actionArg(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		if runes := []rune(v); len(runes) > 64 {
+			v = string(runes[:64]) + `...`
+		}
+		return strconv.Quote(v)
+	case *core.Buffer:
+		return fmt.Sprintf(`buf[%d]`, len(v.Data))
+	case *core.File:
+		return strconv.Quote(v.Name)
+	case Pack:
+		return strconv.Quote(v.FileName())
+	}
+	return fmt.Sprint(value)
+}
+
+func (action Action) String() string {
+	args := make([]string, len(action.Args))
+	for i, arg := range action.Args {
+		args[i] = actionArg(arg)
+	}
+	return ErrFormat(action.Path, action.Line, action.Pos,
+		fmt.Sprintf(`%s(%s)`, action.Kind, strings.Join(args, `, `)))
+}
+
+// embedArgs returns the parameters of the embedded function from the stacks and the top of
+// the stacks without these parameters
+func embedArgs(rt *Runtime, top Call, params []uint16) ([]interface{}, Call) {
+	args := make([]interface{}, len(params))
+	for i := len(params) - 1; i >= 0; i-- {
+		switch params[i] & 0xf {
+		case core.STACKFLOAT:
+			top.Float--
+			args[i] = rt.SFloat[top.Float]
+		case core.STACKSTR:
+			top.Str--
+			args[i] = rt.SStr[top.Str]
+		case core.STACKANY:
+			top.Any--
+			args[i] = rt.SAny[top.Any]
+		default:
+			top.Int--
+			args[i] = rt.SInt[top.Int]
+		}
+	}
+	return args, top
+}
+
+// embedCall returns the name and the parameters of the function which has been called by
+// the script and the level of its call in the trace
+func embedCall(embed core.Embed, args []interface{}) (string, []interface{}, int) {
+	if embed.Name == `sysRun` {
+		// Run(cmd, args...) and Start(cmd, args...) of stdlib, the caller of them is reported
+		name := `Run`
+		if args[1].(int64) != 0 {
+			name = `Start`
+		}
+		return name, append(args[:1:1], args[5].(*core.Array).Data...), 2
+	}
+	return embed.Name, args, 1
+}
+
+// callPosition returns the source position of the call at the level of the trace
+func callPosition(rt *Runtime, pos int64, level int) (path string, line int64, column int64) {
+	if trace := GetTrace(rt, pos); len(trace) >= level {
+		item := trace[len(trace)-level]
+		path, line, column = item.Path, item.Line, item.Pos
+	}
+	return
+}
+
+// dryRun takes the parameters of the embedded function from the stacks, reports the action
+// and pushes the default values of the results instead of calling the function. It returns
+// false if the function must be called.
+func dryRun(rt *Runtime, top *Call, pos int64, idEmbed uint16) bool {
+	var (
+		action Action
+		args   []interface{}
+		level  int
+		after  Call
+	)
+	embed := EmbedFuncs[idEmbed]
+	effect := embedEffects[idEmbed]
+	if effect.Get && effect.Changes == nil {
+		return false
+	}
+	args, after = embedArgs(rt, *top, embed.Params)
+	if effect.Changes != nil && !effect.Changes(args) {
+		return false
+	}
+	*top = after
+	action.Kind, action.Args, level = embedCall(embed, args)
+	action.Path, action.Line, action.Pos = callPosition(rt, pos, level)
+	rt.Owner.ThreadMutex.Lock()
+	if rt.Owner.Settings.ActionHandle != nil {
+		rt.Owner.Settings.ActionHandle(action)
+	} else {
+		fmt.Println(`[dry-run]`, action.String())
+	}
+	rt.Owner.ThreadMutex.Unlock()
+	for _, ret := range embedResults(embed) {
+		switch ret & 0xf {
+		case core.STACKNONE:
+		case core.STACKFLOAT:
+			rt.SFloat[top.Float] = 0
+			top.Float++
+		case core.STACKSTR:
+			rt.SStr[top.Str] = ``
+			top.Str++
+		case core.STACKANY:
+			if effect.DryRun != nil {
+				rt.SAny[top.Any] = effect.DryRun(args)
+			} else {
+				rt.SAny[top.Any] = newValue(rt, int(ret))
+			}
+			top.Any++
+		default:
+			rt.SInt[top.Int] = 0
+			top.Int++
+		}
+	}
+	return true
+}
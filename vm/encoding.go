@@ -87,7 +87,7 @@ func IfaceToObj(val interface{}) (*core.Obj, error) {
 		}
 		ret.Data = data
 	default:
-		return nil, fmt.Errorf(ErrorText(ErrObjType))
+		return nil, newError(ErrObjType)
 	}
 	return ret, nil
 }
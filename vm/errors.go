@@ -5,7 +5,10 @@
 package vm
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
+	"os"
 	"path/filepath"
 	"strings"
 )
@@ -95,7 +98,31 @@ const (
 	ErrPlayFunc
 	// ErrDecode is returned if decoding error occurs
 	ErrDecode
+	// ErrEnum is returned when the value is not a member of enum
+	ErrEnum
+	// ErrIface is returned when the value of interface doesn't have the method
+	ErrIface
+	// ErrChanClosed is returned when the channel has been closed
+	ErrChanClosed
+	// ErrFutureEmpty is returned when the future has not been returned by go
+	ErrFutureEmpty
+	// ErrParallel is returned when some iterations of parallel for have failed
+	ErrParallel
+	// ErrNotLocked is returned when the thread unlocks mutex or semaphore which it doesn't hold
+	ErrNotLocked
+	// ErrStackSize is returned when maximum size of the stack has been reached
+	ErrStackSize
+	// ErrMemoryLimit is returned when the script has allocated more memory than MemoryLimit
+	ErrMemoryLimit
+	// ErrPolicy is returned when the capability is denied by Settings.Policy
+	ErrPolicy
+	// ErrArchivePath is returned when the file of the archive is outside of the destination folder
+	ErrArchivePath
+	// ErrIfaceEmpty is returned when the method is called for the unassigned interface value
+	ErrIfaceEmpty
 
+	// ErrFile means golang file system error in embedded functions, it was ErrEmbedded before
+	ErrFile = 253
 	// ErrEmbedded means golang error in embedded functions
 	ErrEmbedded = 254
 	// ErrRuntime error. It means bug
@@ -116,6 +143,14 @@ type RuntimeError struct {
 	ID      int
 	Message string
 	Trace   []TraceInfo
+	Payload interface{}   // obj or struct value attached to the error
+	Cause   *RuntimeError // the wrapped error
+}
+
+// ErrRange is a range of error identifiers
+type ErrRange struct {
+	Min int
+	Max int
 }
 
 func (re *RuntimeError) Error() string {
@@ -131,6 +166,14 @@ func (re *RuntimeError) Is(err error) bool {
 	return ok
 }
 
+// Unwrap returns the cause of the error
+func (re *RuntimeError) Unwrap() error {
+	if re.Cause == nil {
+		return nil
+	}
+	return re.Cause
+}
+
 var (
 	errText = map[int]string{
 		ErrRunIndex:     `invalid name of Run`,
@@ -174,9 +217,33 @@ var (
 		ErrPlayDepth:    `[Playground] maximum depth of recursion has been reached`,
 		ErrPlayFunc:     `[Playground] calling the %s function is prohibited`,
 		ErrDecode:       `decoding error`,
+		ErrEnum:         `invalid value of enum`,
+		ErrIface:        `the value doesn't implement the method of interface`,
+		ErrChanClosed:   `channel has been closed`,
+		ErrFutureEmpty:  `future has not been started`,
+		ErrParallel:     `%d of %d iterations of parallel for have failed`,
+		ErrNotLocked:    `%s has not been locked by the thread`,
+		ErrStackSize:    `maximum size of the stack has been reached`,
+		ErrMemoryLimit:  `memory limit has been exceeded`,
+		ErrPolicy:       `permission denied: %s`,
+		ErrArchivePath:  `the file is outside of the destination folder: %s`,
+		ErrIfaceEmpty:   `the value of interface is empty`,
 
 		ErrRuntime: `you have found a runtime bug. Let us know, please`,
 	}
+
+	// ErrCategories contains the categories of errors which can be used in catch
+	ErrCategories = map[string][]ErrRange{
+		`ErrFile`:       {{ErrFile, ErrFile}},
+		`ErrEmbedded`:   {{ErrFile, ErrEmbedded}},
+		`ErrMath`:       {{ErrDivZero, ErrDivZero}, {ErrShift, ErrShift}},
+		`ErrConvert`:    {{ErrStrToInt, ErrStrToFloat}, {ErrDecode, ErrEnum}},
+		`ErrIndex`:      {{ErrIndexOut, ErrMapIndex}, {ErrByteOut, ErrByteOut}},
+		`ErrObject`:     {{ErrObjValue, ErrObjValue}, {ErrObjNil, ErrObjType}, {ErrIface, ErrIface}, {ErrIfaceEmpty, ErrIfaceEmpty}},
+		`ErrThreads`:    {{ErrThreadIndex, ErrThreadClosed}, {ErrMainThread, ErrThread}, {ErrChanClosed, ErrNotLocked}},
+		`ErrPlayground`: {{ErrPlayCycle, ErrPlayFunc}},
+		`ErrPolicy`:     {{ErrPolicy, ErrPolicy}},
+	}
 )
 
 // ErrFormat is a function for formating error message
@@ -194,6 +261,18 @@ func ErrorText(id int) string {
 	return errText[id]
 }
 
+// newError returns the error with the specified id for embedded functions
+func newError(id int, pars ...interface{}) error {
+	text := ErrorText(id)
+	if len(pars) > 0 {
+		text = fmt.Sprintf(text, pars...)
+	}
+	return &RuntimeError{
+		ID:      id,
+		Message: text,
+	}
+}
+
 // GetTrace returns information about called functions
 func GetTrace(rt *Runtime, pos int64) []TraceInfo {
 	var (
@@ -281,6 +360,8 @@ func runtimeError(rt *Runtime, pos int64, err interface{}, labels ...interface{}
 	var (
 		errText string
 		idError int
+		payload interface{}
+		cause   *RuntimeError
 	)
 	switch v := err.(type) {
 	case int:
@@ -289,9 +370,18 @@ func runtimeError(rt *Runtime, pos int64, err interface{}, labels ...interface{}
 	case *RuntimeError:
 		errText = v.Message
 		idError = v.ID
+		payload = v.Payload
+		cause = v.Cause
 	case error:
 		errText = v.Error()
 		idError = ErrEmbedded
+		var (
+			errPath *fs.PathError
+			errLink *os.LinkError
+		)
+		if errors.As(v, &errPath) || errors.As(v, &errLink) {
+			idError = ErrFile
+		}
 	}
 	for _, item := range labels {
 		errText += fmt.Sprintf(` [%v]`, item)
@@ -300,5 +390,7 @@ func runtimeError(rt *Runtime, pos int64, err interface{}, labels ...interface{}
 		ID:      idError,
 		Message: errText,
 		Trace:   GetTrace(rt, pos),
+		Payload: payload,
+		Cause:   cause,
 	}
 }
@@ -21,6 +21,80 @@ func errorºIntStr(code int64, text string, pars ...interface{}) error {
 	}
 }
 
+// errorºErrIntStr throws an error which wraps the cause error
+func errorºErrIntStr(cause *RuntimeError, code int64, text string) error {
+	return &RuntimeError{
+		ID:      int(code),
+		Message: text,
+		Cause:   cause,
+	}
+}
+
+// ErrorPayloadºObj throws an error with obj payload
+func ErrorPayloadºObj(code int64, text string, payload *core.Obj) error {
+	return &RuntimeError{
+		ID:      int(code),
+		Message: text,
+		Payload: payload,
+	}
+}
+
+// ErrorPayloadºStruct throws an error with struct payload
+func ErrorPayloadºStruct(code int64, text string, payload *Struct) error {
+	return &RuntimeError{
+		ID:      int(code),
+		Message: text,
+		Payload: payload,
+	}
+}
+
+// structObj converts struct value to object
+func structObj(pstruct *Struct) (*core.Obj, error) {
+	data := core.NewMap()
+	for i, key := range pstruct.Type.Keys {
+		var (
+			iobj *core.Obj
+			err  error
+		)
+		switch v := pstruct.Values[i].(type) {
+		case *Struct:
+			iobj, err = structObj(v)
+		default:
+			if pstruct.Type.Fields[i] == core.TYPEBOOL {
+				iobj = objºBool(v.(int64))
+			} else {
+				iobj, err = objType(v)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		data.SetIndex(key, iobj)
+	}
+	obj := core.NewObj()
+	obj.Data = data
+	return obj, nil
+}
+
+// ErrPayload returns the payload of the error as obj
+func ErrPayload(err *RuntimeError) (*core.Obj, error) {
+	switch v := err.Payload.(type) {
+	case *core.Obj:
+		return v, nil
+	case *Struct:
+		return structObj(v)
+	}
+	return core.NewObj(), nil
+}
+
+// ErrCause returns the wrapped error. It returns an empty error if there is not the cause
+func ErrCause(err *RuntimeError) (*RuntimeError, error) {
+	if err.Cause == nil {
+		return &RuntimeError{}, nil
+	}
+	return err.Cause, nil
+}
+
 // ErrID returns the id of the error
 func ErrID(err *RuntimeError) int64 {
 	return int64(err.ID)
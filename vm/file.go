@@ -8,9 +8,7 @@ import (
 	"crypto/md5"
 	"crypto/sha256"
 	"encoding/hex"
-	"fmt"
 	"io"
-	"io/ioutil"
 	"os"
 	"path/filepath"
 
@@ -34,13 +32,7 @@ func appendFile(rt *Runtime, filename string, data []byte) error {
 			return err
 		}
 	}
-	f, err := os.OpenFile(filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-	_, err = f.Write(data)
-	return err
+	return writeFile(rt.Owner.Settings.FS, filename, data, os.O_APPEND, 0644)
 }
 
 // AppendFileºStrBuf appends a buffer to a file
@@ -60,7 +52,7 @@ func ChDirºStr(rt *Runtime, dirname string) error {
 			return err
 		}
 	}
-	return os.Chdir(dirname)
+	return rt.Owner.Settings.FS.Chdir(dirname)
 }
 
 // ChModeºStr change the file mode.
@@ -70,12 +62,12 @@ func ChModeºStr(rt *Runtime, name string, mode int64) error {
 			return err
 		}
 	}
-	return os.Chmod(name, os.FileMode(mode))
+	return rt.Owner.Settings.FS.Chmod(name, os.FileMode(mode))
 }
 
 func CloseFile(file *core.File) error {
 	if file == nil || file.Handle == nil {
-		return fmt.Errorf(ErrorText(ErrInvalidParam))
+		return newError(ErrInvalidParam)
 	}
 	if err := file.Handle.Close(); err != nil {
 		return err
@@ -92,7 +84,8 @@ func CopyFileºStrStr(rt *Runtime, src, dest string) (int64, error) {
 		}
 	}
 
-	srcFile, err := os.Open(src)
+	fsys := rt.Owner.Settings.FS
+	srcFile, err := fsys.OpenFile(src, os.O_RDONLY, 0)
 	if err != nil {
 		return 0, err
 	}
@@ -104,13 +97,14 @@ func CopyFileºStrStr(rt *Runtime, src, dest string) (int64, error) {
 		}
 	}
 
-	destFile, err := os.Create(dest)
+	destFile, err := fsys.OpenFile(dest, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0666)
 	if err != nil {
 		return 0, err
 	}
 	defer func() {
 		destFile.Close()
-		os.Chtimes(dest, finfo.ModTime(), finfo.ModTime())
+		fsys.Chmod(dest, finfo.Mode())
+		fsys.Chtimes(dest, finfo.ModTime(), finfo.ModTime())
 	}()
 	var (
 		prog   *Progress
@@ -127,7 +121,6 @@ func CopyFileºStrStr(rt *Runtime, src, dest string) (int64, error) {
 	if rt.Owner.Settings.ProgressHandle != nil {
 		prog.Complete()
 	}
-	destFile.Chmod(finfo.Mode())
 	return ret, err
 }
 
@@ -138,7 +131,7 @@ func CreateDirºStr(rt *Runtime, dirname string) error {
 			return err
 		}
 	}
-	return os.MkdirAll(dirname, os.ModePerm)
+	return rt.Owner.Settings.FS.MkdirAll(dirname, os.ModePerm)
 }
 
 // CreateFileºStrBool creates an empty file
@@ -152,7 +145,7 @@ func CreateFileºStrBool(rt *Runtime, filename string, always int64) error {
 			return err
 		}
 	}
-	f, err := os.OpenFile(filename, os.O_CREATE|os.O_WRONLY, 0644)
+	f, err := rt.Owner.Settings.FS.OpenFile(filename, os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
 		return err
 	}
@@ -172,7 +165,7 @@ func ExistFile(rt *Runtime, filename string) (int64, error) {
 			return 0, err
 		}
 	}
-	if _, err := os.Stat(filename); err != nil {
+	if _, err := rt.Owner.Settings.FS.Stat(filename); err != nil {
 		if os.IsNotExist(err) {
 			return 0, nil
 		}
@@ -199,7 +192,7 @@ func fromFileInfo(fileInfo os.FileInfo, finfo *Struct) *Struct {
 func FileInfoºFile(rt *Runtime, file *core.File) (*Struct, error) {
 	finfo := NewStruct(rt, &rt.Owner.Exec.Structs[FINFOSTRUCT])
 	if file == nil || file.Handle == nil {
-		return finfo, fmt.Errorf(ErrorText(ErrInvalidParam))
+		return finfo, newError(ErrInvalidParam)
 	}
 	fileInfo, err := file.Handle.Stat()
 	if err != nil {
@@ -216,12 +209,7 @@ func FileInfoºStr(rt *Runtime, name string) (*Struct, error) {
 		}
 	}
 	finfo := NewStruct(rt, &rt.Owner.Exec.Structs[FINFOSTRUCT])
-	handle, err := os.Open(name)
-	if err != nil {
-		return finfo, err
-	}
-	defer handle.Close()
-	fileInfo, err := handle.Stat()
+	fileInfo, err := rt.Owner.Settings.FS.Stat(name)
 	if err != nil {
 		return finfo, err
 	}
@@ -235,7 +223,7 @@ func FileModeºStr(rt *Runtime, name string) (int64, error) {
 			return 0, err
 		}
 	}
-	fStat, err := os.Stat(name)
+	fStat, err := rt.Owner.Settings.FS.Stat(name)
 	if err != nil {
 		return 0, err
 	}
@@ -243,8 +231,8 @@ func FileModeºStr(rt *Runtime, name string) (int64, error) {
 }
 
 // GetCurDir returns the current directory
-func GetCurDir() (string, error) {
-	return os.Getwd()
+func GetCurDir(rt *Runtime) (string, error) {
+	return rt.Owner.Settings.FS.Getwd()
 }
 
 // IsEmptyDir returns true if the specified folder is empty
@@ -254,15 +242,12 @@ func IsEmptyDir(rt *Runtime, path string) (ret int64, err error) {
 			return
 		}
 	}
-	var f *os.File
-	if f, err = os.Open(path); err != nil {
+	var list []os.FileInfo
+	if list, err = rt.Owner.Settings.FS.ReadDir(path); err != nil {
 		return
 	}
-	defer f.Close()
-	_, err = f.Readdir(1)
-
-	if err == io.EOF {
-		return 1, nil
+	if len(list) == 0 {
+		ret = 1
 	}
 	return
 }
@@ -274,7 +259,7 @@ func Md5FileºStr(rt *Runtime, filename string) (string, error) {
 			return ``, err
 		}
 	}
-	file, err := os.Open(filename)
+	file, err := rt.Owner.Settings.FS.OpenFile(filename, os.O_RDONLY, 0)
 	if err != nil {
 		return ``, err
 	}
@@ -301,7 +286,7 @@ func ObjºFinfo(finfo *Struct) *core.Obj {
 }
 
 func OpenFileºStr(rt *Runtime, fname string, flags int64) (ret *core.File, err error) {
-	if fname, err = filepath.Abs(fname); err != nil {
+	if fname, err = rt.Owner.Settings.FS.Abs(fname); err != nil {
 		return
 	}
 	if rt.Owner.Settings.IsPlayground {
@@ -316,7 +301,7 @@ func OpenFileºStr(rt *Runtime, fname string, flags int64) (ret *core.File, err
 	ret = core.NewFile()
 	var (
 		iFlags int
-		handle *os.File
+		handle core.FileHandle
 	)
 	if (flags & FileCreate) != 0 {
 		iFlags |= os.O_CREATE
@@ -326,7 +311,7 @@ func OpenFileºStr(rt *Runtime, fname string, flags int64) (ret *core.File, err
 	} else {
 		iFlags |= os.O_RDWR
 	}
-	if handle, err = os.OpenFile(fname, iFlags, 0644); err != nil {
+	if handle, err = rt.Owner.Settings.FS.OpenFile(fname, iFlags, 0644); err != nil {
 		return
 	}
 	if (flags & FileTrunc) != 0 {
@@ -339,9 +324,12 @@ func OpenFileºStr(rt *Runtime, fname string, flags int64) (ret *core.File, err
 	return
 }
 
-func ReadºFileInt(file *core.File, size int64) (*core.Buffer, error) {
+func ReadºFileInt(rt *Runtime, file *core.File, size int64) (*core.Buffer, error) {
 	if file == nil || file.Handle == nil {
-		return nil, fmt.Errorf(ErrorText(ErrInvalidParam))
+		return nil, newError(ErrInvalidParam)
+	}
+	if err := checkMemory(rt, size); err != nil {
+		return nil, err
 	}
 	buf := core.NewBuffer()
 	buf.Data = make([]byte, size)
@@ -361,7 +349,7 @@ func ReadDirºStr(rt *Runtime, dirname string) (*core.Array, error) {
 		}
 	}
 	ret := core.NewArray()
-	fileList, err := ioutil.ReadDir(dirname)
+	fileList, err := rt.Owner.Settings.FS.ReadDir(dirname)
 	if err != nil {
 		return ret, err
 	}
@@ -374,7 +362,7 @@ func ReadDirºStr(rt *Runtime, dirname string) (*core.Array, error) {
 
 func readDir(rt *Runtime, ret *core.Array, dirname string, flags int64, patterns *core.Array,
 	ignore *core.Array) error {
-	fileList, err := ioutil.ReadDir(dirname)
+	fileList, err := rt.Owner.Settings.FS.ReadDir(dirname)
 	if err != nil {
 		return err
 	}
@@ -463,7 +451,7 @@ func ReadDirºStrArr(rt *Runtime, dirname string, flags int64, patterns *core.Ar
 		flags &^= OnlyDirs
 	}
 	ret := core.NewArray()
-	dirname, err = filepath.Abs(dirname)
+	dirname, err = rt.Owner.Settings.FS.Abs(dirname)
 	if err != nil {
 		return ret, err
 	}
@@ -471,6 +459,18 @@ func ReadDirºStrArr(rt *Runtime, dirname string, flags int64, patterns *core.Ar
 	return ret, err
 }
 
+// checkFileMemory checks if the file can be read within the memory limit
+func checkFileMemory(rt *Runtime, filename string) error {
+	if rt.Owner.Settings.MemoryLimit <= 0 {
+		return nil
+	}
+	fi, err := rt.Owner.Settings.FS.Stat(filename)
+	if err != nil {
+		return nil
+	}
+	return checkMemory(rt, fi.Size())
+}
+
 // ReadFileºStr reads a file
 func ReadFileºStr(rt *Runtime, filename string) (string, error) {
 	if rt.Owner.Settings.IsPlayground {
@@ -478,7 +478,10 @@ func ReadFileºStr(rt *Runtime, filename string) (string, error) {
 			return ``, err
 		}
 	}
-	out, err := ioutil.ReadFile(filename)
+	if err := checkFileMemory(rt, filename); err != nil {
+		return ``, err
+	}
+	out, err := readFile(rt.Owner.Settings.FS, filename)
 	if err != nil {
 		return ``, err
 	}
@@ -492,7 +495,10 @@ func ReadFileºStrBuf(rt *Runtime, filename string, buf *core.Buffer) (*core.Buf
 			return nil, err
 		}
 	}
-	out, err := ioutil.ReadFile(filename)
+	if err := checkFileMemory(rt, filename); err != nil {
+		return buf, err
+	}
+	out, err := readFile(rt.Owner.Settings.FS, filename)
 	if err != nil {
 		return buf, err
 	}
@@ -503,7 +509,7 @@ func ReadFileºStrBuf(rt *Runtime, filename string, buf *core.Buffer) (*core.Buf
 // ReadFileºStrIntInt reads a part of the file to the buffer
 func ReadFileºStrIntInt(rt *Runtime, filename string, off int64, length int64) (buf *core.Buffer, err error) {
 	var (
-		fhandle *os.File
+		fhandle core.FileHandle
 		n       int
 	)
 	if rt.Owner.Settings.IsPlayground {
@@ -512,7 +518,7 @@ func ReadFileºStrIntInt(rt *Runtime, filename string, off int64, length int64)
 		}
 	}
 	buf = core.NewBuffer()
-	if fhandle, err = os.Open(filename); err != nil {
+	if fhandle, err = rt.Owner.Settings.FS.OpenFile(filename, os.O_RDONLY, 0); err != nil {
 		return
 	}
 	defer fhandle.Close()
@@ -529,6 +535,9 @@ func ReadFileºStrIntInt(rt *Runtime, filename string, off int64, length int64)
 	if off+length > fsize {
 		length = fsize - off
 	}
+	if err = checkMemory(rt, length); err != nil {
+		return
+	}
 	buf.Data = make([]byte, length)
 	n, err = fhandle.ReadAt(buf.Data, off)
 	if err != nil && err == io.EOF {
@@ -545,7 +554,7 @@ func RemoveºStr(rt *Runtime, filename string) error {
 			return err
 		}
 	}
-	return os.Remove(filename)
+	return rt.Owner.Settings.FS.Remove(filename)
 }
 
 // RemoveDirºStr removes a directory
@@ -555,7 +564,7 @@ func RemoveDirºStr(rt *Runtime, dirname string) error {
 			return err
 		}
 	}
-	return os.RemoveAll(dirname)
+	return rt.Owner.Settings.FS.RemoveAll(dirname)
 }
 
 // RenameºStrStr renames a file or a directory
@@ -572,7 +581,7 @@ func RenameºStrStr(rt *Runtime, oldname, newname string) error {
 			return err
 		}
 	}
-	return os.Rename(oldname, newname)
+	return rt.Owner.Settings.FS.Rename(oldname, newname)
 }
 
 // SetFileTimeºStrTime changes the modification time of the named file
@@ -583,13 +592,13 @@ func SetFileTimeºStrTime(rt *Runtime, name string, ftime *Struct) error {
 		}
 	}
 	mtime := toTime(ftime)
-	return os.Chtimes(name, mtime, mtime)
+	return rt.Owner.Settings.FS.Chtimes(name, mtime, mtime)
 }
 
 // SetPosºFileIntInt sets the postion in the file
 func SetPosºFileIntInt(file *core.File, off int64, whence int64) (int64, error) {
 	if file == nil || file.Handle == nil || whence < 0 || whence > 2 {
-		return 0, fmt.Errorf(ErrorText(ErrInvalidParam))
+		return 0, newError(ErrInvalidParam)
 	}
 	return file.Handle.Seek(off, int(whence))
 }
@@ -601,7 +610,7 @@ func Sha256FileºStr(rt *Runtime, filename string) (string, error) {
 			return ``, err
 		}
 	}
-	file, err := os.Open(filename)
+	file, err := rt.Owner.Settings.FS.OpenFile(filename, os.O_RDONLY, 0)
 	if err != nil {
 		return ``, err
 	}
@@ -614,8 +623,8 @@ func Sha256FileºStr(rt *Runtime, filename string) (string, error) {
 }
 
 // TempDir returns the temporary directory
-func TempDir() string {
-	return os.TempDir()
+func TempDir(rt *Runtime) string {
+	return rt.Owner.Settings.FS.TempDir()
 }
 
 // TempDirºStrStr creates a directory in the temporary directory
@@ -623,19 +632,19 @@ func TempDirºStrStr(rt *Runtime, dir, prefix string) (string, error) {
 	if rt.Owner.Settings.IsPlayground {
 		tmp := dir
 		if len(tmp) == 0 {
-			tmp = TempDir()
+			tmp = TempDir(rt)
 		}
 		if err := CheckPlaygroundLimits(rt.Owner, filepath.Join(tmp, prefix+`_`), NoLimit); err != nil {
 			return ``, err
 		}
 	}
-	return ioutil.TempDir(dir, prefix)
+	return rt.Owner.Settings.FS.MkdirTemp(dir, prefix)
 }
 
 // WriteFileºFileBuf writes a buffer to a file
 func WriteFileºFileBuf(rt *Runtime, file *core.File, buf *core.Buffer) (*core.File, error) {
 	if file == nil || file.Handle == nil {
-		return file, fmt.Errorf(ErrorText(ErrInvalidParam))
+		return file, newError(ErrInvalidParam)
 	}
 	if rt.Owner.Settings.IsPlayground {
 		if err := CheckPlaygroundLimits(rt.Owner, file.Name, int64(len(buf.Data))); err != nil {
@@ -653,7 +662,7 @@ func WriteFileºStrBuf(rt *Runtime, filename string, buf *core.Buffer) error {
 			return err
 		}
 	}
-	return ioutil.WriteFile(filename, buf.Data, os.ModePerm)
+	return writeFile(rt.Owner.Settings.FS, filename, buf.Data, os.O_TRUNC, os.ModePerm)
 }
 
 // WriteFileºStrStr writes a string to a file
@@ -663,5 +672,5 @@ func WriteFileºStrStr(rt *Runtime, filename, in string) error {
 			return err
 		}
 	}
-	return ioutil.WriteFile(filename, []byte(in), os.ModePerm)
+	return writeFile(rt.Owner.Settings.FS, filename, []byte(in), os.O_TRUNC, os.ModePerm)
 }
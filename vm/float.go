@@ -5,7 +5,6 @@
 package vm
 
 import (
-	"fmt"
 	"math"
 	"strconv"
 )
@@ -29,7 +28,7 @@ func AssignAddºFloatFloat(ptr *float64, value float64) (float64, error) {
 // AssignDivºFloatFloat does float /= float
 func AssignDivºFloatFloat(ptr *float64, value float64) (float64, error) {
 	if value == 0 {
-		return 0, fmt.Errorf(ErrorText(ErrDivZero))
+		return 0, newError(ErrDivZero)
 	}
 	*ptr /= value
 	return *ptr, nil
@@ -63,7 +62,7 @@ func CeilºFloat(val float64) int64 {
 // DivºFloatInt divides one float by int
 func DivºFloatInt(left float64, right int64) (float64, error) {
 	if right == 0 {
-		return 0, fmt.Errorf(ErrorText(ErrDivZero))
+		return 0, newError(ErrDivZero)
 	}
 	return left / float64(right), nil
 }
@@ -71,7 +70,7 @@ func DivºFloatInt(left float64, right int64) (float64, error) {
 // DivºIntFloat divides one int by float
 func DivºIntFloat(left int64, right float64) (float64, error) {
 	if right == 0 {
-		return 0, fmt.Errorf(ErrorText(ErrDivZero))
+		return 0, newError(ErrDivZero)
 	}
 	return float64(left) / right, nil
 }
@@ -0,0 +1,135 @@
+// Copyright 2019 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package vm
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gentee/gentee/core"
+)
+
+// FileSystem is the file system which is used by the file functions of the script.
+// By default, it is OSFS. The relative paths are resolved from the current directory of
+// the file system.
+type FileSystem interface {
+	Abs(name string) (string, error)
+	Getwd() (string, error)
+	Chdir(dir string) error
+	TempDir() string
+	MkdirTemp(dir, prefix string) (string, error)
+	OpenFile(name string, flag int, perm os.FileMode) (core.FileHandle, error)
+	Stat(name string) (os.FileInfo, error)
+	ReadDir(name string) ([]os.FileInfo, error) // sorted by names
+	MkdirAll(name string, perm os.FileMode) error
+	Remove(name string) error
+	RemoveAll(name string) error
+	Rename(oldname, newname string) error
+	Chmod(name string, mode os.FileMode) error
+	Chtimes(name string, atime, mtime time.Time) error
+}
+
+// OSFS is the file system of the operating system
+type OSFS struct{}
+
+// Abs returns the absolute path
+func (OSFS) Abs(name string) (string, error) {
+	return filepath.Abs(name)
+}
+
+// Getwd returns the current directory
+func (OSFS) Getwd() (string, error) {
+	return os.Getwd()
+}
+
+// Chdir changes the current directory of the process
+func (OSFS) Chdir(dir string) error {
+	return os.Chdir(dir)
+}
+
+// TempDir returns the temporary directory
+func (OSFS) TempDir() string {
+	return os.TempDir()
+}
+
+// MkdirTemp creates a new directory with the unique name
+func (OSFS) MkdirTemp(dir, prefix string) (string, error) {
+	return ioutil.TempDir(dir, prefix)
+}
+
+// OpenFile opens the file
+func (OSFS) OpenFile(name string, flag int, perm os.FileMode) (core.FileHandle, error) {
+	file, err := os.OpenFile(name, flag, perm)
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// Stat returns the information about the file
+func (OSFS) Stat(name string) (os.FileInfo, error) {
+	return os.Stat(name)
+}
+
+// ReadDir returns the list of the directory entries
+func (OSFS) ReadDir(name string) ([]os.FileInfo, error) {
+	return ioutil.ReadDir(name)
+}
+
+// MkdirAll creates the directory with all parent directories
+func (OSFS) MkdirAll(name string, perm os.FileMode) error {
+	return os.MkdirAll(name, perm)
+}
+
+// Remove removes the file or the empty directory
+func (OSFS) Remove(name string) error {
+	return os.Remove(name)
+}
+
+// RemoveAll removes the file or the directory with all its contents
+func (OSFS) RemoveAll(name string) error {
+	return os.RemoveAll(name)
+}
+
+// Rename renames the file or the directory
+func (OSFS) Rename(oldname, newname string) error {
+	return os.Rename(oldname, newname)
+}
+
+// Chmod changes the mode of the file
+func (OSFS) Chmod(name string, mode os.FileMode) error {
+	return os.Chmod(name, mode)
+}
+
+// Chtimes changes the access and modification times of the file
+func (OSFS) Chtimes(name string, atime, mtime time.Time) error {
+	return os.Chtimes(name, atime, mtime)
+}
+
+// readFile reads the whole file of the file system
+func readFile(fsys FileSystem, name string) ([]byte, error) {
+	file, err := fsys.OpenFile(name, os.O_RDONLY, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ioutil.ReadAll(file)
+}
+
+// writeFile writes data to the file of the file system. flag can contain os.O_APPEND or
+// os.O_TRUNC.
+func writeFile(fsys FileSystem, name string, data []byte, flag int, perm os.FileMode) error {
+	file, err := fsys.OpenFile(name, flag|os.O_CREATE|os.O_WRONLY, perm)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(data)
+	if errClose := file.Close(); err == nil {
+		err = errClose
+	}
+	return err
+}
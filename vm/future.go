@@ -0,0 +1,93 @@
+// Copyright 2019 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package vm
+
+import (
+	"reflect"
+
+	"github.com/gentee/gentee/core"
+)
+
+// futureResult converts the result of the thread to the value of the stack
+func futureResult(result interface{}) interface{} {
+	switch v := result.(type) {
+	case bool:
+		if v {
+			return int64(1)
+		}
+		return int64(0)
+	case rune:
+		return int64(v)
+	}
+	return result
+}
+
+// futureWait waits for the finish of the thread and returns its result or error
+func futureWait(rt *Runtime, future *core.Future) (interface{}, error) {
+	if future.Done == nil {
+		return nil, newError(ErrFutureEmpty)
+	}
+	if _, _, _, err := chanSelect(rt, []reflect.SelectCase{{Dir: reflect.SelectRecv,
+		Chan: reflect.ValueOf(future.Done)}}, false); err != nil {
+		return nil, err
+	}
+	if future.Err != nil {
+		return nil, future.Err
+	}
+	return future.Result, receivedMemory(rt, future.Result)
+}
+
+// WaitºFutureInt returns int result of the thread
+func WaitºFutureInt(rt *Runtime, future *core.Future) (int64, error) {
+	value, err := futureWait(rt, future)
+	if err != nil {
+		return 0, err
+	}
+	return value.(int64), nil
+}
+
+// WaitºFutureFloat returns float result of the thread
+func WaitºFutureFloat(rt *Runtime, future *core.Future) (float64, error) {
+	value, err := futureWait(rt, future)
+	if err != nil {
+		return 0, err
+	}
+	return value.(float64), nil
+}
+
+// WaitºFutureStr returns str result of the thread
+func WaitºFutureStr(rt *Runtime, future *core.Future) (string, error) {
+	value, err := futureWait(rt, future)
+	if err != nil {
+		return ``, err
+	}
+	return value.(string), nil
+}
+
+// WaitºFutureAny returns the copy of the result of the thread
+func WaitºFutureAny(rt *Runtime, future *core.Future) (interface{}, error) {
+	value, err := futureWait(rt, future)
+	if err != nil {
+		return nil, err
+	}
+	var copied interface{}
+	CopyVar(rt, &copied, value)
+	return copied, nil
+}
+
+// WaitAllºFuture waits for all futures of the array and returns the array of their results.
+// It returns the first error of the threads.
+func WaitAllºFuture(rt *Runtime, futures *core.Array) (*core.Array, error) {
+	ret := core.NewArray()
+	ret.Data = make([]interface{}, len(futures.Data))
+	for i, item := range futures.Data {
+		value, err := futureWait(rt, item.(*core.Future))
+		if err != nil {
+			return nil, err
+		}
+		CopyVar(rt, &ret.Data[i], value)
+	}
+	return ret, nil
+}
@@ -11,8 +11,13 @@ package main
 
 import (
 	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
 	"io/ioutil"
 	"log"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"time"
@@ -50,11 +55,17 @@ func str2type(in string) (ret string) {
 		ret = `core.TYPESET`
 	case `obj`:
 		ret = `core.TYPEOBJ`
+	case `mutex`, `rwmutex`, `semaphore`:
+		ret = `core.TYPESYNC`
 	default:
 		if in == `arr` || strings.HasPrefix(in, `arr.`) {
 			ret = `core.TYPEARR`
 		} else if in == `map` || strings.HasPrefix(in, `map.`) {
 			ret = `core.TYPEMAP`
+		} else if strings.HasPrefix(in, `chan`) {
+			ret = `core.TYPECHAN`
+		} else if strings.HasPrefix(in, `future`) {
+			ret = `core.TYPEFUTURE`
 		} else {
 			ret = `core.TYPESTRUCT`
 		}
@@ -73,6 +84,147 @@ func str2pars(in string) string {
 	return `[]uint16{` + strings.Join(types, `,`) + `}`
 }
 
+// str2stack returns the name of the typed stack for the type
+func str2stack(in string) string {
+	switch in {
+	case `int`, `thread`, `bool`, `char`, `int64`:
+		return `Int`
+	case `float`, `float64`:
+		return `Float`
+	case `str`, `string`:
+		return `Str`
+	}
+	return `Any`
+}
+
+// funcDecls parses the source files of vm package and returns the declarations of functions
+func funcDecls() map[string]*ast.FuncDecl {
+	files, err := filepath.Glob(`*.go`)
+	if err != nil {
+		log.Fatal(err)
+	}
+	decls := make(map[string]*ast.FuncDecl)
+	fset := token.NewFileSet()
+	for _, name := range files {
+		if name == `stdlib.go` || strings.HasSuffix(name, `_test.go`) {
+			continue
+		}
+		file, err := parser.ParseFile(fset, name, nil, 0)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, decl := range file.Decls {
+			if fdecl, ok := decl.(*ast.FuncDecl); ok && fdecl.Recv == nil {
+				if _, ok := decls[fdecl.Name.Name]; !ok {
+					decls[fdecl.Name.Name] = fdecl
+				}
+			}
+		}
+	}
+	return decls
+}
+
+// fieldTypes returns the list of types of parameters or results
+func fieldTypes(fields *ast.FieldList) (ret []ast.Expr) {
+	if fields == nil {
+		return
+	}
+	for _, field := range fields.List {
+		count := len(field.Names)
+		if count == 0 {
+			count = 1
+		}
+		for i := 0; i < count; i++ {
+			ret = append(ret, field.Type)
+		}
+	}
+	return
+}
+
+// stub returns the typed call of the embedded function which takes the parameters
+// directly from the stacks of the runtime
+func stub(decl *ast.FuncDecl, embed core.Embed, pars, ret string) string {
+	var params []string
+	if len(pars) > 0 {
+		params = strings.Split(pars, `,`)
+	}
+	name := decl.Name.Name
+	goPars := fieldTypes(decl.Type.Params)
+	if embed.Runtime {
+		if len(goPars) == 0 || types.ExprString(goPars[0]) != `*Runtime` {
+			log.Fatalf(`%s must have *Runtime parameter`, name)
+		}
+		goPars = goPars[1:]
+	}
+	if embed.Variadic {
+		if len(goPars) == 0 || types.ExprString(goPars[len(goPars)-1]) != `...interface{}` {
+			log.Fatalf(`%s must have variadic ...interface{} parameter`, name)
+		}
+		goPars = goPars[:len(goPars)-1]
+	}
+	if len(goPars) != len(params) {
+		log.Fatalf(`%s has wrong count of parameters`, name)
+	}
+	out := "\tfunc(rt *Runtime, top *Call, vars []interface{}) error {\n"
+	args := make([]string, len(goPars))
+	for i := len(goPars) - 1; i >= 0; i-- {
+		goType := types.ExprString(goPars[i])
+		stack := str2stack(params[i])
+		if goType != `interface{}` && stack != str2stack(goType) {
+			log.Fatalf(`%s has wrong type of %d parameter`, name, i)
+		}
+		args[i] = fmt.Sprintf(`p%d`, i)
+		out += fmt.Sprintf("\t\ttop.%s--\n\t\tp%d := rt.S%[1]s[top.%[1]s]", stack, i)
+		if stack == `Any` && goType != `interface{}` {
+			out += `.(` + goType + `)`
+		}
+		out += "\n"
+	}
+	if embed.Runtime {
+		args = append([]string{`rt`}, args...)
+	}
+	if embed.Variadic {
+		args = append(args, `vars...`)
+	}
+	call := name + `(` + strings.Join(args, `, `) + `)`
+	results := fieldTypes(decl.Type.Results)
+	isError := len(results) > 0 && types.ExprString(results[len(results)-1]) == `error`
+	if isError {
+		results = results[:len(results)-1]
+	}
+	if len(results) > 1 || (len(results) == 0 && len(ret) > 0) {
+		log.Fatalf(`%s has wrong results`, name)
+	}
+	var stack, value string
+	if len(results) == 1 && len(ret) > 0 {
+		goType := types.ExprString(results[0])
+		stack = str2stack(ret)
+		value = `ret`
+		if goType == `interface{}` && stack != `Any` {
+			value += `.(` + map[string]string{`Int`: `int64`, `Float`: `float64`,
+				`Str`: `string`}[stack] + `)`
+		} else if stack != str2stack(goType) {
+			log.Fatalf(`%s has wrong type of the result`, name)
+		}
+	}
+	switch {
+	case len(stack) > 0 && isError:
+		out += "\t\tret, err := " + call + "\n\t\tif err != nil {\n\t\t\treturn err\n\t\t}\n"
+	case len(stack) > 0:
+		out += "\t\tret := " + call + "\n"
+	case len(results) > 0 && isError:
+		out += "\t\t_, err := " + call + "\n\t\tif err != nil {\n\t\t\treturn err\n\t\t}\n"
+	case isError:
+		out += "\t\tif err := " + call + "; err != nil {\n\t\t\treturn err\n\t\t}\n"
+	default:
+		out += "\t\t" + call + "\n"
+	}
+	if len(stack) > 0 {
+		out += fmt.Sprintf("\t\trt.S%s[top.%[1]s] = %s\n\t\ttop.%[1]s++\n", stack, value)
+	}
+	return out + "\t\treturn nil\n\t},\n"
+}
+
 func main() {
 	var (
 		input    []byte
@@ -100,6 +252,12 @@ var EmbedFuncs = []core.Embed{
 		log.Fatal(err)
 	}
 	var count int64
+	decls := funcDecls()
+	stubs := `
+// embedStubs contains the typed calls of the embedded functions. nil means that
+// the function is called with reflect.
+var embedStubs = []embedStub{
+`
 	for i, v := range strings.Split(string(input), "\n") {
 		var (
 			embed     core.Embed
@@ -138,6 +296,11 @@ var EmbedFuncs = []core.Embed{
 		embed.Variadic = strings.Contains(vals[5], `v`)
 		embed.Runtime = strings.Contains(vals[5], `r`)
 		embed.CanError = strings.Contains(vals[5], `e`)
+		if decl := decls[fnc]; decl != nil {
+			stubs += stub(decl, embed, vals[2], vals[3])
+		} else {
+			stubs += "\tnil,\n"
+		}
 		out += fmt.Sprintf(`	{Name: "%s", Pars: "%s", Ret: "%s", Code: %s, 
 		Func: %s, Return: %s, 
 		Params: %s, 
@@ -147,7 +310,7 @@ var EmbedFuncs = []core.Embed{
 	}
 	out += fmt.Sprintf(`}
 const StdLibCount = %d
-`, count)
+`, count) + stubs + "}\n"
 	if err = ioutil.WriteFile(`stdlib.go`, []byte(out), 0644); err != nil {
 		log.Fatal(err)
 	}
@@ -0,0 +1,90 @@
+// Copyright 2019 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package vm
+
+import "github.com/gentee/gentee/core"
+
+// globalVar gives access to the global variable as to the indexed object
+type globalVar struct {
+	Value interface{} // *int64, *float64, *string or *interface{}
+}
+
+// newGlobal returns the pointer to the default value of the global variable
+func newGlobal(rt *Runtime, vtype int) interface{} {
+	switch value := newValue(rt, vtype).(type) {
+	case int64:
+		return &value
+	case float64:
+		return &value
+	case string:
+		return &value
+	default:
+		return &value
+	}
+}
+
+// newCell returns the heap cell with the value of the variable which is captured by function
+// literals. types are the types of the variables of the block, base is the start of the block.
+func newCell(rt *Runtime, types []core.Bcode, index int, base Call) interface{} {
+	var pos int32
+	kind := types[index] & 0xf
+	for _, itype := range types[:index] {
+		if itype&0xf == kind {
+			pos++
+		}
+	}
+	switch kind {
+	case core.STACKINT:
+		value := rt.SInt[base.Int+pos]
+		return &value
+	case core.STACKFLOAT:
+		value := rt.SFloat[base.Float+pos]
+		return &value
+	case core.STACKSTR:
+		value := rt.SStr[base.Str+pos]
+		return &value
+	}
+	return nil
+}
+
+// getGlobal returns the value of the global variable
+func getGlobal(global interface{}) interface{} {
+	switch v := global.(type) {
+	case *int64:
+		return *v
+	case *float64:
+		return *v
+	case *string:
+		return *v
+	case *interface{}:
+		return *v
+	}
+	return nil
+}
+
+// Len is part of Indexer interface.
+func (pglobal *globalVar) Len() int {
+	return 1
+}
+
+// GetIndex is part of Indexer interface.
+func (pglobal *globalVar) GetIndex(index interface{}) (interface{}, bool) {
+	return getGlobal(pglobal.Value), true
+}
+
+// SetIndex is part of Indexer interface.
+func (pglobal *globalVar) SetIndex(index, value interface{}) int {
+	switch v := pglobal.Value.(type) {
+	case *int64:
+		*v = value.(int64)
+	case *float64:
+		*v = value.(float64)
+	case *string:
+		*v = value.(string)
+	case *interface{}:
+		*v = value
+	}
+	return 0
+}
@@ -5,7 +5,6 @@
 package vm
 
 import (
-	"fmt"
 	"math/rand"
 	"strconv"
 )
@@ -45,7 +44,7 @@ func AssignBitXorºIntInt(ptr *int64, value int64) (int64, error) {
 // AssignDivºIntInt does int /= int
 func AssignDivºIntInt(ptr *int64, value int64) (int64, error) {
 	if value == 0 {
-		return 0, fmt.Errorf(ErrorText(ErrDivZero))
+		return 0, newError(ErrDivZero)
 	}
 	*ptr /= value
 	return *ptr, nil
@@ -54,7 +53,7 @@ func AssignDivºIntInt(ptr *int64, value int64) (int64, error) {
 // AssignModºIntInt equals int %= int
 func AssignModºIntInt(ptr *int64, value int64) (int64, error) {
 	if value == 0 {
-		return 0, fmt.Errorf(ErrorText(ErrDivZero))
+		return 0, newError(ErrDivZero)
 	}
 	*ptr %= value
 	return *ptr, nil
@@ -63,7 +62,7 @@ func AssignModºIntInt(ptr *int64, value int64) (int64, error) {
 // AssignLShiftºIntInt does int <<= int
 func AssignLShiftºIntInt(ptr *int64, value int64) (int64, error) {
 	if value < 0 {
-		return 0, fmt.Errorf(ErrorText(ErrShift))
+		return 0, newError(ErrShift)
 	}
 	*ptr <<= uint64(value)
 	return *ptr, nil
@@ -78,7 +77,7 @@ func AssignMulºIntInt(ptr *int64, value int64) (int64, error) {
 // AssignRShiftºIntInt does int >>= int
 func AssignRShiftºIntInt(ptr *int64, value int64) (int64, error) {
 	if value < 0 {
-		return 0, fmt.Errorf(ErrorText(ErrShift))
+		return 0, newError(ErrShift)
 	}
 	*ptr >>= uint64(value)
 	return *ptr, nil
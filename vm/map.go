@@ -5,8 +5,6 @@
 package vm
 
 import (
-	"fmt"
-
 	"github.com/gentee/gentee/core"
 )
 
@@ -42,7 +40,7 @@ func IsKeyºMapStr(pmap *core.Map, key string) int64 {
 // KeyºMapInt returns the key by the index
 func KeyºMapInt(pmap *core.Map, index int64) (string, error) {
 	if index >= int64(len(pmap.Keys)) {
-		return ``, fmt.Errorf(ErrorText(ErrIndexOut))
+		return ``, newError(ErrIndexOut)
 	}
 	return pmap.Keys[index], nil
 }
@@ -0,0 +1,609 @@
+// Copyright 2019 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package vm
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gentee/gentee/core"
+)
+
+var (
+	errNotDir   = errors.New(`not a directory`)
+	errIsDir    = errors.New(`is a directory`)
+	errNotEmpty = errors.New(`directory not empty`)
+	errReadOnly = errors.New(`file has been opened for reading`)
+)
+
+// MemFS is the file system in memory. The paths of MemFS are slash-separated and the root is /.
+// If the base directory is specified then MemFS is the overlay on this real directory. The files
+// of the base directory are visible until they are changed or deleted, and all changes are kept
+// in memory.
+type MemFS struct {
+	mutex   sync.RWMutex
+	base    string
+	cwd     string
+	nodes   map[string]*memNode // files and directories in memory
+	deleted map[string]bool     // the deleted paths which hide the files of the base directory
+}
+
+type memNode struct {
+	mode    os.FileMode
+	modTime time.Time
+	data    []byte
+}
+
+// memInfo implements os.FileInfo
+type memInfo struct {
+	name    string
+	size    int64
+	mode    os.FileMode
+	modTime time.Time
+}
+
+func (fi *memInfo) Name() string       { return fi.name }
+func (fi *memInfo) Size() int64        { return fi.size }
+func (fi *memInfo) Mode() os.FileMode  { return fi.mode }
+func (fi *memInfo) ModTime() time.Time { return fi.modTime }
+func (fi *memInfo) IsDir() bool        { return fi.mode.IsDir() }
+func (fi *memInfo) Sys() interface{}   { return nil }
+
+// memFile is the opened file of MemFS
+type memFile struct {
+	fs     *MemFS
+	node   *memNode
+	name   string
+	flag   int
+	pos    int64
+	closed bool
+}
+
+// NewMemFS returns a new empty file system in memory. If base is not empty then it is the
+// overlay on the base directory.
+func NewMemFS(base string) (*MemFS, error) {
+	mfs := &MemFS{
+		cwd:     `/`,
+		nodes:   map[string]*memNode{`/`: {mode: os.ModeDir | 0755, modTime: time.Now()}},
+		deleted: make(map[string]bool),
+	}
+	if len(base) > 0 {
+		var err error
+		if mfs.base, err = filepath.Abs(base); err != nil {
+			return nil, err
+		}
+		finfo, err := os.Stat(mfs.base)
+		if err != nil {
+			return nil, err
+		}
+		if !finfo.IsDir() {
+			return nil, &os.PathError{Op: `open`, Path: base, Err: errNotDir}
+		}
+	}
+	return mfs, nil
+}
+
+func newMemInfo(name string, node *memNode) *memInfo {
+	return &memInfo{name: name, size: int64(len(node.data)), mode: node.mode,
+		modTime: node.modTime}
+}
+
+// clean returns the absolute clean path
+func (mfs *MemFS) clean(name string) string {
+	name = filepath.ToSlash(name)
+	if !path.IsAbs(name) {
+		name = path.Join(mfs.cwd, name)
+	}
+	return path.Clean(name)
+}
+
+// hidden returns true if the path of the base directory has been deleted
+func (mfs *MemFS) hidden(name string) bool {
+	for {
+		if mfs.deleted[name] {
+			return true
+		}
+		if name == `/` {
+			return false
+		}
+		name = path.Dir(name)
+	}
+}
+
+// realPath returns the path of the file in the base directory
+func (mfs *MemFS) realPath(name string) string {
+	return filepath.Join(mfs.base, filepath.FromSlash(name))
+}
+
+func (mfs *MemFS) stat(name string) (os.FileInfo, error) {
+	if node, ok := mfs.nodes[name]; ok {
+		return newMemInfo(path.Base(name), node), nil
+	}
+	if len(mfs.base) > 0 && !mfs.hidden(name) {
+		if finfo, err := os.Stat(mfs.realPath(name)); err == nil {
+			return &memInfo{name: path.Base(name), size: finfo.Size(), mode: finfo.Mode(),
+				modTime: finfo.ModTime()}, nil
+		}
+	}
+	return nil, &os.PathError{Op: `stat`, Path: name, Err: os.ErrNotExist}
+}
+
+func (mfs *MemFS) readDir(name string) ([]os.FileInfo, error) {
+	finfo, err := mfs.stat(name)
+	if err != nil {
+		return nil, err
+	}
+	if !finfo.IsDir() {
+		return nil, &os.PathError{Op: `readdir`, Path: name, Err: errNotDir}
+	}
+	items := make(map[string]os.FileInfo)
+	if len(mfs.base) > 0 && !mfs.hidden(name) {
+		if list, err := ioutil.ReadDir(mfs.realPath(name)); err == nil {
+			for _, item := range list {
+				if !mfs.deleted[path.Join(name, item.Name())] {
+					items[item.Name()] = &memInfo{name: item.Name(), size: item.Size(),
+						mode: item.Mode(), modTime: item.ModTime()}
+				}
+			}
+		}
+	}
+	for key, node := range mfs.nodes {
+		if key != `/` && path.Dir(key) == name {
+			items[path.Base(key)] = newMemInfo(path.Base(key), node)
+		}
+	}
+	ret := make([]os.FileInfo, 0, len(items))
+	for _, item := range items {
+		ret = append(ret, item)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Name() < ret[j].Name() })
+	return ret, nil
+}
+
+// copyUp copies the file or the directory from the base directory to memory
+func (mfs *MemFS) copyUp(name string, recursive bool) (*memNode, error) {
+	node, ok := mfs.nodes[name]
+	if !ok {
+		finfo, err := mfs.stat(name)
+		if err != nil {
+			return nil, err
+		}
+		node = &memNode{mode: finfo.Mode(), modTime: finfo.ModTime()}
+		if !finfo.IsDir() {
+			if node.data, err = ioutil.ReadFile(mfs.realPath(name)); err != nil {
+				return nil, err
+			}
+		}
+		mfs.nodes[name] = node
+	}
+	if recursive && node.mode.IsDir() {
+		list, err := mfs.readDir(name)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range list {
+			if _, err = mfs.copyUp(path.Join(name, item.Name()), true); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return node, nil
+}
+
+// checkParent returns an error if the parent directory doesn't exist
+func (mfs *MemFS) checkParent(op, name string) error {
+	finfo, err := mfs.stat(path.Dir(name))
+	if err != nil {
+		return &os.PathError{Op: op, Path: name, Err: os.ErrNotExist}
+	}
+	if !finfo.IsDir() {
+		return &os.PathError{Op: op, Path: name, Err: errNotDir}
+	}
+	return nil
+}
+
+func (mfs *MemFS) mkdirAll(name string, perm os.FileMode) error {
+	if finfo, err := mfs.stat(name); err == nil {
+		if finfo.IsDir() {
+			return nil
+		}
+		return &os.PathError{Op: `mkdir`, Path: name, Err: errNotDir}
+	}
+	if err := mfs.mkdirAll(path.Dir(name), perm); err != nil {
+		return err
+	}
+	mfs.nodes[name] = &memNode{mode: os.ModeDir | perm.Perm(), modTime: time.Now()}
+	return nil
+}
+
+// removeNodes removes the file or the directory with all its contents from memory
+func (mfs *MemFS) removeNodes(name string) {
+	for key := range mfs.nodes {
+		if key == name || strings.HasPrefix(key, name+`/`) {
+			delete(mfs.nodes, key)
+		}
+	}
+	if len(mfs.base) > 0 {
+		mfs.deleted[name] = true
+	}
+}
+
+// Abs returns the absolute path
+func (mfs *MemFS) Abs(name string) (string, error) {
+	mfs.mutex.RLock()
+	defer mfs.mutex.RUnlock()
+	return mfs.clean(name), nil
+}
+
+// Getwd returns the current directory
+func (mfs *MemFS) Getwd() (string, error) {
+	mfs.mutex.RLock()
+	defer mfs.mutex.RUnlock()
+	return mfs.cwd, nil
+}
+
+// Chdir changes the current directory of the file system
+func (mfs *MemFS) Chdir(dir string) error {
+	mfs.mutex.Lock()
+	defer mfs.mutex.Unlock()
+	dir = mfs.clean(dir)
+	finfo, err := mfs.stat(dir)
+	if err != nil {
+		return err
+	}
+	if !finfo.IsDir() {
+		return &os.PathError{Op: `chdir`, Path: dir, Err: errNotDir}
+	}
+	mfs.cwd = dir
+	return nil
+}
+
+// TempDir returns the temporary directory
+func (mfs *MemFS) TempDir() string {
+	return `/tmp`
+}
+
+// MkdirTemp creates a new directory with the unique name
+func (mfs *MemFS) MkdirTemp(dir, prefix string) (string, error) {
+	mfs.mutex.Lock()
+	defer mfs.mutex.Unlock()
+	if len(dir) == 0 {
+		dir = mfs.TempDir()
+	}
+	dir = mfs.clean(dir)
+	if err := mfs.mkdirAll(dir, 0755); err != nil {
+		return ``, err
+	}
+	for {
+		name := path.Join(dir, prefix+strings.ToLower(core.RandName()))
+		if _, err := mfs.stat(name); err != nil {
+			mfs.nodes[name] = &memNode{mode: os.ModeDir | 0700, modTime: time.Now()}
+			return name, nil
+		}
+	}
+}
+
+// OpenFile opens the file
+func (mfs *MemFS) OpenFile(name string, flag int, perm os.FileMode) (core.FileHandle, error) {
+	mfs.mutex.Lock()
+	defer mfs.mutex.Unlock()
+	name = mfs.clean(name)
+	write := flag&(os.O_WRONLY|os.O_RDWR) != 0
+	finfo, err := mfs.stat(name)
+	if err != nil {
+		if flag&os.O_CREATE == 0 {
+			return nil, &os.PathError{Op: `open`, Path: name, Err: os.ErrNotExist}
+		}
+		if err = mfs.checkParent(`open`, name); err != nil {
+			return nil, err
+		}
+		mfs.nodes[name] = &memNode{mode: perm.Perm(), modTime: time.Now()}
+	} else if flag&os.O_CREATE != 0 && flag&os.O_EXCL != 0 {
+		return nil, &os.PathError{Op: `open`, Path: name, Err: os.ErrExist}
+	} else if finfo.IsDir() && write {
+		return nil, &os.PathError{Op: `open`, Path: name, Err: errIsDir}
+	}
+	node, ok := mfs.nodes[name]
+	if !ok {
+		if write {
+			if node, err = mfs.copyUp(name, false); err != nil {
+				return nil, err
+			}
+		} else {
+			// the file of the base directory is read without copying to memory
+			node = &memNode{mode: finfo.Mode(), modTime: finfo.ModTime()}
+			if !finfo.IsDir() {
+				if node.data, err = ioutil.ReadFile(mfs.realPath(name)); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	if write && flag&os.O_TRUNC != 0 {
+		node.data = nil
+		node.modTime = time.Now()
+	}
+	return &memFile{fs: mfs, node: node, name: name, flag: flag}, nil
+}
+
+// Stat returns the information about the file
+func (mfs *MemFS) Stat(name string) (os.FileInfo, error) {
+	mfs.mutex.RLock()
+	defer mfs.mutex.RUnlock()
+	return mfs.stat(mfs.clean(name))
+}
+
+// ReadDir returns the list of the directory entries sorted by names
+func (mfs *MemFS) ReadDir(name string) ([]os.FileInfo, error) {
+	mfs.mutex.RLock()
+	defer mfs.mutex.RUnlock()
+	return mfs.readDir(mfs.clean(name))
+}
+
+// MkdirAll creates the directory with all parent directories
+func (mfs *MemFS) MkdirAll(name string, perm os.FileMode) error {
+	mfs.mutex.Lock()
+	defer mfs.mutex.Unlock()
+	return mfs.mkdirAll(mfs.clean(name), perm)
+}
+
+// Remove removes the file or the empty directory
+func (mfs *MemFS) Remove(name string) error {
+	mfs.mutex.Lock()
+	defer mfs.mutex.Unlock()
+	name = mfs.clean(name)
+	finfo, err := mfs.stat(name)
+	if err != nil {
+		return &os.PathError{Op: `remove`, Path: name, Err: os.ErrNotExist}
+	}
+	if finfo.IsDir() {
+		list, err := mfs.readDir(name)
+		if err != nil {
+			return err
+		}
+		if len(list) > 0 || name == `/` {
+			return &os.PathError{Op: `remove`, Path: name, Err: errNotEmpty}
+		}
+	}
+	mfs.removeNodes(name)
+	return nil
+}
+
+// RemoveAll removes the file or the directory with all its contents
+func (mfs *MemFS) RemoveAll(name string) error {
+	mfs.mutex.Lock()
+	defer mfs.mutex.Unlock()
+	name = mfs.clean(name)
+	if name == `/` {
+		return &os.PathError{Op: `removeall`, Path: name, Err: os.ErrPermission}
+	}
+	mfs.removeNodes(name)
+	return nil
+}
+
+// Rename renames the file or the directory
+func (mfs *MemFS) Rename(oldname, newname string) error {
+	mfs.mutex.Lock()
+	defer mfs.mutex.Unlock()
+	oldname, newname = mfs.clean(oldname), mfs.clean(newname)
+	linkError := func(err error) error {
+		return &os.LinkError{Op: `rename`, Old: oldname, New: newname, Err: err}
+	}
+	finfo, err := mfs.stat(oldname)
+	if err != nil {
+		return linkError(os.ErrNotExist)
+	}
+	if oldname == newname {
+		return nil
+	}
+	if oldname == `/` || strings.HasPrefix(newname, oldname+`/`) {
+		return linkError(os.ErrInvalid)
+	}
+	if err = mfs.checkParent(`rename`, newname); err != nil {
+		return linkError(err)
+	}
+	if target, err := mfs.stat(newname); err == nil {
+		if target.IsDir() {
+			return linkError(os.ErrExist)
+		}
+		if finfo.IsDir() {
+			return linkError(errNotDir)
+		}
+	}
+	if _, err = mfs.copyUp(oldname, true); err != nil {
+		return linkError(err)
+	}
+	mfs.removeNodes(newname)
+	moved := make(map[string]*memNode)
+	for key, node := range mfs.nodes {
+		if key == oldname || strings.HasPrefix(key, oldname+`/`) {
+			moved[newname+key[len(oldname):]] = node
+		}
+	}
+	mfs.removeNodes(oldname)
+	for key, node := range moved {
+		mfs.nodes[key] = node
+	}
+	return nil
+}
+
+// Chmod changes the mode of the file
+func (mfs *MemFS) Chmod(name string, mode os.FileMode) error {
+	mfs.mutex.Lock()
+	defer mfs.mutex.Unlock()
+	node, err := mfs.copyUp(mfs.clean(name), false)
+	if err != nil {
+		return err
+	}
+	node.mode = node.mode&os.ModeType | mode.Perm()
+	return nil
+}
+
+// Chtimes changes the modification time of the file
+func (mfs *MemFS) Chtimes(name string, atime, mtime time.Time) error {
+	mfs.mutex.Lock()
+	defer mfs.mutex.Unlock()
+	node, err := mfs.copyUp(mfs.clean(name), false)
+	if err != nil {
+		return err
+	}
+	node.modTime = mtime
+	return nil
+}
+
+// ReadFile returns the contents of the file
+func (mfs *MemFS) ReadFile(name string) ([]byte, error) {
+	return readFile(mfs, name)
+}
+
+// WriteFile writes data to the file, the parent directories are created if it is required
+func (mfs *MemFS) WriteFile(name string, data []byte) error {
+	if err := mfs.MkdirAll(path.Dir(mfs.clean(name)), 0755); err != nil {
+		return err
+	}
+	return writeFile(mfs, name, data, os.O_TRUNC, 0644)
+}
+
+// Files returns the sorted paths of all files and directories, the paths of the directories
+// end with a slash
+func (mfs *MemFS) Files() []string {
+	var (
+		ret  []string
+		walk func(string)
+	)
+	walk = func(dir string) {
+		list, _ := mfs.ReadDir(dir)
+		for _, item := range list {
+			name := path.Join(dir, item.Name())
+			if item.IsDir() {
+				ret = append(ret, name+`/`)
+				walk(name)
+			} else {
+				ret = append(ret, name)
+			}
+		}
+	}
+	walk(`/`)
+	return ret
+}
+
+func (file *memFile) Read(b []byte) (int, error) {
+	n, err := file.ReadAt(b, file.pos)
+	file.pos += int64(n)
+	if n > 0 && err == io.EOF {
+		err = nil
+	}
+	return n, err
+}
+
+func (file *memFile) ReadAt(b []byte, off int64) (int, error) {
+	if file.closed {
+		return 0, os.ErrClosed
+	}
+	file.fs.mutex.RLock()
+	defer file.fs.mutex.RUnlock()
+	if file.node.mode.IsDir() {
+		return 0, &os.PathError{Op: `read`, Path: file.name, Err: errIsDir}
+	}
+	if off < 0 {
+		return 0, &os.PathError{Op: `read`, Path: file.name, Err: os.ErrInvalid}
+	}
+	if off >= int64(len(file.node.data)) {
+		return 0, io.EOF
+	}
+	n := copy(b, file.node.data[off:])
+	if n < len(b) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+func (file *memFile) Write(b []byte) (int, error) {
+	if file.closed {
+		return 0, os.ErrClosed
+	}
+	if file.flag&(os.O_WRONLY|os.O_RDWR) == 0 {
+		return 0, &os.PathError{Op: `write`, Path: file.name, Err: errReadOnly}
+	}
+	file.fs.mutex.Lock()
+	defer file.fs.mutex.Unlock()
+	node := file.node
+	if file.flag&os.O_APPEND != 0 {
+		file.pos = int64(len(node.data))
+	}
+	if end := file.pos + int64(len(b)); end > int64(len(node.data)) {
+		node.data = append(node.data, make([]byte, end-int64(len(node.data)))...)
+	}
+	copy(node.data[file.pos:], b)
+	file.pos += int64(len(b))
+	node.modTime = time.Now()
+	return len(b), nil
+}
+
+func (file *memFile) Seek(offset int64, whence int) (int64, error) {
+	if file.closed {
+		return 0, os.ErrClosed
+	}
+	file.fs.mutex.RLock()
+	size := int64(len(file.node.data))
+	file.fs.mutex.RUnlock()
+	switch whence {
+	case io.SeekCurrent:
+		offset += file.pos
+	case io.SeekEnd:
+		offset += size
+	}
+	if offset < 0 {
+		return 0, &os.PathError{Op: `seek`, Path: file.name, Err: os.ErrInvalid}
+	}
+	file.pos = offset
+	return offset, nil
+}
+
+func (file *memFile) Close() error {
+	if file.closed {
+		return os.ErrClosed
+	}
+	file.closed = true
+	return nil
+}
+
+func (file *memFile) Stat() (os.FileInfo, error) {
+	file.fs.mutex.RLock()
+	defer file.fs.mutex.RUnlock()
+	return newMemInfo(path.Base(file.name), file.node), nil
+}
+
+func (file *memFile) Truncate(size int64) error {
+	if file.flag&(os.O_WRONLY|os.O_RDWR) == 0 {
+		return &os.PathError{Op: `truncate`, Path: file.name, Err: errReadOnly}
+	}
+	if size < 0 {
+		return &os.PathError{Op: `truncate`, Path: file.name, Err: os.ErrInvalid}
+	}
+	file.fs.mutex.Lock()
+	defer file.fs.mutex.Unlock()
+	node := file.node
+	if size <= int64(len(node.data)) {
+		node.data = node.data[:size]
+	} else {
+		node.data = append(node.data, make([]byte, size-int64(len(node.data)))...)
+	}
+	node.modTime = time.Now()
+	return nil
+}
+
+func (file *memFile) String() string {
+	return fmt.Sprintf(`memfile %s`, file.name)
+}
@@ -0,0 +1,189 @@
+// Copyright 2019 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package vm
+
+import (
+	"math"
+	"sync/atomic"
+
+	"github.com/gentee/gentee/core"
+)
+
+// memItemSize is the approximate size of the item of array and map
+const memItemSize = 16
+
+// memSize returns the approximate size of the value. The nested objects are not taken into
+// account because their size has been counted when they were created.
+func memSize(value interface{}) int64 {
+	switch v := value.(type) {
+	case string:
+		return int64(len(v))
+	case *core.Buffer:
+		return int64(len(v.Data))
+	case *core.Array:
+		return int64(len(v.Data)) * memItemSize
+	case *core.Map:
+		return int64(len(v.Keys)) * 2 * memItemSize
+	case *core.Set:
+		return int64(len(v.Data)) * 8
+	case *core.Struct:
+		return int64(len(v.Values)) * memItemSize
+	}
+	return 0
+}
+
+// mulSize returns count * size or math.MaxInt64 if the result overflows
+func mulSize(count, size int64) int64 {
+	if size > 0 && count > math.MaxInt64/size {
+		return math.MaxInt64
+	}
+	return count * size
+}
+
+// liveSize returns the size of the value with its nested objects. The objects from seen
+// have been already counted.
+func liveSize(value interface{}, seen map[interface{}]bool) int64 {
+	switch v := value.(type) {
+	case string:
+		return int64(len(v))
+	case *core.Array, *core.Map, *core.Struct, *core.Buffer, *core.Set, *core.Obj:
+		if seen[v] {
+			return 0
+		}
+		seen[v] = true
+	default:
+		return 0
+	}
+	size := memSize(value)
+	switch v := value.(type) {
+	case *core.Array:
+		for _, item := range v.Data {
+			size += liveSize(item, seen)
+		}
+	case *core.Map:
+		for key, item := range v.Data {
+			size += int64(len(key)) + liveSize(item, seen)
+		}
+	case *core.Struct:
+		for _, item := range v.Values {
+			size += liveSize(item, seen)
+		}
+	case *core.Obj:
+		size += liveSize(v.Data, seen)
+	}
+	return size
+}
+
+// recountMemory replaces the memory counted by the thread with the size of the values on its
+// stacks and recounts the global variables. The counter only grows between recounts so
+// it is called when the limit has been reached.
+func recountMemory(rt *Runtime) {
+	if rt.top == nil {
+		return
+	}
+	vm := rt.Owner
+	seen := make(map[interface{}]bool)
+	var live, global int64
+	for _, value := range rt.SStr[:rt.top.Str] {
+		live += int64(len(value))
+	}
+	for _, value := range rt.SAny[:rt.top.Any] {
+		live += liveSize(value, seen)
+	}
+	atomic.AddInt64(&vm.Memory, live-rt.memory)
+	rt.memory = live
+	if !rt.isGlobal {
+		vm.GlobalMutex.Lock()
+		defer vm.GlobalMutex.Unlock()
+	}
+	for _, value := range vm.Globals {
+		global += liveSize(getGlobal(value), seen)
+	}
+	atomic.AddInt64(&vm.Memory, global-vm.GlobalSize)
+	vm.GlobalSize = global
+}
+
+// releaseMemory subtracts the memory counted by the finished thread. Its results which are
+// received by other threads are counted by receivedMemory.
+func releaseMemory(rt *Runtime) {
+	atomic.AddInt64(&rt.Owner.Memory, -rt.memory)
+	rt.memory = 0
+}
+
+// receivedMemory takes into account the value which has been received from another thread.
+// The memory of the finished thread is released so its values are counted again.
+func receivedMemory(rt *Runtime, value interface{}) error {
+	if rt.Owner.Settings.MemoryLimit <= 0 {
+		return nil
+	}
+	return allocMemory(rt, liveSize(value, make(map[interface{}]bool)))
+}
+
+// checkMemory returns an error if size bytes cannot be allocated within Settings.MemoryLimit.
+// It is called before the large allocations.
+func checkMemory(rt *Runtime, size int64) error {
+	limit := rt.Owner.Settings.MemoryLimit
+	if limit > 0 && size > limit-atomic.LoadInt64(&rt.Owner.Memory) {
+		recountMemory(rt)
+		if size > limit-atomic.LoadInt64(&rt.Owner.Memory) {
+			return newError(ErrMemoryLimit)
+		}
+	}
+	return nil
+}
+
+// allocMemory takes into account size bytes of the allocated memory. The released memory
+// is subtracted when the counter reaches the limit.
+func allocMemory(rt *Runtime, size int64) error {
+	limit := rt.Owner.Settings.MemoryLimit
+	if limit <= 0 || size <= 0 {
+		return nil
+	}
+	rt.memory += size
+	if atomic.AddInt64(&rt.Owner.Memory, size) > limit {
+		recountMemory(rt)
+		if atomic.LoadInt64(&rt.Owner.Memory) > limit {
+			return newError(ErrMemoryLimit)
+		}
+	}
+	return nil
+}
+
+// stackMemory returns the summary size of the values of the specified types on the top of
+// the stacks
+func stackMemory(rt *Runtime, top Call, types []uint16) (size int64) {
+	for i := len(types) - 1; i >= 0; i-- {
+		switch types[i] & 0xf {
+		case core.STACKNONE:
+		case core.STACKFLOAT:
+			top.Float--
+		case core.STACKSTR:
+			top.Str--
+			size += memSize(rt.SStr[top.Str])
+		case core.STACKANY:
+			top.Any--
+			size += memSize(rt.SAny[top.Any])
+		default:
+			top.Int--
+		}
+	}
+	return
+}
+
+// embedResults returns the types of the results of the embedded function
+func embedResults(embed core.Embed) []uint16 {
+	if len(embed.Returns) > 0 {
+		return embed.Returns
+	}
+	return []uint16{embed.Return}
+}
+
+// ptrMemory returns the size of the variable value by the pointer
+func ptrMemory(ptr interface{}) int64 {
+	if v, ok := ptr.(*string); ok {
+		return int64(len(*v))
+	}
+	return memSize(ptr)
+}
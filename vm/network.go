@@ -45,7 +45,7 @@ func Download(rt *Runtime, url, filename string) (written int64, err error) {
 		return 0, err
 	}
 	defer resp.Body.Close()
-	out, err := os.Create(filename)
+	out, err := rt.Owner.Settings.FS.OpenFile(filename, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0666)
 	if err != nil {
 		return 0, err
 	}
@@ -144,7 +144,7 @@ func HTTPRequest(rt *Runtime, urlPath string, method string, params *core.Map, h
 		body        io.Reader
 	)
 	if rt.Owner.Settings.IsPlayground {
-		return ``, fmt.Errorf(ErrorText(ErrPlayFunc), `HTTPRequest`)
+		return ``, newError(ErrPlayFunc, `HTTPRequest`)
 	}
 	for _, key := range headers.Keys {
 		if key == `Content-Type` {
@@ -14,14 +14,14 @@ import (
 func AssignAddºObj(obj interface{}, value interface{}) (interface{}, error) {
 	var err error
 	if obj == nil {
-		return nil, fmt.Errorf(ErrorText(ErrObjNil))
+		return nil, newError(ErrObjNil)
 	}
 	val := obj.(*core.Obj)
 	switch v := val.Data.(type) {
 	case *core.Array:
 		v.Data = append(v.Data, value)
 	default:
-		err = fmt.Errorf(ErrorText(ErrObjValue))
+		err = newError(ErrObjValue)
 	}
 	return obj, err
 }
@@ -40,11 +40,11 @@ func AssignºObjBool(ptr interface{}, value interface{}) (interface{}, error) {
 // arrºObj returns array of the objects
 func arrºObj(obj *core.Obj) (*core.Array, error) {
 	if obj.Data == nil {
-		return nil, fmt.Errorf(ErrorText(ErrObjNil))
+		return nil, newError(ErrObjNil)
 	}
 	ret, ok := obj.Data.(*core.Array)
 	if !ok {
-		return nil, fmt.Errorf(ErrorText(ErrObjArr))
+		return nil, newError(ErrObjArr)
 	}
 	return ret, nil
 }
@@ -52,7 +52,7 @@ func arrºObj(obj *core.Obj) (*core.Array, error) {
 // arrstrºObj returns array of strings
 func arrstrºObj(val *core.Obj) (ret *core.Array, err error) {
 	if val.Data == nil {
-		return nil, fmt.Errorf(ErrorText(ErrObjNil))
+		return nil, newError(ErrObjNil)
 	}
 	ret = core.NewArray()
 	switch v := val.Data.(type) {
@@ -61,7 +61,7 @@ func arrstrºObj(val *core.Obj) (ret *core.Array, err error) {
 			AssignAddºArrAny(ret, strºObj(item.(*core.Obj)))
 		}
 	default:
-		err = fmt.Errorf(ErrorText(ErrObjArr))
+		err = newError(ErrObjArr)
 	}
 	return
 }
@@ -69,7 +69,7 @@ func arrstrºObj(val *core.Obj) (ret *core.Array, err error) {
 // boolºObj converts object to boolean value
 func boolºObj(val *core.Obj) (ret int64, err error) {
 	if val.Data == nil {
-		return 0, fmt.Errorf(ErrorText(ErrObjNil))
+		return 0, newError(ErrObjNil)
 	}
 	switch v := val.Data.(type) {
 	case int64:
@@ -113,7 +113,7 @@ func floatºObj(val *core.Obj) (ret float64, err error) {
 	case string:
 		ret, err = floatºStr(v)
 	default:
-		err = fmt.Errorf(ErrorText(ErrObjValue))
+		err = newError(ErrObjValue)
 	}
 	return
 }
@@ -140,7 +140,7 @@ func intºObj(val *core.Obj) (ret int64, err error) {
 	case string:
 		ret, err = intºStr(v)
 	default:
-		err = fmt.Errorf(ErrorText(ErrObjValue))
+		err = newError(ErrObjValue)
 	}
 	return
 }
@@ -188,7 +188,7 @@ func ItemºObjInt(val *core.Obj, ind int64) (ret *core.Obj, err error) {
 			ret = v.Data[ind].(*core.Obj)
 		}
 	default:
-		err = fmt.Errorf(ErrorText(ErrObjValue))
+		err = newError(ErrObjValue)
 	}
 	return
 }
@@ -204,7 +204,7 @@ func ItemºObjStr(val *core.Obj, key string) (ret *core.Obj, err error) {
 			ret = item.(*core.Obj)
 		}
 	default:
-		err = fmt.Errorf(ErrorText(ErrObjValue))
+		err = newError(ErrObjValue)
 	}
 	return
 }
@@ -212,11 +212,11 @@ func ItemºObjStr(val *core.Obj, key string) (ret *core.Obj, err error) {
 // mapºObj returns map of the objects
 func mapºObj(obj *core.Obj) (*core.Map, error) {
 	if obj.Data == nil {
-		return nil, fmt.Errorf(ErrorText(ErrObjNil))
+		return nil, newError(ErrObjNil)
 	}
 	ret, ok := obj.Data.(*core.Map)
 	if !ok {
-		return nil, fmt.Errorf(ErrorText(ErrObjMap))
+		return nil, newError(ErrObjMap)
 	}
 	return ret, nil
 }
@@ -301,7 +301,7 @@ func objType(val interface{}) (*core.Obj, error) {
 	case *core.Obj:
 		obj = v
 	default:
-		return nil, fmt.Errorf(ErrorText(ErrObjType))
+		return nil, newError(ErrObjType)
 	}
 	return obj, nil
 }
@@ -0,0 +1,30 @@
+// Copyright 2019 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+//go:build !linux && !windows && !darwin
+
+package vm
+
+import (
+	"os/exec"
+)
+
+func init() {
+	// the calls of these functions are compile-time errors on this platform
+	for i, embed := range EmbedFuncs {
+		if embed.Name == `Open` || embed.Name == `OpenWith` {
+			EmbedFuncs[i].Platform = true
+		}
+	}
+}
+
+// openCmd returns the command which opens the file with the corresponding application
+func openCmd(fname string) (*exec.Cmd, error) {
+	return nil, newError(ErrPlatform)
+}
+
+// openWithCmd returns the command which opens the file with the specified application
+func openWithCmd(app, fname string) (*exec.Cmd, error) {
+	return nil, newError(ErrPlatform)
+}
@@ -0,0 +1,20 @@
+// Copyright 2019 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package vm
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// openCmd returns the command which opens the file with the corresponding application
+func openCmd(fname string) (*exec.Cmd, error) {
+	return exec.Command("rundll32", "url.dll,FileProtocolHandler", fname), nil
+}
+
+// openWithCmd returns the command which opens the file with the specified application
+func openWithCmd(app, fname string) (*exec.Cmd, error) {
+	return exec.Command("cmd", "/c", "start", app, strings.Replace(fname, "&", "^&", -1)), nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2019 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package vm
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gentee/gentee/core"
+)
+
+// runner is used to call Run from the embedded function. Direct call of Run causes
+// the initialization cycle of EmbedFuncs.
+type runner interface {
+	Run(int64) (interface{}, error)
+}
+
+// parallelError is the error of the iteration of parallel for
+type parallelError struct {
+	Index int64
+	Err   *RuntimeError
+}
+
+// ParallelºFor calls the body of parallel for for each item of the source. The items are
+// distributed over count threads. Each thread gets own copies of the captured variables.
+// It waits for the finish of all iterations and returns the error if any iterations failed.
+func ParallelºFor(rt *Runtime, count int64, src interface{}, pfn *Fn) error {
+	var (
+		next  int64
+		stop  int32
+		mutex sync.Mutex
+		errs  []parallelError
+	)
+	length := int64(src.(core.Indexer).Len())
+	if count <= 0 {
+		count = rt.Owner.Settings.MaxThreads
+		if count <= 0 {
+			count = int64(runtime.NumCPU())
+		}
+	}
+	if count > length {
+		count = length
+	}
+	if count == 0 {
+		return nil
+	}
+	offset := int64(rt.Owner.Exec.Funcs[pfn.Func])
+	done := make(chan struct{})
+	active := count
+	workers := make([]*Thread, 0, count)
+	for k := int64(0); k < count; k++ {
+		thread := rt.Owner.newThread(ThQueue)
+		if thread == nil {
+			atomic.StoreInt32(&stop, 1)
+			return newError(ErrThreadClosed)
+		}
+		workers = append(workers, thread)
+		optional := make([]OptValue, 2+len(pfn.Captures))
+		for i := range optional {
+			optional[i].Var = int32(i)
+			if i >= 2 {
+				CopyVar(rt, &optional[i].Value, pfn.Captures[i-2])
+			}
+		}
+		rt.Owner.startThread(thread, func(wrt *Runtime) (interface{}, error) {
+			defer func() {
+				if atomic.AddInt64(&active, -1) == 0 {
+					close(done)
+				}
+			}()
+			var worker runner = wrt
+			for atomic.LoadInt32(&stop) == 0 {
+				index := atomic.AddInt64(&next, 1) - 1
+				if index >= length {
+					break
+				}
+				item, _ := src.(core.Indexer).GetIndex(index)
+				optional[0].Value = nil
+				CopyVar(wrt, &optional[0].Value, item)
+				optional[1].Value = index
+				wrt.Optional = &optional
+				wrt.Calls = wrt.Calls[:0]
+				wrt.Defers = wrt.Defers[:0]
+				_, err := worker.Run(offset)
+				wrt.releaseLocks()
+				if err != nil {
+					if thread.Status == ThClosed {
+						return nil, err
+					}
+					mutex.Lock()
+					errs = append(errs, parallelError{Index: index, Err: err.(*RuntimeError)})
+					mutex.Unlock()
+				}
+			}
+			return nil, nil
+		}, nil)
+	}
+	_, _, _, err := chanSelect(rt, []reflect.SelectCase{{Dir: reflect.SelectRecv,
+		Chan: reflect.ValueOf(done)}}, false)
+	if err != nil {
+		atomic.StoreInt32(&stop, 1)
+		rt.Owner.ThreadMutex.Lock()
+		for _, thread := range workers {
+			if thread.Status < ThFinished {
+				thread.Chan <- ThCmdClose
+			}
+		}
+		rt.Owner.ThreadMutex.Unlock()
+		return err
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	sort.Slice(errs, func(i, j int) bool {
+		return errs[i].Index < errs[j].Index
+	})
+	messages := core.NewArray()
+	for _, item := range errs {
+		messages.Data = append(messages.Data, item.Err.Message)
+	}
+	payload, _ := objType(messages)
+	return &RuntimeError{
+		ID:      ErrParallel,
+		Message: fmt.Sprintf(ErrorText(ErrParallel), len(errs), length),
+		Payload: payload,
+		Cause:   errs[0].Err,
+	}
+}
@@ -15,7 +15,7 @@ func AbsPath(rt *Runtime, fname string) (string, error) {
 	if rt.Owner.Settings.IsPlayground {
 		return PlaygroundAbsPath(rt.Owner, fname)
 	}
-	return filepath.Abs(fname)
+	return rt.Owner.Settings.FS.Abs(fname)
 }
 
 // BaseName returns the last element of path.
@@ -40,13 +40,13 @@ type PlaygroundFS struct {
 // InitPlayground inits playground settings
 func InitPlayground(settings *Settings) (err error) {
 	if len(settings.Playground.Path) == 0 {
-		settings.Playground.Path = TempDir()
+		settings.Playground.Path = settings.FS.TempDir()
 	}
-	if settings.Playground.Path, err = filepath.Abs(settings.Playground.Path); err != nil {
+	settings.Playground.Path = filepath.Join(settings.Playground.Path, strings.ToLower(core.RandName()))
+	if settings.Playground.Path, err = settings.FS.Abs(settings.Playground.Path); err != nil {
 		return
 	}
-	settings.Playground.Path = filepath.Join(settings.Playground.Path, strings.ToLower(core.RandName()))
-	if err = os.MkdirAll(settings.Playground.Path, os.ModePerm); err != nil {
+	if err = settings.FS.MkdirAll(settings.Playground.Path, os.ModePerm); err != nil {
 		return
 	}
 	if settings.Playground.AllSizeLimit == 0 {
@@ -58,16 +58,16 @@ func InitPlayground(settings *Settings) (err error) {
 	if settings.Playground.SizeLimit == 0 {
 		settings.Playground.SizeLimit = 5 << 20 // 5MB
 	}
-	return os.Chdir(settings.Playground.Path)
+	return settings.FS.Chdir(settings.Playground.Path)
 }
 
 // DeinitPlayground removes playground files
 func DeinitPlayground(vm *VM) {
-	os.RemoveAll(vm.Settings.Playground.Path)
+	vm.Settings.FS.RemoveAll(vm.Settings.Playground.Path)
 }
 
 func PlaygroundAbsPath(vm *VM, fname string) (ret string, err error) {
-	ret, err = filepath.Abs(fname)
+	ret, err = vm.Settings.FS.Abs(fname)
 	if err == nil {
 		if !strings.HasPrefix(strings.ToLower(ret), strings.ToLower(vm.Settings.Playground.Path)) {
 			return ``, fmt.Errorf(`%s [%s]`, ErrorText(ErrPlayAccess), fname)
@@ -0,0 +1,252 @@
+// Copyright 2019 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package vm
+
+import (
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/gentee/gentee/core"
+)
+
+// Capability is the kind of the actions which can be restricted by Policy
+type Capability int
+
+const (
+	// CapRead is reading files and directories. The target is the path prefix.
+	CapRead Capability = iota
+	// CapWrite is creating, changing and deleting files and directories. The target is the
+	// path prefix.
+	CapWrite
+	// CapExec is starting processes. The target is the name of the program.
+	CapExec
+	// CapNetwork is network requests. The target is the host.
+	CapNetwork
+	// CapEnv is changing environment variables. The target is the name of the variable.
+	CapEnv
+	// CapThread is starting threads. It doesn't have any target.
+	CapThread
+)
+
+var capNames = []string{`read`, `write`, `exec`, `network`, `env`, `thread`}
+
+func (capability Capability) String() string {
+	if int(capability) < len(capNames) {
+		return capNames[capability]
+	}
+	return `unknown`
+}
+
+// Rule allows or denies the capability for the target. Empty target or * matches any target.
+// The path target matches the path and all files inside it, the host target matches the host
+// and its subdomains.
+type Rule struct {
+	Cap    Capability
+	Target string
+	Deny   bool
+}
+
+// Policy restricts the capabilities of the script. The first matching rule is applied and the
+// action is denied if there is no matching rule.
+type Policy struct {
+	Rules []Rule
+}
+
+// policyCheck describes the restricted parameter of the embedded function
+type policyCheck struct {
+	Cap   Capability
+	Param int // index of the parameter with the target, -1 means no target
+}
+
+// policyFuncs contains the embedded functions which are restricted by Policy
+var policyFuncs = map[string][]policyCheck{
+	`AppendFile(str,buf)`:                  {{CapWrite, 0}},
+	`AppendFile(str,str)`:                  {{CapWrite, 0}},
+	`ChDir(str)`:                           {{CapRead, 0}},
+	`ChMode(str,int)`:                      {{CapWrite, 0}},
+	`Command(str)`:                         {{CapExec, 0}},
+	`CommandOutput(str)`:                   {{CapExec, 0}},
+	`CompressFile(handle,str,str)`:         {{CapRead, 1}},
+	`CopyFile(str,str)`:                    {{CapRead, 0}, {CapWrite, 1}},
+	`CreateDir(str)`:                       {{CapWrite, 0}},
+	`CreateFile(str,bool)`:                 {{CapWrite, 0}},
+	`CreateTarGz(str)`:                     {{CapWrite, 0}},
+	`CreateZip(str)`:                       {{CapWrite, 0}},
+	`Download(str,str)`:                    {{CapNetwork, 0}, {CapWrite, 1}},
+	`ExistFile(str)`:                       {{CapRead, 0}},
+	`FileInfo(str)`:                        {{CapRead, 0}},
+	`FileMode(str)`:                        {{CapRead, 0}},
+	`HeadInfo(str)`:                        {{CapNetwork, 0}},
+	`HTTPGet(str)`:                         {{CapNetwork, 0}},
+	`HTTPPage(str)`:                        {{CapNetwork, 0}},
+	`HTTPRequest(str,str,map.str,map.str)`: {{CapNetwork, 0}},
+	`IsEmptyDir(str)`:                      {{CapRead, 0}},
+	`Md5File(str)`:                         {{CapRead, 0}},
+	`Open(str)`:                            {{CapExec, -1}},
+	`OpenFile(str,int)`:                    {{CapRead, 0}, {CapWrite, 0}},
+	`OpenWith(str,str)`:                    {{CapExec, 0}, {CapRead, 1}},
+	`ParallelºFor(int,obj,fn)`:             {{CapThread, -1}},
+	`ReadDir(str)`:                         {{CapRead, 0}},
+	`ReadDir(str,int,arr.str,arr.str)`:     {{CapRead, 0}},
+	`ReadDir(str,int,str)`:                 {{CapRead, 0}},
+	`ReadFile(str)`:                        {{CapRead, 0}},
+	`ReadFile(str,buf)`:                    {{CapRead, 0}},
+	`ReadFile(str,int,int)`:                {{CapRead, 0}},
+	`ReadTarGz(str)`:                       {{CapRead, 0}},
+	`ReadZip(str)`:                         {{CapRead, 0}},
+	`Remove(str)`:                          {{CapWrite, 0}},
+	`RemoveDir(str)`:                       {{CapWrite, 0}},
+	`Rename(str,str)`:                      {{CapWrite, 0}, {CapWrite, 1}},
+	`SetEnv(str,bool)`:                     {{CapEnv, 0}},
+	`SetEnv(str,int)`:                      {{CapEnv, 0}},
+	`SetEnv(str,str)`:                      {{CapEnv, 0}},
+	`SetFileTime(str,time)`:                {{CapWrite, 0}},
+	`Sha256File(str)`:                      {{CapRead, 0}},
+	`sysRun(str,bool,buf,buf,buf,arr.str)`: {{CapExec, 0}},
+	`TarGz(str,str)`:                       {{CapWrite, 0}, {CapRead, 1}},
+	`TempDir(str,str)`:                     {{CapWrite, 0}},
+	`UnpackTarGz(str,str)`:                 {{CapRead, 0}, {CapWrite, 1}},
+	`UnpackTarGz(str,str,arr.str,arr.str)`: {{CapRead, 0}, {CapWrite, 1}},
+	`UnpackZip(str,str)`:                   {{CapRead, 0}, {CapWrite, 1}},
+	`UnpackZip(str,str,arr.str,arr.str)`:   {{CapRead, 0}, {CapWrite, 1}},
+	`UnsetEnv(str)`:                        {{CapEnv, 0}},
+	`WriteFile(str,buf)`:                   {{CapWrite, 0}},
+	`WriteFile(str,str)`:                   {{CapWrite, 0}},
+	`Zip(str,str)`:                         {{CapWrite, 0}, {CapRead, 1}},
+}
+
+// embedPolicy contains the restrictions of EmbedFuncs by their indexes
+var embedPolicy [][]policyCheck
+
+func init() {
+	embedPolicy = make([][]policyCheck, len(EmbedFuncs))
+	for i, embed := range EmbedFuncs {
+		embedPolicy[i] = policyFuncs[embedKey(embed)]
+	}
+}
+
+// embedKey returns the name of the embedded function with the types of the parameters
+func embedKey(embed core.Embed) string {
+	return embed.Name + `(` + strings.ReplaceAll(embed.Pars, ` `, ``) + `)`
+}
+
+// isPathSeparator returns true if the character is the separator of the OS or MemFS paths
+func isPathSeparator(c byte) bool {
+	return c == '/' || c == os.PathSeparator
+}
+
+// insidePath returns true if the path is the directory or it is inside the directory
+func insidePath(dir, path string) bool {
+	if !strings.HasPrefix(path, dir) {
+		return false
+	}
+	return len(path) == len(dir) || isPathSeparator(dir[len(dir)-1]) ||
+		isPathSeparator(path[len(dir)])
+}
+
+// matchTarget returns true if the pattern of the rule matches the target
+func matchTarget(fsys FileSystem, capability Capability, pattern, target string) bool {
+	if len(pattern) == 0 || pattern == `*` {
+		return true
+	}
+	switch capability {
+	case CapRead, CapWrite:
+		var err error
+		if pattern, err = fsys.Abs(pattern); err != nil {
+			return false
+		}
+		return insidePath(pattern, target)
+	case CapNetwork:
+		target = strings.ToLower(target)
+		pattern = strings.ToLower(pattern)
+		return target == pattern || strings.HasSuffix(target, `.`+pattern)
+	}
+	return target == pattern
+}
+
+// policyTarget converts the parameter of the function to the target of the capability
+func policyTarget(fsys FileSystem, capability Capability, value string) (string, error) {
+	switch capability {
+	case CapRead, CapWrite:
+		return fsys.Abs(value)
+	case CapExec:
+		if args := SplitCmdLine(value); len(args.Data) > 0 {
+			return args.Data[0].(string), nil
+		}
+	case CapNetwork:
+		if u, err := url.Parse(value); err == nil && len(u.Hostname()) > 0 {
+			return u.Hostname(), nil
+		}
+	}
+	return value, nil
+}
+
+// checkCapability returns an error if the policy doesn't allow the capability for the target
+func checkCapability(settings *Settings, capability Capability, target string) error {
+	for _, rule := range settings.Policy.Rules {
+		if rule.Cap == capability && matchTarget(settings.FS, capability, rule.Target, target) {
+			if !rule.Deny {
+				return nil
+			}
+			break
+		}
+	}
+	if len(target) > 0 {
+		return newError(ErrPolicy, capability.String()+` `+target)
+	}
+	return newError(ErrPolicy, capability.String())
+}
+
+// checkPolicy checks the parameters of the embedded function which are on the top of the stacks
+func checkPolicy(rt *Runtime, top Call, idEmbed uint16) error {
+	if int(idEmbed) >= len(embedPolicy) || len(embedPolicy[idEmbed]) == 0 {
+		return nil
+	}
+	params := EmbedFuncs[idEmbed].Params
+	for _, check := range embedPolicy[idEmbed] {
+		var target string
+		if check.Param >= 0 {
+			if check.Cap == CapWrite && EmbedFuncs[idEmbed].Name == `OpenFile` &&
+				stackParam(rt, top, params, 1).(int64)&FileReadonly != 0 {
+				continue
+			}
+			value, _ := stackParam(rt, top, params, check.Param).(string)
+			var err error
+			if target, err = policyTarget(rt.Owner.Settings.FS, check.Cap, value); err != nil {
+				return err
+			}
+		}
+		if err := checkCapability(&rt.Owner.Settings, check.Cap, target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stackParam returns the parameter of the embedded function from the stacks
+func stackParam(rt *Runtime, top Call, params []uint16, index int) interface{} {
+	for i := len(params) - 1; i > index; i-- {
+		switch params[i] & 0xf {
+		case core.STACKFLOAT:
+			top.Float--
+		case core.STACKSTR:
+			top.Str--
+		case core.STACKANY:
+			top.Any--
+		default:
+			top.Int--
+		}
+	}
+	switch params[index] & 0xf {
+	case core.STACKFLOAT:
+		return rt.SFloat[top.Float-1]
+	case core.STACKSTR:
+		return rt.SStr[top.Str-1]
+	case core.STACKANY:
+		return rt.SAny[top.Any-1]
+	}
+	return rt.SInt[top.Int-1]
+}
@@ -9,7 +9,6 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
-	"runtime"
 	"strconv"
 	"strings"
 
@@ -118,42 +117,26 @@ func ArgsTail(rt *Runtime) *core.Array {
 
 // OpenºStr runs corresponding application with the specified file.
 func OpenºStr(rt *Runtime, fname string) error {
-	var err error
-
 	if rt.Owner.Settings.IsPlayground {
-		return fmt.Errorf(ErrorText(ErrPlayRun))
+		return newError(ErrPlayRun)
 	}
-	switch runtime.GOOS {
-	case "linux":
-		err = exec.Command("xdg-open", fname).Start()
-	case "windows":
-		err = exec.Command("rundll32", "url.dll,FileProtocolHandler", fname).Start()
-	case "darwin":
-		err = exec.Command("open", fname).Start()
-	default:
-		err = fmt.Errorf(ErrorText(ErrPlatform))
+	cmd, err := openCmd(fname)
+	if err != nil {
+		return err
 	}
-	return err
+	return cmd.Start()
 }
 
 // OpenWithºStr runs the application with the specified file.
 func OpenWithºStr(rt *Runtime, app, fname string) error {
-	var err error
-
 	if rt.Owner.Settings.IsPlayground {
-		return fmt.Errorf(ErrorText(ErrPlayRun))
+		return newError(ErrPlayRun)
 	}
-	switch runtime.GOOS {
-	case "linux":
-		err = exec.Command(app, fname).Start()
-	case "windows":
-		err = exec.Command("cmd", "/c", "start", app, strings.Replace(fname, "&", "^&", -1)).Start()
-	case "darwin":
-		err = exec.Command("open", "-a", app, fname).Start()
-	default:
-		err = fmt.Errorf(ErrorText(ErrPlatform))
+	cmd, err := openWithCmd(app, fname)
+	if err != nil {
+		return err
 	}
-	return err
+	return cmd.Start()
 }
 
 // IsArgºStr returns true if the options is present
@@ -227,7 +210,7 @@ func sysRun(rt *Runtime, cmd string, start int64, stdin *core.Buffer, stdout *co
 		bufOut, bufIn, bufErr bytes.Buffer
 	)
 	if rt.Owner.Settings.IsPlayground {
-		return fmt.Errorf(ErrorText(ErrPlayRun))
+		return newError(ErrPlayRun)
 	}
 	for _, arg := range args.Data {
 		pars = append(pars, fmt.Sprint(arg))
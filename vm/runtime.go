@@ -28,6 +28,23 @@ type indexInfo struct {
 	Objects [32]indexObj
 }
 
+// pushCmds contains the commands which can push more items to a typed stack than they pop.
+// The free space of the stacks is checked only before these commands.
+var pushCmds [0x1000]bool
+
+func init() {
+	for _, cmd := range []core.Bcode{core.PUSH32, core.PUSH64, core.PUSHFLOAT, core.PUSHSTR,
+		core.PUSHFUNC, core.CLOSURE, core.EQFLOAT, core.LTFLOAT, core.GTFLOAT, core.EQSTR,
+		core.LTSTR, core.GTSTR, core.GETVAR, core.SETVAR, core.DUP, core.INITOBJ, core.RANGE,
+		core.ARRAY, core.LEN, core.CONSTBYID, core.GOBYID, core.EMBED, core.IFACE,
+		core.ENUMVAL, core.INCVAR} {
+		pushCmds[cmd] = true
+	}
+}
+
+// embedStub calls the embedded function with the parameters from the stacks of the runtime
+type embedStub func(rt *Runtime, top *Call, vars []interface{}) error
+
 func (rt *Runtime) Run(i int64) (result interface{}, err error) {
 	var (
 		iInfo    indexInfo
@@ -35,12 +52,79 @@ func (rt *Runtime) Run(i int64) (result interface{}, err error) {
 		tmpStr   string
 		tmpFloat float64
 		count    int
+		isGlobal bool // GlobalMutex is locked by the current command
+		// stackTop is the greatest top which leaves stackReserve free items in each typed stack
+		stackTop int32 = -stackReserve
 	)
 
 	top := Call{}
+	rt.top = &top
 	code := rt.Owner.Exec.Code
 	end := int64(len(code))
 
+	// runDefer calls the latest deferred function or finally block which must be called
+	// when the blocks from the level index are left. The call returns to offset + 1.
+	runDefer := func(level int, offset int64, pending error) bool {
+		last := len(rt.Defers) - 1
+		for ; last >= 0; last-- {
+			if rt.Defers[last].Level >= level {
+				break
+			}
+		}
+		if last < 0 {
+			return false
+		}
+		item := rt.Defers[last]
+		rt.Defers = append(rt.Defers[:last], rt.Defers[last+1:]...)
+		if item.Fn == nil && pending != nil && len(rt.Calls) > item.Level+1 {
+			// finally block uses the variables of the blocks where it has been defined
+			curTop := top
+			top = rt.Calls[item.Level+1]
+			rt.Calls = rt.Calls[:item.Level+1]
+			for j := top.Any; j < curTop.Any; j++ {
+				rt.SAny[j] = nil
+			}
+		}
+		if item.Fn != nil {
+			if !pushCaptures(rt, item.Fn, &top) {
+				// the deferred function cannot be called so the script is stopped
+				err = runtimeError(rt, offset, ErrStackSize)
+				i = end + 1
+				return true
+			}
+			rt.ParCount = int32(len(item.Fn.Captures))
+			i = int64(rt.Owner.Exec.Funcs[item.Fn.Func])
+		} else {
+			i = item.Offset
+		}
+		rt.Calls = append(rt.Calls, Call{
+			IsFunc:  item.Fn != nil,
+			IsLocal: item.Fn == nil,
+			Offset:  int32(offset),
+			Int:     top.Int,
+			Float:   top.Float,
+			Str:     top.Str,
+			Any:     top.Any,
+			Err:     pending,
+		})
+		return true
+	}
+
+	// unlockGlobal is called if isGlobal is true. The check is inlined because it is done
+	// for each command.
+	unlockGlobal := func() {
+		isGlobal = false
+		rt.isGlobal = false
+		rt.Owner.GlobalMutex.Unlock()
+	}
+	// lockGlobal locks the access to the global variables until the end of the current command
+	lockGlobal := func(id int32) interface{} {
+		rt.Owner.GlobalMutex.Lock()
+		isGlobal = true
+		rt.isGlobal = true
+		return rt.Owner.Globals[id]
+	}
+
 	errHandle := func(pos int64, errPar interface{}, pars ...interface{}) {
 		k := len(rt.Calls) - 1
 		for ; k > 0; k-- {
@@ -51,6 +135,9 @@ func (rt *Runtime) Run(i int64) (result interface{}, err error) {
 		if pos >= 0 { // otherwise, getting error from CATCH
 			err = runtimeError(rt, pos, errPar, pars...)
 		}
+		if runDefer(max(k, 0), i, err) {
+			return
+		}
 		if k <= 0 {
 			i = end + 1
 			return
@@ -66,7 +153,20 @@ func (rt *Runtime) Run(i int64) (result interface{}, err error) {
 
 main:
 	for i < end {
-		switch code[i] & 0x0fff {
+		if isGlobal {
+			unlockGlobal()
+		}
+		cmd := code[i] & 0x0fff
+		if pushCmds[cmd] && (top.Int > stackTop || top.Float > stackTop ||
+			top.Str > stackTop || top.Any > stackTop) {
+			if !rt.growStacks(&top, stackReserve) {
+				errHandle(i, ErrStackSize)
+				continue
+			}
+			stackTop = int32(min(len(rt.SInt), len(rt.SFloat), len(rt.SStr),
+				len(rt.SAny))) - stackReserve
+		}
+		switch cmd {
 		case core.PUSH32:
 			i++
 			rt.SInt[top.Int] = int64(code[i])
@@ -87,6 +187,29 @@ main:
 			i++
 			rt.SAny[top.Any] = &Fn{Func: int32(code[i])}
 			top.Any++
+		case core.CLOSURE:
+			count = int(code[i] >> 16)
+			i++
+			pfn := &Fn{Func: int32(code[i]), Captures: make([]interface{}, count)}
+			for j := count - 1; j >= 0; j-- {
+				switch code[i+int64(j)+1] & 0xf {
+				case core.STACKFLOAT:
+					top.Float--
+					pfn.Captures[j] = rt.SFloat[top.Float]
+				case core.STACKSTR:
+					top.Str--
+					pfn.Captures[j] = rt.SStr[top.Str]
+				case core.STACKANY:
+					top.Any--
+					pfn.Captures[j] = rt.SAny[top.Any]
+				default:
+					top.Int--
+					pfn.Captures[j] = rt.SInt[top.Int]
+				}
+			}
+			i += int64(count)
+			rt.SAny[top.Any] = pfn
+			top.Any++
 		case core.ADD:
 			top.Int--
 			rt.SInt[top.Int-1] += rt.SInt[top.Int]
@@ -209,6 +332,10 @@ main:
 		case core.ADDSTR:
 			top.Str--
 			rt.SStr[top.Str-1] += rt.SStr[top.Str]
+			if errMem := allocMemory(rt, int64(len(rt.SStr[top.Str]))); errMem != nil {
+				errHandle(i, errMem)
+				continue
+			}
 		case core.EQSTR:
 			top.Str -= 2
 			if rt.SStr[top.Str] == rt.SStr[top.Str+1] {
@@ -243,19 +370,18 @@ main:
 			} else {
 				count = 0
 			}
+			var (
+				blockOff Call
+				global   interface{}
+			)
 			base := int(code[i]) >> 16
-			if base >= 0x0f00 {
-				next := base - 0x0f00
-				for base = len(rt.Calls) - 1; base > 0; base-- {
-					if rt.Calls[base].IsFunc {
-						break
-					}
-				}
-				base += next
+			if base == core.GlobalShift {
+				global = lockGlobal(int32(code[i+1] & 0xffff))
+			} else if base&core.BoxShift != 0 {
+				global = rt.SAny[rt.Calls[rt.blockCall(base&^core.BoxShift)].Any+int32(code[i+1]&0xffff)]
 			} else {
-				base = len(rt.Calls) - 1 - base
+				blockOff = rt.Calls[rt.blockCall(base)]
 			}
-			blockOff := rt.Calls[int32(base)]
 			i++
 			typeVar := int(code[i]) >> 16
 			root := int64(int(code[i]) & 0xffff)
@@ -272,6 +398,25 @@ main:
 				fmt.Println(`root index`, typeVar)
 			}
 			if count == 0 {
+				if global != nil {
+					value := getGlobal(global)
+					switch typeVar & 0xf {
+					case core.STACKINT:
+						rt.SInt[top.Int] = value.(int64)
+						top.Int++
+					case core.STACKFLOAT:
+						rt.SFloat[top.Float] = value.(float64)
+						top.Float++
+					case core.STACKSTR:
+						rt.SStr[top.Str] = value.(string)
+						top.Str++
+					default:
+						rt.SAny[top.Any] = value
+						top.Any++
+					}
+					i++
+					continue
+				}
 				switch typeVar & 0xf {
 				case core.STACKINT:
 					rt.SInt[top.Int] = rt.SInt[root]
@@ -294,7 +439,13 @@ main:
 			i++
 			var ptr, value interface{}
 			var ok bool
-			if typeVar&0xf == core.STACKSTR {
+			if global != nil {
+				if typeVar&0xf == core.STACKSTR {
+					ptr = global
+				} else {
+					ptr = getGlobal(global)
+				}
+			} else if typeVar&0xf == core.STACKSTR {
 				ptr = &rt.SStr[root]
 			} else {
 				ptr = rt.SAny[root]
@@ -375,9 +526,11 @@ main:
 			}
 		case core.SETVAR:
 			var (
-				ptr     interface{}
-				errVar  error
-				typeRet int
+				ptr      interface{}
+				errVar   error
+				typeRet  int
+				blockOff Call
+				global   interface{}
 			)
 
 			if code[i+2]&0xffff == core.INDEX {
@@ -386,39 +539,44 @@ main:
 				count = 0
 			}
 			base := int(code[i]) >> 16
-			if base >= 0x0f00 {
-				next := base - 0x0f00
-				for base = len(rt.Calls) - 1; base > 0; base-- {
-					if rt.Calls[base].IsFunc {
-						break
-					}
-				}
-				base += next
+			if base == core.GlobalShift {
+				global = lockGlobal(int32(code[i+1] & 0xffff))
+			} else if base&core.BoxShift != 0 {
+				global = rt.SAny[rt.Calls[rt.blockCall(base&^core.BoxShift)].Any+int32(code[i+1]&0xffff)]
 			} else {
-				base = len(rt.Calls) - 1 - base
+				blockOff = rt.Calls[rt.blockCall(base)]
 			}
-			blockOff := rt.Calls[int32(base)]
 			i++
 			typeVar := int(code[i]) >> 16
 			//			typeRet := typeVar
 			obj := &iInfo.Objects[0]
 			obj.Type = typeVar
+			obj.Obj = nil
 			root := int64(int(code[i]) & 0xffff)
-			switch typeVar & 0xf {
-			case core.STACKINT:
-				root += int64(blockOff.Int)
-				ptr = &rt.SInt[root]
-			case core.STACKFLOAT:
-				root += int64(blockOff.Float)
-				ptr = &rt.SFloat[root]
-			case core.STACKSTR:
-				root += int64(blockOff.Str)
-				ptr = &rt.SStr[root]
-			case core.STACKANY:
-				root += int64(blockOff.Any)
-				ptr = rt.SAny[root]
-			default:
-				fmt.Println(`root index`, typeVar)
+			if global != nil {
+				obj.Obj = &globalVar{Value: global}
+				if typeVar&0xf == core.STACKANY {
+					ptr = getGlobal(global)
+				} else {
+					ptr = global
+				}
+			} else {
+				switch typeVar & 0xf {
+				case core.STACKINT:
+					root += int64(blockOff.Int)
+					ptr = &rt.SInt[root]
+				case core.STACKFLOAT:
+					root += int64(blockOff.Float)
+					ptr = &rt.SFloat[root]
+				case core.STACKSTR:
+					root += int64(blockOff.Str)
+					ptr = &rt.SStr[root]
+				case core.STACKANY:
+					root += int64(blockOff.Any)
+					ptr = rt.SAny[root]
+				default:
+					fmt.Println(`root index`, typeVar)
+				}
 			}
 			obj.Index = root
 			if count > 0 {
@@ -485,19 +643,29 @@ main:
 				}
 			}
 			i++
-			assign := code[i] & 0xffff
+			assign := code[i] & 0xffff &^ core.AssignDrop
+			drop := code[i]&core.AssignDrop != 0
 			rightType := code[i] >> 16
 			//			fmt.Printf("Assign %d %d %d %d %x %x\n", count, assign, core.ASSIGN, core.ASSIGNPTR,
 			//				core.Bcode(typeVar), rightType)
 			if count == 0 && (assign == core.ASSIGN || assign == core.ASSIGNPTR) &&
-				core.Bcode(typeVar) == rightType {
+				core.Bcode(typeVar) == rightType && global == nil {
 				switch rightType & 0xf {
 				case core.STACKINT:
 					rt.SInt[root] = rt.SInt[top.Int-1]
+					if drop {
+						top.Int--
+					}
 				case core.STACKFLOAT:
 					rt.SFloat[root] = rt.SFloat[top.Float-1]
+					if drop {
+						top.Float--
+					}
 				case core.STACKSTR:
 					rt.SStr[root] = rt.SStr[top.Str-1]
+					if drop {
+						top.Str--
+					}
 				default:
 					if rt.SAny[root] == rt.SAny[top.Any-1] && rt.SAny[top.Any-1] != nil /*handle*/ {
 						errHandle(i, ErrAssignment)
@@ -506,9 +674,16 @@ main:
 					}
 					if assign == core.ASSIGN {
 						CopyVar(rt, &rt.SAny[root], rt.SAny[top.Any-1])
+						if errMem := allocMemory(rt, memSize(rt.SAny[root])); errMem != nil {
+							errHandle(i, errMem)
+							continue main
+						}
 					} else {
 						rt.SAny[root] = rt.SAny[top.Any-1]
 					}
+					if drop {
+						top.Any--
+					}
 				}
 				i++
 				continue
@@ -530,6 +705,10 @@ main:
 			default:
 				fmt.Printf("iValue %x\n", rightType)
 			}
+			var memBefore int64
+			if rt.Owner.Settings.MemoryLimit > 0 {
+				memBefore = ptrMemory(ptr) + memSize(iInfo.Objects[count].Obj)
+			}
 			obj = &iInfo.Objects[count]
 			if assign == core.ASSIGN || assign == core.ASSIGNPTR &&
 				core.Bcode(obj.Type) == rightType {
@@ -621,6 +800,16 @@ main:
 					}
 				}
 			}
+			if rt.Owner.Settings.MemoryLimit > 0 {
+				errVar = allocMemory(rt, ptrMemory(ptr)+memSize(iInfo.Objects[count].Obj)-memBefore)
+				if errVar != nil {
+					errHandle(i, errVar)
+					continue main
+				}
+			}
+			if drop {
+				break
+			}
 			switch iInfo.Objects[count].Type & 0xf {
 			case core.STACKINT:
 				rt.SInt[top.Int] = iValue.(int64)
@@ -777,6 +966,11 @@ main:
 				}
 				i++
 				varCount := int32(code[i] & 0xffff)
+				if !rt.growStacks(&top, varCount) {
+					errHandle(i, ErrStackSize)
+					continue main
+				}
+				types := code[i+1 : i+1+int64(varCount)]
 				for k := int32(0); k < varCount; k++ {
 					i++
 					varType := int(code[i])
@@ -797,7 +991,12 @@ main:
 							curTop.Int--
 						}
 					} else {
-						value := newValue(rt, varType)
+						var value interface{}
+						if varType&0xffff == core.TYPEBOX {
+							value = newCell(rt, types, varType>>16, curTop)
+						} else {
+							value = newValue(rt, varType)
+						}
 						if optional != nil {
 							for _, optVar := range *optional {
 								if k == optVar.Var {
@@ -847,6 +1046,9 @@ main:
 			//			fmt.Println(`INIT OK`, rt.SInt[:top.Int], rt.SAny[:top.Any])
 			//			fmt.Println(`INITVARS`, rt.Calls)
 		case core.DELVARS:
+			if runDefer(len(rt.Calls)-1, i-1, nil) {
+				continue
+			}
 			curTop := top
 			top = rt.Calls[len(rt.Calls)-1]
 			rt.Calls = rt.Calls[:len(rt.Calls)-1]
@@ -1080,6 +1282,9 @@ main:
 					break
 				}
 			}
+			if runDefer(k, i-1, nil) {
+				continue
+			}
 			for j := rt.Calls[k].Any; j < top.Any; j++ {
 				rt.SAny[j] = nil
 			}
@@ -1094,6 +1299,9 @@ main:
 					break
 				}
 			}
+			if runDefer(k, i-1, nil) {
+				continue
+			}
 			for j := rt.Calls[k].Any; j < top.Any; j++ {
 				rt.SAny[j] = nil
 			}
@@ -1111,6 +1319,9 @@ main:
 					break
 				}
 			}
+			if runDefer(k, i-1, nil) {
+				continue
+			}
 			for j := rt.Calls[k].Any; j < top.Any; j++ {
 				rt.SAny[j] = nil
 			}
@@ -1131,6 +1342,9 @@ main:
 					break
 				}
 			}
+			if runDefer(max(k, 0), i-1, nil) {
+				continue
+			}
 			rt.Calls = rt.Calls[:k+1]
 			if len(rt.Calls) == 0 { // return from run function
 				switch retType {
@@ -1175,6 +1389,41 @@ main:
 				rt.SAny[j] = nil
 			}
 			i = int64(top.Offset)
+		case core.RETMULTI:
+			var counts [core.STACKANY + 1]int32
+			count = int(code[i] >> 16)
+			for j := int64(1); j <= int64(count); j++ {
+				counts[code[i+j]&0xf]++
+			}
+			k := len(rt.Calls) - 1
+			for ; k >= 0; k-- {
+				if rt.Calls[k].IsFunc || rt.Calls[k].IsLocal {
+					break
+				}
+			}
+			if runDefer(max(k, 0), i-1, nil) {
+				continue
+			}
+			if k < 0 {
+				errHandle(i, ErrRuntime, `return values`)
+				continue main
+			}
+			curTop := top
+			top = rt.Calls[k]
+			rt.Calls = rt.Calls[:k]
+			// the values are copied with keeping their order in the typed stacks
+			copy(rt.SInt[top.Int:], rt.SInt[curTop.Int-counts[core.STACKINT]:curTop.Int])
+			top.Int += counts[core.STACKINT]
+			copy(rt.SStr[top.Str:], rt.SStr[curTop.Str-counts[core.STACKSTR]:curTop.Str])
+			top.Str += counts[core.STACKSTR]
+			copy(rt.SFloat[top.Float:], rt.SFloat[curTop.Float-counts[core.STACKFLOAT]:curTop.Float])
+			top.Float += counts[core.STACKFLOAT]
+			copy(rt.SAny[top.Any:], rt.SAny[curTop.Any-counts[core.STACKANY]:curTop.Any])
+			top.Any += counts[core.STACKANY]
+			for j := top.Any; j < curTop.Any; j++ {
+				rt.SAny[j] = nil
+			}
+			i = int64(top.Offset)
 		case core.END:
 			k := len(rt.Calls) - 1
 			for ; k >= 0; k-- {
@@ -1182,6 +1431,9 @@ main:
 					break
 				}
 			}
+			if runDefer(max(k, 0), i-1, nil) {
+				continue
+			}
 			rt.Calls = rt.Calls[:k+1]
 			if len(rt.Calls) == 0 {
 				break main
@@ -1189,6 +1441,11 @@ main:
 			top = rt.Calls[k]
 			rt.Calls = rt.Calls[:k]
 			i = int64(top.Offset)
+			if top.Err != nil { // the deferred call has been called on the error
+				err = top.Err
+				errHandle(-1, err)
+				continue main
+			}
 		case core.CONSTBYID:
 			i++
 			v := rt.Owner.Consts[int32(code[i])]
@@ -1209,12 +1466,19 @@ main:
 			id := int32(code[i])
 			if id == 0 {
 				top.Any--
-				id = rt.SAny[top.Any].(*Fn).Func
+				pfn := rt.SAny[top.Any].(*Fn)
+				id = pfn.Func
 				if id == 0 {
 					errHandle(i, ErrFnEmpty)
 					continue main
 					//return nil, runtimeError(rt, i, ErrFnEmpty)
 				}
+				// captured values are passed after the parameters
+				if !pushCaptures(rt, pfn, &top) {
+					errHandle(i, ErrStackSize)
+					continue main
+				}
+				rt.ParCount += int32(len(pfn.Captures))
 			}
 			rt.Calls = append(rt.Calls, Call{
 				IsFunc:   true,
@@ -1237,7 +1501,13 @@ main:
 			i = int64(rt.Owner.Exec.Funcs[id])
 			continue
 		case core.GOBYID:
-			var pars []int32
+			var (
+				pars   []int32
+				future *core.Future
+			)
+			if code[i]&0x8000 != 0 {
+				future = core.NewFuture()
+			}
 			rt.ParCount = int32(code[i]) >> 16
 			i++
 			id := int32(code[i])
@@ -1249,9 +1519,21 @@ main:
 				}
 				rt.ParCount = 0
 			}
-			threadID := rt.GoThread(int64(rt.Owner.Exec.Funcs[id]), pars, &top)
-			rt.SInt[top.Int] = threadID
-			top.Int++
+			if rt.Owner.Settings.Policy != nil {
+				if errPolicy := checkCapability(&rt.Owner.Settings, CapThread,
+					``); errPolicy != nil {
+					errHandle(i, errPolicy)
+					continue
+				}
+			}
+			threadID := rt.GoThread(int64(rt.Owner.Exec.Funcs[id]), pars, &top, future)
+			if future != nil {
+				rt.SAny[top.Any] = future
+				top.Any++
+			} else {
+				rt.SInt[top.Int] = threadID
+				top.Int++
+			}
 		case core.EMBED:
 			var (
 				vCount int
@@ -1263,6 +1545,68 @@ main:
 				i++
 				vCount = int(code[i])
 			}
+			if int(idEmbed) < len(embedStubs) && embedStubs[idEmbed] != nil {
+				var vars []interface{}
+				if vCount > 0 {
+					vars = make([]interface{}, vCount)
+					for j := vCount - 1; j >= 0; j-- {
+						switch code[i+int64(j)+1] & 0xf {
+						case core.STACKFLOAT:
+							top.Float--
+							vars[j] = rt.SFloat[top.Float]
+						case core.STACKSTR:
+							top.Str--
+							vars[j] = rt.SStr[top.Str]
+						case core.STACKANY:
+							top.Any--
+							vars[j] = rt.SAny[top.Any]
+						default:
+							top.Int--
+							vars[j] = rt.SInt[top.Int]
+						}
+					}
+					i += int64(vCount)
+				}
+				if rt.Owner.Settings.Policy != nil {
+					if errPolicy := checkPolicy(rt, top, idEmbed); errPolicy != nil {
+						errHandle(i, errPolicy)
+						continue
+					}
+				}
+				if rt.Owner.Settings.DryRun && int(idEmbed) < len(embedEffects) &&
+					embedEffects[idEmbed] != nil && dryRun(rt, &top, i, idEmbed) {
+					break
+				}
+				var memBefore int64
+				if rt.Owner.Settings.MemoryLimit > 0 {
+					memBefore = stackMemory(rt, top, embed.Params)
+				}
+				var audit *AuditEvent
+				if rt.Owner.Settings.AuditHandle != nil && int(idEmbed) < len(embedEffects) &&
+					embedEffects[idEmbed] != nil {
+					audit = startAudit(rt, top, idEmbed)
+				}
+				errEmbed := embedStubs[idEmbed](rt, &top, vars)
+				if audit != nil {
+					finishAudit(rt, audit, i, errEmbed)
+				}
+				if errEmbed != nil {
+					if errMain, isMain := errEmbed.(errThread); isMain {
+						return nil, errMain.error
+					}
+					errHandle(i, errEmbed)
+					continue
+				}
+				if rt.Owner.Settings.MemoryLimit > 0 {
+					errMem := allocMemory(rt, stackMemory(rt, top, embedResults(embed))-memBefore)
+					if errMem != nil {
+						errHandle(i, errMem)
+						continue
+					}
+				}
+				break
+			}
+			// custom functions are called with reflect
 			pars := make([]reflect.Value, count+vCount)
 			if vCount > 0 {
 				for j := vCount - 1; j >= 0; j-- {
@@ -1283,6 +1627,16 @@ main:
 				}
 				i += int64(vCount)
 			}
+			if rt.Owner.Settings.Policy != nil {
+				if errPolicy := checkPolicy(rt, top, idEmbed); errPolicy != nil {
+					errHandle(i, errPolicy)
+					continue
+				}
+			}
+			var memBefore int64
+			if rt.Owner.Settings.MemoryLimit > 0 {
+				memBefore = stackMemory(rt, top, embed.Params)
+			}
 			for i := count - 1; i >= 0; i-- {
 				switch embed.Params[i] & 0xf {
 				case core.STACKFLOAT:
@@ -1306,11 +1660,30 @@ main:
 			if len(result) > 0 {
 				last := result[len(result)-1].Interface()
 				if last != nil {
+					if errMain, isMain := last.(errThread); isMain {
+						return nil, errMain.error
+					}
 					if _, isError := last.(error); isError {
 						errHandle(i, result[len(result)-1].Interface().(error))
 						continue
 					}
 				}
+				for j, rtype := range embed.Returns {
+					switch rtype & 0xf {
+					case core.STACKFLOAT:
+						rt.SFloat[top.Float] = result[j].Interface().(float64)
+						top.Float++
+					case core.STACKSTR:
+						rt.SStr[top.Str] = result[j].Interface().(string)
+						top.Str++
+					case core.STACKANY:
+						rt.SAny[top.Any] = result[j].Interface()
+						top.Any++
+					default:
+						rt.SInt[top.Int] = result[j].Interface().(int64)
+						top.Int++
+					}
+				}
 				switch embed.Return & 0xf {
 				case core.STACKNONE:
 				case core.STACKFLOAT:
@@ -1327,6 +1700,13 @@ main:
 					top.Int++
 				}
 			}
+			if rt.Owner.Settings.MemoryLimit > 0 {
+				errMem := allocMemory(rt, stackMemory(rt, top, embedResults(embed))-memBefore)
+				if errMem != nil {
+					errHandle(i, errMem)
+					continue
+				}
+			}
 		case core.LOCAL:
 			rt.ParCount = int32(code[i]) >> 16
 			i++
@@ -1349,21 +1729,119 @@ main:
 			}
 			i += int64(shift)
 			continue
+		case core.DEFER:
+			top.Any--
+			k := len(rt.Calls) - 1
+			for ; k > 0; k-- {
+				if rt.Calls[k].IsFunc || rt.Calls[k].IsLocal {
+					break
+				}
+			}
+			rt.Defers = append(rt.Defers, Defer{Level: k, Fn: rt.SAny[top.Any].(*Fn)})
+			rt.SAny[top.Any] = nil
+		case core.FINALLY:
+			i++
+			rt.Defers = append(rt.Defers, Defer{Level: len(rt.Calls) - 1,
+				Offset: i + int64(int32(code[i]))})
 		case core.CATCH: // Error throw
 			errHandle(-1, err)
 			continue main
+		case core.IFACE:
+			var id int32
+			count := int64(code[i+1])
+			iface := &rt.Owner.Exec.Structs[(code[i+2]-core.TYPESTRUCT)>>8]
+			pstruct, ok := rt.SAny[top.Any-1-int32(code[i]>>16)].(*Struct)
+			if !ok || pstruct.Type == iface {
+				errHandle(i, ErrIfaceEmpty, iface.Name)
+				continue
+			}
+			for j := int64(0); j < count; j++ {
+				if &rt.Owner.Exec.Structs[(code[i+3+j*2]-core.TYPESTRUCT)>>8] == pstruct.Type {
+					id = int32(code[i+4+j*2])
+					break
+				}
+			}
+			if id == 0 {
+				errHandle(i, ErrIface, pstruct.Type.Name)
+				continue
+			}
+			i += 2 + count*2
+			rt.SAny[top.Any] = &Fn{Func: id}
+			top.Any++
+		case core.ENUMSTR:
+			count := int32(code[i] >> 16)
+			top.Int--
+			top.Str -= count
+			val := rt.SInt[top.Int]
+			if val < 0 || val >= int64(count) {
+				errHandle(i, ErrEnum, val)
+				continue
+			}
+			rt.SStr[top.Str] = rt.SStr[top.Str+int32(val)]
+			top.Str++
+		case core.ENUMVAL:
+			count := int32(code[i] >> 16)
+			top.Str--
+			name := rt.SStr[top.Str]
+			top.Str -= count
+			val := int64(-1)
+			for j := int32(0); j < count; j++ {
+				if rt.SStr[top.Str+j] == name {
+					val = int64(j)
+					break
+				}
+			}
+			if val < 0 {
+				errHandle(i, ErrEnum, name)
+				continue
+			}
+			rt.SInt[top.Int] = val
+			top.Int++
+		case core.ENUMINT:
+			if val := rt.SInt[top.Int-1]; val < 0 || val >= int64(code[i]>>16) {
+				errHandle(i, ErrEnum, val)
+				continue
+			}
+		case core.CATCHID:
+			count := int(code[i] >> 16)
+			id := int64(err.(*RuntimeError).ID)
+			isCatch := false
+			for ; count > 0; count-- {
+				top.Int -= 2
+				if id >= rt.SInt[top.Int] && id <= rt.SInt[top.Int+1] {
+					isCatch = true
+				}
+			}
+			if !isCatch {
+				errHandle(-1, err)
+				continue main
+			}
+		case core.GLOBAL:
+			rt.Owner.GlobalMutex.Lock()
+			rt.Owner.Globals[int32(code[i+1])] = newGlobal(rt, int(code[i+2]))
+			rt.Owner.GlobalMutex.Unlock()
+			i += 2
+		case core.INCVAR:
+			root := int64(rt.Calls[rt.blockCall(int(code[i])>>16)].Int) + int64(code[i+1]&0xffff)
+			rt.SInt[root] += int64(code[i+2])
+			rt.SInt[top.Int] = rt.SInt[root]
+			top.Int++
+			i += 2
 		case core.IOTA:
 			rt.Owner.Consts[rt.Owner.Exec.Init[0]] = Const{
 				Type:  core.TYPEINT,
 				Value: int64((int32(code[i]) >> 16) - 1),
 			}
 		}
+		if isGlobal {
+			unlockGlobal()
+		}
 		i++
 		/*		if i&0x8 != 0x8 {
 				continue
 			}*/
 		step := SleepStep
-		check := len(rt.Owner.Runtimes) > 1
+		check := len(rt.Owner.Threads) > 1
 		for check || rt.Thread.Status == ThPaused || rt.Thread.Status == ThWait ||
 			rt.Thread.Sleep > 0 || rt.Owner.Stopped {
 			if rt.Owner.Stopped {
@@ -1442,9 +1920,37 @@ main:
 			check = false
 		}
 	}
+	if isGlobal {
+		unlockGlobal()
+	}
 	return
 }
 
+// pushCaptures pushes the captured values of the function literal. It returns false if
+// the stacks exceed the limit of their size.
+func pushCaptures(rt *Runtime, pfn *Fn, top *Call) bool {
+	if !rt.growStacks(top, int32(len(pfn.Captures))) {
+		return false
+	}
+	for _, capture := range pfn.Captures {
+		switch v := capture.(type) {
+		case int64:
+			rt.SInt[top.Int] = v
+			top.Int++
+		case float64:
+			rt.SFloat[top.Float] = v
+			top.Float++
+		case string:
+			rt.SStr[top.Str] = v
+			top.Str++
+		default:
+			rt.SAny[top.Any] = v
+			top.Any++
+		}
+	}
+	return true
+}
+
 // exit terminates the script execution
 func exit(rt *Runtime, code int64) error {
 	return &RuntimeError{
@@ -1452,3 +1958,52 @@ func exit(rt *Runtime, code int64) error {
 		Message: ErrorText(ErrExit),
 	}
 }
+
+// growStack returns the stack which has at least size items. The size of the stack is
+// doubled but it doesn't exceed limit + stackReserve.
+func growStack[T any](stack []T, size, limit int32) []T {
+	if size <= int32(len(stack)) {
+		return stack
+	}
+	newSize := max(2*int32(len(stack)), STACKSIZE, size)
+	if newSize > limit+stackReserve && size <= limit+stackReserve {
+		newSize = limit + stackReserve
+	}
+	ret := make([]T, newSize)
+	copy(ret, stack)
+	return ret
+}
+
+// growStacks makes sure that each typed stack has count free items after top.
+// It returns false if any stack would exceed the limit of its size.
+func (rt *Runtime) growStacks(top *Call, count int32) bool {
+	limit := int32(STACKMAX)
+	if size := rt.Owner.Settings.StackSize; size > 0 && size < STACKMAX {
+		limit = int32(size)
+	}
+	// the stacks can have stackReserve items over the limit
+	last := limit + stackReserve - count
+	if top.Int > last || top.Float > last || top.Str > last || top.Any > last {
+		return false
+	}
+	rt.SInt = growStack(rt.SInt, top.Int+count, limit)
+	rt.SFloat = growStack(rt.SFloat, top.Float+count, limit)
+	rt.SStr = growStack(rt.SStr, top.Str+count, limit)
+	rt.SAny = growStack(rt.SAny, top.Any+count, limit)
+	return true
+}
+
+// blockCall returns the index of the call with the variables of the block by the block shift
+// of GETVAR or SETVAR command
+func (rt *Runtime) blockCall(shift int) int {
+	if shift < 0x0f00 {
+		return len(rt.Calls) - 1 - shift
+	}
+	base := len(rt.Calls) - 1
+	for ; base > 0; base-- {
+		if rt.Calls[base].IsFunc {
+			break
+		}
+	}
+	return base + shift - 0x0f00
+}
@@ -5,8 +5,6 @@
 package vm
 
 import (
-	"fmt"
-
 	"github.com/gentee/gentee/core"
 )
 
@@ -76,7 +74,7 @@ func bitSet(left *core.Set, right *core.Set, and bool) *core.Set {
 
 func checkIndex(set *core.Set, index int64) error {
 	if index < 0 || index >= core.MaxSet {
-		return fmt.Errorf(ErrorText(ErrIndexOut))
+		return newError(ErrIndexOut)
 	}
 	return nil
 }
@@ -122,7 +120,7 @@ func setºStr(value string) (*core.Set, error) {
 		case '1':
 			s.Set(int64(i), true)
 		default:
-			return nil, fmt.Errorf(ErrorText(ErrInvalidParam))
+			return nil, newError(ErrInvalidParam)
 		}
 	}
 	return s, nil
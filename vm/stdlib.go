@@ -1,6 +1,6 @@
 // Code generated by go generate; DO NOT EDIT.
 // This file was generated by github.com/gentee/gentee/vm/generate/generate.go at
-// 2021/12/10 20:56:22 +05
+// 2026/10/19 18:51:08 UTC
 
 package vm
 
@@ -17,15 +17,19 @@ var EmbedFuncs = []core.Embed{
 		Func: AbsPath, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "Add", Pars: "buf,buf", Ret: "buf", Code: 2, 
+	{Name: "Acquire", Pars: "semaphore", Ret: "", Code: 2, 
+		Func: AcquireºSemaphore, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPESYNC}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "Add", Pars: "buf,buf", Ret: "buf", Code: 3, 
 		Func: AddºBufBuf, Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPEBUF,core.TYPEBUF}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Add", Pars: "char,char", Ret: "str", Code: 3, 
+	{Name: "Add", Pars: "char,char", Ret: "str", Code: 4, 
 		Func: AddºCharChar, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPECHAR,core.TYPECHAR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Add", Pars: "char,str", Ret: "str", Code: 4, 
+	{Name: "Add", Pars: "char,str", Ret: "str", Code: 5, 
 		Func: AddºCharStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPECHAR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -33,11 +37,11 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPEFLOAT,core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Add", Pars: "float,int", Ret: "float", Code: 6, 
+	{Name: "Add", Pars: "float,int", Ret: "float", Code: 7, 
 		Func: AddºFloatInt, Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPEFLOAT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Add", Pars: "int,float", Ret: "float", Code: 7, 
+	{Name: "Add", Pars: "int,float", Ret: "float", Code: 8, 
 		Func: AddºIntFloat, Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPEINT,core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -45,7 +49,7 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Add", Pars: "str,char", Ret: "str", Code: 9, 
+	{Name: "Add", Pars: "str,char", Ret: "str", Code: 10, 
 		Func: AddºStrChar, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPECHAR}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -53,67 +57,71 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AddHours", Pars: "time,int", Ret: "time", Code: 11, 
+	{Name: "AddHours", Pars: "time,int", Ret: "time", Code: 12, 
 		Func: AddHoursºTimeInt, Return: core.TYPESTRUCT, 
 		Params: []uint16{core.TYPESTRUCT,core.TYPEINT}, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "AESDecrypt", Pars: "str,buf", Ret: "buf", Code: 12, 
+	{Name: "After", Pars: "int", Ret: "chan.int", Code: 13, 
+		Func: AfterºInt, Return: core.TYPECHAN, 
+		Params: []uint16{core.TYPEINT}, 
+		Variadic: false, Runtime: false, CanError: false},
+	{Name: "AESDecrypt", Pars: "str,buf", Ret: "buf", Code: 14, 
 		Func: AESDecryptBuf, Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPESTR,core.TYPEBUF}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "AESEncrypt", Pars: "str,buf", Ret: "buf", Code: 13, 
+	{Name: "AESEncrypt", Pars: "str,buf", Ret: "buf", Code: 15, 
 		Func: AESEncryptBuf, Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPESTR,core.TYPEBUF}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "AppendFile", Pars: "str,buf", Ret: "", Code: 14, 
+	{Name: "AppendFile", Pars: "str,buf", Ret: "", Code: 16, 
 		Func: AppendFileºStrBuf, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPESTR,core.TYPEBUF}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "AppendFile", Pars: "str,str", Ret: "", Code: 15, 
+	{Name: "AppendFile", Pars: "str,str", Ret: "", Code: 17, 
 		Func: AppendFileºStrStr, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "Arg", Pars: "str", Ret: "str", Code: 16, 
+	{Name: "Arg", Pars: "str", Ret: "str", Code: 18, 
 		Func: ArgºStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "Arg", Pars: "str,int", Ret: "int", Code: 17, 
+	{Name: "Arg", Pars: "str,int", Ret: "int", Code: 19, 
 		Func: ArgºStrInt, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPESTR,core.TYPEINT}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "Arg", Pars: "str,str", Ret: "str", Code: 18, 
+	{Name: "Arg", Pars: "str,str", Ret: "str", Code: 20, 
 		Func: ArgºStrStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "ArgCount", Pars: "", Ret: "int", Code: 19, 
+	{Name: "ArgCount", Pars: "", Ret: "int", Code: 21, 
 		Func: ArgCount, Return: core.TYPEINT, 
 		Params: nil, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "Args", Pars: "", Ret: "arr.str", Code: 20, 
+	{Name: "Args", Pars: "", Ret: "arr.str", Code: 22, 
 		Func: Args, Return: core.TYPEARR, 
 		Params: nil, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "Args", Pars: "str", Ret: "arr.str", Code: 21, 
+	{Name: "Args", Pars: "str", Ret: "arr.str", Code: 23, 
 		Func: ArgsºStr, Return: core.TYPEARR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "ArgsTail", Pars: "", Ret: "arr.str", Code: 22, 
+	{Name: "ArgsTail", Pars: "", Ret: "arr.str", Code: 24, 
 		Func: ArgsTail, Return: core.TYPEARR, 
 		Params: nil, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "ArchiveName", Pars: "finfo,str", Ret: "str", Code: 23, 
+	{Name: "ArchiveName", Pars: "finfo,str", Ret: "str", Code: 25, 
 		Func: ArchiveName, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTRUCT,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "arr", Pars: "obj", Ret: "arr.obj", Code: 24, 
+	{Name: "arr", Pars: "obj", Ret: "arr.obj", Code: 26, 
 		Func: arrºObj, Return: core.TYPEARR, 
 		Params: []uint16{core.TYPEOBJ}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "arr", Pars: "set", Ret: "arr.int", Code: 25, 
+	{Name: "arr", Pars: "set", Ret: "arr.int", Code: 27, 
 		Func: arrºSet, Return: core.TYPEARR, 
 		Params: []uint16{core.TYPESET}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "arrstr", Pars: "obj", Ret: "arr.str", Code: 26, 
+	{Name: "arrstr", Pars: "obj", Ret: "arr.str", Code: 28, 
 		Func: arrstrºObj, Return: core.TYPEARR, 
 		Params: []uint16{core.TYPEOBJ}, 
 		Variadic: false, Runtime: false, CanError: true},
@@ -141,23 +149,23 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Assign", Pars: "obj,arr*", Ret: "obj", Code: 33, 
+	{Name: "Assign", Pars: "obj,arr*", Ret: "obj", Code: 35, 
 		Func: core.AssignAnyFunc(AssignºObjAny), Return: core.TYPEOBJ, 
 		Params: []uint16{core.TYPEOBJ,core.TYPESTRUCT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Assign", Pars: "obj,bool", Ret: "obj", Code: 34, 
+	{Name: "Assign", Pars: "obj,bool", Ret: "obj", Code: 36, 
 		Func: core.AssignAnyFunc(AssignºObjBool), Return: core.TYPEOBJ, 
 		Params: []uint16{core.TYPEOBJ,core.TYPEBOOL}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Assign", Pars: "obj,float", Ret: "obj", Code: 35, 
+	{Name: "Assign", Pars: "obj,float", Ret: "obj", Code: 37, 
 		Func: core.AssignAnyFunc(AssignºObjAny), Return: core.TYPEOBJ, 
 		Params: []uint16{core.TYPEOBJ,core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Assign", Pars: "obj,int", Ret: "obj", Code: 36, 
+	{Name: "Assign", Pars: "obj,int", Ret: "obj", Code: 38, 
 		Func: core.AssignAnyFunc(AssignºObjAny), Return: core.TYPEOBJ, 
 		Params: []uint16{core.TYPEOBJ,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Assign", Pars: "obj,map*", Ret: "obj", Code: 37, 
+	{Name: "Assign", Pars: "obj,map*", Ret: "obj", Code: 39, 
 		Func: core.AssignAnyFunc(AssignºObjAny), Return: core.TYPEOBJ, 
 		Params: []uint16{core.TYPEOBJ,core.TYPESTRUCT}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -165,7 +173,7 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEOBJ, 
 		Params: []uint16{core.TYPEOBJ,core.TYPEOBJ}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Assign", Pars: "obj,str", Ret: "obj", Code: 39, 
+	{Name: "Assign", Pars: "obj,str", Ret: "obj", Code: 41, 
 		Func: core.AssignAnyFunc(AssignºObjAny), Return: core.TYPEOBJ, 
 		Params: []uint16{core.TYPEOBJ,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -173,11 +181,11 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPESET, 
 		Params: []uint16{core.TYPESET,core.TYPESET}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Assign", Pars: "str,bool", Ret: "str", Code: 41, 
+	{Name: "Assign", Pars: "str,bool", Ret: "str", Code: 43, 
 		Func: core.AssignStrFunc(AssignºStrBool), Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPEBOOL}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Assign", Pars: "str,int", Ret: "str", Code: 42, 
+	{Name: "Assign", Pars: "str,int", Ret: "str", Code: 44, 
 		Func: core.AssignStrFunc(AssignºStrInt), Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -189,6 +197,10 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPESTRUCT, 
 		Params: []uint16{core.TYPESTRUCT,core.TYPESTRUCT}, 
 		Variadic: false, Runtime: false, CanError: false},
+	{Name: "AssignºChanChan", Pars: "chan*,chan*", Ret: "chan*", Code: core.ASSIGN, 
+		Func: nil, Return: core.TYPECHAN, 
+		Params: []uint16{core.TYPECHAN,core.TYPECHAN}, 
+		Variadic: false, Runtime: false, CanError: false},
 	{Name: "AssignºFileFile", Pars: "file,file", Ret: "file", Code: core.ASSIGN, 
 		Func: nil, Return: core.TYPEFILE, 
 		Params: []uint16{core.TYPEFILE,core.TYPEFILE}, 
@@ -197,6 +209,10 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEFUNC, 
 		Params: []uint16{core.TYPEFUNC,core.TYPEFUNC}, 
 		Variadic: false, Runtime: false, CanError: false},
+	{Name: "AssignºFutureFuture", Pars: "future*,future*", Ret: "future*", Code: core.ASSIGN, 
+		Func: nil, Return: core.TYPEFUTURE, 
+		Params: []uint16{core.TYPEFUTURE,core.TYPEFUTURE}, 
+		Variadic: false, Runtime: false, CanError: false},
 	{Name: "AssignºHandleHandle", Pars: "handle,handle", Ret: "handle", Code: core.ASSIGN, 
 		Func: nil, Return: core.TYPEHANDLE, 
 		Params: []uint16{core.TYPEHANDLE,core.TYPEHANDLE}, 
@@ -213,83 +229,99 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignAddºArr", Pars: "arr*,arr*", Ret: "arr*", Code: 51, 
+	{Name: "Assign", Pars: "mutex,mutex", Ret: "mutex", Code: core.ASSIGN, 
+		Func: nil, Return: core.TYPESYNC, 
+		Params: []uint16{core.TYPESYNC,core.TYPESYNC}, 
+		Variadic: false, Runtime: false, CanError: false},
+	{Name: "Assign", Pars: "rwmutex,rwmutex", Ret: "rwmutex", Code: core.ASSIGN, 
+		Func: nil, Return: core.TYPESYNC, 
+		Params: []uint16{core.TYPESYNC,core.TYPESYNC}, 
+		Variadic: false, Runtime: false, CanError: false},
+	{Name: "Assign", Pars: "semaphore,semaphore", Ret: "semaphore", Code: core.ASSIGN, 
+		Func: nil, Return: core.TYPESYNC, 
+		Params: []uint16{core.TYPESYNC,core.TYPESYNC}, 
+		Variadic: false, Runtime: false, CanError: false},
+	{Name: "AssignAddºArr", Pars: "arr*,arr*", Ret: "arr*", Code: 58, 
 		Func: core.AssignAnyFunc(AssignAddºArr), Return: core.TYPESTRUCT, 
 		Params: []uint16{core.TYPESTRUCT,core.TYPESTRUCT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "AssignAdd", Pars: "arr.bool,bool", Ret: "arr.bool", Code: 52, 
+	{Name: "AssignAdd", Pars: "arr.bool,bool", Ret: "arr.bool", Code: 59, 
 		Func: core.AssignAnyFunc(AssignAddºArrAny), Return: core.TYPEARR, 
 		Params: []uint16{core.TYPEARR,core.TYPEBOOL}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignAdd", Pars: "arr.int,int", Ret: "arr.int", Code: 53, 
+	{Name: "AssignAdd", Pars: "arr.int,int", Ret: "arr.int", Code: 60, 
 		Func: core.AssignAnyFunc(AssignAddºArrAny), Return: core.TYPEARR, 
 		Params: []uint16{core.TYPEARR,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignAdd", Pars: "arr.obj,obj", Ret: "arr.obj", Code: 54, 
+	{Name: "AssignAdd", Pars: "arr.obj,obj", Ret: "arr.obj", Code: 61, 
 		Func: core.AssignAnyFunc(AssignAddºArrAny), Return: core.TYPEARR, 
 		Params: []uint16{core.TYPEARR,core.TYPEOBJ}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignAdd", Pars: "arr.thread,thread", Ret: "arr.thread", Code: 55, 
+	{Name: "AssignAdd", Pars: "arr.thread,thread", Ret: "arr.thread", Code: 62, 
 		Func: core.AssignAnyFunc(AssignAddºArrAny), Return: core.TYPEARR, 
 		Params: []uint16{core.TYPEARR,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignAdd", Pars: "arr.str,str", Ret: "arr.str", Code: 56, 
+	{Name: "AssignAdd", Pars: "arr.str,str", Ret: "arr.str", Code: 63, 
 		Func: core.AssignAnyFunc(AssignAddºArrAny), Return: core.TYPEARR, 
 		Params: []uint16{core.TYPEARR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignAdd", Pars: "buf,buf", Ret: "buf", Code: 57, 
+	{Name: "AssignAdd", Pars: "buf,buf", Ret: "buf", Code: 64, 
 		Func: core.AssignAnyFunc(AssignAddºBufBuf), Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPEBUF,core.TYPEBUF}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignAdd", Pars: "buf,char", Ret: "buf", Code: 58, 
+	{Name: "AssignAdd", Pars: "buf,char", Ret: "buf", Code: 65, 
 		Func: core.AssignAnyFunc(AssignAddºBufChar), Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPEBUF,core.TYPECHAR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignAdd", Pars: "buf,int", Ret: "buf", Code: 59, 
+	{Name: "AssignAdd", Pars: "buf,int", Ret: "buf", Code: 66, 
 		Func: core.AssignAnyFunc(AssignAddºBufInt), Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPEBUF,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "AssignAdd", Pars: "buf,str", Ret: "buf", Code: 60, 
+	{Name: "AssignAdd", Pars: "buf,str", Ret: "buf", Code: 67, 
 		Func: core.AssignAnyFunc(AssignAddºBufStr), Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPEBUF,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignAdd", Pars: "float,float", Ret: "float", Code: 61, 
+	{Name: "AssignAdd", Pars: "float,float", Ret: "float", Code: 68, 
 		Func: core.AssignFloatFunc(AssignAddºFloatFloat), Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPEFLOAT,core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignAdd", Pars: "int,int", Ret: "int", Code: 62, 
+	{Name: "AssignAdd", Pars: "int,int", Ret: "int", Code: 69, 
 		Func: core.AssignIntFunc(AssignAddºIntInt), Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignAdd", Pars: "obj,obj", Ret: "obj", Code: 63, 
+	{Name: "AssignAdd", Pars: "obj,obj", Ret: "obj", Code: 70, 
 		Func: core.AssignAnyFunc(AssignAddºObj), Return: core.TYPEOBJ, 
 		Params: []uint16{core.TYPEOBJ,core.TYPEOBJ}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignAdd", Pars: "set,set", Ret: "set", Code: 64, 
+	{Name: "AssignAdd", Pars: "set,set", Ret: "set", Code: 71, 
 		Func: core.AssignAnyFunc(AssignAddºSetSet), Return: core.TYPESET, 
 		Params: []uint16{core.TYPESET,core.TYPESET}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignAdd", Pars: "str,char", Ret: "str", Code: 65, 
+	{Name: "AssignAdd", Pars: "str,char", Ret: "str", Code: 72, 
 		Func: core.AssignStrFunc(AssignAddºStrChar), Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPECHAR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignAdd", Pars: "str,str", Ret: "str", Code: 66, 
+	{Name: "AssignAdd", Pars: "str,str", Ret: "str", Code: 73, 
 		Func: core.AssignStrFunc(AssignAddºStrStr), Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignAddºArrArr", Pars: "arr.arr*,arr*", Ret: "arr.arr*", Code: 67, 
+	{Name: "AssignAddºArrArr", Pars: "arr.arr*,arr*", Ret: "arr.arr*", Code: 74, 
 		Func: core.AssignAnyFunc(AssignAddºArrAny), Return: core.TYPEARR, 
 		Params: []uint16{core.TYPEARR,core.TYPESTRUCT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignAddºArrMap", Pars: "arr.map*,map*", Ret: "arr.map*", Code: 68, 
+	{Name: "AssignAddºArrMap", Pars: "arr.map*,map*", Ret: "arr.map*", Code: 75, 
 		Func: core.AssignAnyFunc(AssignAddºArrAny), Return: core.TYPEARR, 
 		Params: []uint16{core.TYPEARR,core.TYPESTRUCT}, 
 		Variadic: false, Runtime: false, CanError: false},
+	{Name: "AssignAddºArrFuture", Pars: "arr.future*,future*", Ret: "arr.future*", Code: 76, 
+		Func: core.AssignAnyFunc(AssignAddºArrAny), Return: core.TYPEARR, 
+		Params: []uint16{core.TYPEARR,core.TYPEFUTURE}, 
+		Variadic: false, Runtime: false, CanError: false},
 	{Name: "AssignBitAnd", Pars: "buf,buf", Ret: "buf", Code: core.ASSIGNPTR, 
 		Func: nil, Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPEBUF,core.TYPEBUF}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignBitAnd", Pars: "int,int", Ret: "int", Code: 70, 
+	{Name: "AssignBitAnd", Pars: "int,int", Ret: "int", Code: 78, 
 		Func: core.AssignIntFunc(AssignBitAndºIntInt), Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -313,55 +345,55 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPESTRUCT, 
 		Params: []uint16{core.TYPESTRUCT,core.TYPESTRUCT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignBitOr", Pars: "int,int", Ret: "int", Code: 76, 
+	{Name: "AssignBitOr", Pars: "int,int", Ret: "int", Code: 84, 
 		Func: core.AssignIntFunc(AssignBitOrºIntInt), Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignBitXor", Pars: "int,int", Ret: "int", Code: 77, 
+	{Name: "AssignBitXor", Pars: "int,int", Ret: "int", Code: 85, 
 		Func: core.AssignIntFunc(AssignBitXorºIntInt), Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignDiv", Pars: "float,float", Ret: "float", Code: 78, 
+	{Name: "AssignDiv", Pars: "float,float", Ret: "float", Code: 86, 
 		Func: core.AssignFloatFunc(AssignDivºFloatFloat), Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPEFLOAT,core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "AssignDiv", Pars: "int,int", Ret: "int", Code: 79, 
+	{Name: "AssignDiv", Pars: "int,int", Ret: "int", Code: 87, 
 		Func: core.AssignIntFunc(AssignDivºIntInt), Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "AssignMod", Pars: "int,int", Ret: "int", Code: 80, 
+	{Name: "AssignMod", Pars: "int,int", Ret: "int", Code: 88, 
 		Func: core.AssignIntFunc(AssignModºIntInt), Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "AssignLShift", Pars: "int,int", Ret: "int", Code: 81, 
+	{Name: "AssignLShift", Pars: "int,int", Ret: "int", Code: 89, 
 		Func: core.AssignIntFunc(AssignLShiftºIntInt), Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "AssignMul", Pars: "float,float", Ret: "float", Code: 82, 
+	{Name: "AssignMul", Pars: "float,float", Ret: "float", Code: 90, 
 		Func: core.AssignFloatFunc(AssignMulºFloatFloat), Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPEFLOAT,core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignMul", Pars: "int,int", Ret: "int", Code: 83, 
+	{Name: "AssignMul", Pars: "int,int", Ret: "int", Code: 91, 
 		Func: core.AssignIntFunc(AssignMulºIntInt), Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignRShift", Pars: "int,int", Ret: "int", Code: 84, 
+	{Name: "AssignRShift", Pars: "int,int", Ret: "int", Code: 92, 
 		Func: core.AssignIntFunc(AssignRShiftºIntInt), Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "AssignSub", Pars: "float,float", Ret: "float", Code: 85, 
+	{Name: "AssignSub", Pars: "float,float", Ret: "float", Code: 93, 
 		Func: core.AssignFloatFunc(AssignSubºFloatFloat), Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPEFLOAT,core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "AssignSub", Pars: "int,int", Ret: "int", Code: 86, 
+	{Name: "AssignSub", Pars: "int,int", Ret: "int", Code: 94, 
 		Func: core.AssignIntFunc(AssignSubºIntInt), Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Base64", Pars: "buf", Ret: "str", Code: 87, 
+	{Name: "Base64", Pars: "buf", Ret: "str", Code: 95, 
 		Func: Base64ºBuf, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPEBUF}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "BaseName", Pars: "str", Ret: "str", Code: 88, 
+	{Name: "BaseName", Pars: "str", Ret: "str", Code: 96, 
 		Func: BaseName, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -369,7 +401,7 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "BitAnd", Pars: "set,set", Ret: "set", Code: 90, 
+	{Name: "BitAnd", Pars: "set,set", Ret: "set", Code: 98, 
 		Func: BitAndºSetSet, Return: core.TYPESET, 
 		Params: []uint16{core.TYPESET,core.TYPESET}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -377,7 +409,7 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "BitNot", Pars: "set", Ret: "set", Code: 92, 
+	{Name: "BitNot", Pars: "set", Ret: "set", Code: 100, 
 		Func: BitNotºSet, Return: core.TYPESET, 
 		Params: []uint16{core.TYPESET}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -385,7 +417,7 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "BitOr", Pars: "set,set", Ret: "set", Code: 94, 
+	{Name: "BitOr", Pars: "set,set", Ret: "set", Code: 102, 
 		Func: BitOrºSetSet, Return: core.TYPESET, 
 		Params: []uint16{core.TYPESET,core.TYPESET}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -393,163 +425,171 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "bool", Pars: "arr*", Ret: "bool", Code: 96, 
+	{Name: "bool", Pars: "arr*", Ret: "bool", Code: 104, 
 		Func: boolºArr, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPESTRUCT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "bool", Pars: "buf", Ret: "bool", Code: 97, 
+	{Name: "bool", Pars: "buf", Ret: "bool", Code: 105, 
 		Func: boolºBuf, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPEBUF}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "bool", Pars: "float", Ret: "bool", Code: 98, 
+	{Name: "bool", Pars: "float", Ret: "bool", Code: 106, 
 		Func: boolºFloat, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "bool", Pars: "int", Ret: "bool", Code: 99, 
+	{Name: "bool", Pars: "int", Ret: "bool", Code: 107, 
 		Func: boolºInt, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "bool", Pars: "obj", Ret: "bool", Code: 100, 
+	{Name: "bool", Pars: "obj", Ret: "bool", Code: 108, 
 		Func: boolºObj, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPEOBJ}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "bool", Pars: "obj,bool", Ret: "bool", Code: 101, 
+	{Name: "bool", Pars: "obj,bool", Ret: "bool", Code: 109, 
 		Func: boolºObjDef, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPEOBJ,core.TYPEBOOL}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "bool", Pars: "map*", Ret: "bool", Code: 102, 
+	{Name: "bool", Pars: "map*", Ret: "bool", Code: 110, 
 		Func: boolºMap, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPESTRUCT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "bool", Pars: "str", Ret: "bool", Code: 103, 
+	{Name: "bool", Pars: "str", Ret: "bool", Code: 111, 
 		Func: boolºStr, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "buf", Pars: "str", Ret: "buf", Code: 104, 
+	{Name: "buf", Pars: "str", Ret: "buf", Code: 112, 
 		Func: bufºStr, Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Ceil", Pars: "float", Ret: "int", Code: 105, 
+	{Name: "Cap", Pars: "chan*", Ret: "int", Code: 113, 
+		Func: CapºChan, Return: core.TYPEINT, 
+		Params: []uint16{core.TYPECHAN}, 
+		Variadic: false, Runtime: false, CanError: false},
+	{Name: "Ceil", Pars: "float", Ret: "int", Code: 114, 
 		Func: CeilºFloat, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "ChDir", Pars: "str", Ret: "", Code: 106, 
+	{Name: "ChDir", Pars: "str", Ret: "", Code: 115, 
 		Func: ChDirºStr, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "ChMode", Pars: "str,int", Ret: "", Code: 107, 
+	{Name: "ChMode", Pars: "str,int", Ret: "", Code: 116, 
 		Func: ChModeºStr, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPESTR,core.TYPEINT}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "ClearCarriage", Pars: "str", Ret: "str", Code: 108, 
+	{Name: "ClearCarriage", Pars: "str", Ret: "str", Code: 117, 
 		Func: ClearCarriage, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "CloseFile", Pars: "file", Ret: "", Code: 109, 
+	{Name: "close", Pars: "chan*", Ret: "", Code: 118, 
+		Func: closeºChan, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPECHAN}, 
+		Variadic: false, Runtime: false, CanError: true},
+	{Name: "CloseFile", Pars: "file", Ret: "", Code: 119, 
 		Func: CloseFile, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPEFILE}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "CloseTarGz", Pars: "handle", Ret: "", Code: 110, 
+	{Name: "CloseTarGz", Pars: "handle", Ret: "", Code: 120, 
 		Func: CloseTarGz, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPEHANDLE}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "CloseZip", Pars: "handle", Ret: "", Code: 111, 
+	{Name: "CloseZip", Pars: "handle", Ret: "", Code: 121, 
 		Func: CloseZip, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPEHANDLE}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "Command", Pars: "str", Ret: "", Code: 112, 
+	{Name: "Command", Pars: "str", Ret: "", Code: 122, 
 		Func: Command, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "CommandOutput", Pars: "str", Ret: "str", Code: 113, 
+	{Name: "CommandOutput", Pars: "str", Ret: "str", Code: 123, 
 		Func: CommandOutput, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "CompressFile", Pars: "handle,str,str", Ret: "", Code: 114, 
+	{Name: "CompressFile", Pars: "handle,str,str", Ret: "", Code: 124, 
 		Func: CompressFile, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPEHANDLE,core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "CopyFile", Pars: "str,str", Ret: "int", Code: 115, 
+	{Name: "CopyFile", Pars: "str,str", Ret: "int", Code: 125, 
 		Func: CopyFileºStrStr, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "CreateDir", Pars: "str", Ret: "", Code: 116, 
+	{Name: "CreateDir", Pars: "str", Ret: "", Code: 126, 
 		Func: CreateDirºStr, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "CreateFile", Pars: "str,bool", Ret: "", Code: 117, 
+	{Name: "CreateFile", Pars: "str,bool", Ret: "", Code: 127, 
 		Func: CreateFileºStrBool, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPESTR,core.TYPEBOOL}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "CreateTarGz", Pars: "str", Ret: "handle", Code: 118, 
+	{Name: "CreateTarGz", Pars: "str", Ret: "handle", Code: 128, 
 		Func: CreateTarGz, Return: core.TYPEHANDLE, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "CreateZip", Pars: "str", Ret: "handle", Code: 119, 
+	{Name: "CreateZip", Pars: "str", Ret: "handle", Code: 129, 
 		Func: CreateZip, Return: core.TYPEHANDLE, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "Ctx", Pars: "str", Ret: "str", Code: 120, 
+	{Name: "Ctx", Pars: "str", Ret: "str", Code: 130, 
 		Func: CtxºStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "CtxGet", Pars: "str", Ret: "str", Code: 121, 
+	{Name: "CtxGet", Pars: "str", Ret: "str", Code: 131, 
 		Func: CtxGetºStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "CtxIs", Pars: "str", Ret: "bool", Code: 122, 
+	{Name: "CtxIs", Pars: "str", Ret: "bool", Code: 132, 
 		Func: CtxIsºStr, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "CtxSet", Pars: "str,bool", Ret: "str", Code: 123, 
+	{Name: "CtxSet", Pars: "str,bool", Ret: "str", Code: 133, 
 		Func: CtxSetºStrBool, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPEBOOL}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "CtxSet", Pars: "str,float", Ret: "str", Code: 124, 
+	{Name: "CtxSet", Pars: "str,float", Ret: "str", Code: 134, 
 		Func: CtxSetºStrFloat, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPEFLOAT}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "CtxSet", Pars: "str,int", Ret: "str", Code: 125, 
+	{Name: "CtxSet", Pars: "str,int", Ret: "str", Code: 135, 
 		Func: CtxSetºStrInt, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPEINT}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "CtxSet", Pars: "str,str", Ret: "str", Code: 126, 
+	{Name: "CtxSet", Pars: "str,str", Ret: "str", Code: 136, 
 		Func: CtxSetºStrStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "CtxValue", Pars: "str", Ret: "str", Code: 127, 
+	{Name: "CtxValue", Pars: "str", Ret: "str", Code: 137, 
 		Func: CtxValueºStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "Date", Pars: "int,int,int", Ret: "time", Code: 128, 
+	{Name: "Date", Pars: "int,int,int", Ret: "time", Code: 138, 
 		Func: DateºInts, Return: core.TYPESTRUCT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "DateTime", Pars: "int,int,int,int,int,int", Ret: "time", Code: 129, 
+	{Name: "DateTime", Pars: "int,int,int,int,int,int", Ret: "time", Code: 139, 
 		Func: DateTimeºInts, Return: core.TYPESTRUCT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT,core.TYPEINT,core.TYPEINT,core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "Days", Pars: "time", Ret: "int", Code: 130, 
+	{Name: "Days", Pars: "time", Ret: "int", Code: 140, 
 		Func: DaysºTime, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPESTRUCT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "DecodeInt", Pars: "buf,int", Ret: "int", Code: 131, 
+	{Name: "DecodeInt", Pars: "buf,int", Ret: "int", Code: 141, 
 		Func: DecodeºBufInt, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEBUF,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "Del", Pars: "buf,int,int", Ret: "buf", Code: 132, 
+	{Name: "Del", Pars: "buf,int,int", Ret: "buf", Code: 142, 
 		Func: DelºBufIntInt, Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPEBUF,core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "DelAuto", Pars: "map*,str", Ret: "map*", Code: 133, 
+	{Name: "DelAuto", Pars: "map*,str", Ret: "map*", Code: 143, 
 		Func: DelºMapStr, Return: core.TYPESTRUCT, 
 		Params: []uint16{core.TYPESTRUCT,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Dir", Pars: "str", Ret: "str", Code: 134, 
+	{Name: "Dir", Pars: "str", Ret: "str", Code: 144, 
 		Func: Dir, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Download", Pars: "str,str", Ret: "int", Code: 135, 
+	{Name: "Download", Pars: "str,str", Ret: "int", Code: 145, 
 		Func: Download, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
@@ -557,11 +597,11 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPEFLOAT,core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "Div", Pars: "float,int", Ret: "float", Code: 137, 
+	{Name: "Div", Pars: "float,int", Ret: "float", Code: 147, 
 		Func: DivºFloatInt, Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPEFLOAT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "Div", Pars: "int,float", Ret: "float", Code: 138, 
+	{Name: "Div", Pars: "int,float", Ret: "float", Code: 148, 
 		Func: DivºIntFloat, Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPEINT,core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: true},
@@ -569,7 +609,7 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "EncodeInt", Pars: "buf,int", Ret: "buf", Code: 140, 
+	{Name: "EncodeInt", Pars: "buf,int", Ret: "buf", Code: 150, 
 		Func: EncodeºBufInt, Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPEBUF,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -581,7 +621,7 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPEFLOAT,core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Equal", Pars: "float,int", Ret: "bool", Code: 143, 
+	{Name: "Equal", Pars: "float,int", Ret: "bool", Code: 153, 
 		Func: EqualºFloatInt, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPEFLOAT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -593,51 +633,71 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Equal", Pars: "time,time", Ret: "bool", Code: 146, 
+	{Name: "Equal", Pars: "time,time", Ret: "bool", Code: 156, 
 		Func: EqualºTimeTime, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPESTRUCT,core.TYPESTRUCT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "ErrID", Pars: "error", Ret: "int", Code: 147, 
+	{Name: "ErrCause", Pars: "error", Ret: "error", Code: 157, 
+		Func: ErrCause, Return: core.TYPEERROR, 
+		Params: []uint16{core.TYPEERROR}, 
+		Variadic: false, Runtime: false, CanError: true},
+	{Name: "ErrID", Pars: "error", Ret: "int", Code: 158, 
 		Func: ErrID, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEERROR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "error", Pars: "int,str", Ret: "", Code: 148, 
+	{Name: "error", Pars: "error,int,str", Ret: "", Code: 159, 
+		Func: errorºErrIntStr, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPEERROR,core.TYPEINT,core.TYPESTR}, 
+		Variadic: false, Runtime: false, CanError: true},
+	{Name: "error", Pars: "int,str", Ret: "", Code: 160, 
 		Func: errorºIntStr, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPEINT,core.TYPESTR}, 
 		Variadic: true, Runtime: false, CanError: true},
-	{Name: "ErrText", Pars: "error", Ret: "str", Code: 149, 
+	{Name: "ErrorPayload", Pars: "int,str,obj", Ret: "", Code: 161, 
+		Func: ErrorPayloadºObj, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPEINT,core.TYPESTR,core.TYPEOBJ}, 
+		Variadic: false, Runtime: false, CanError: true},
+	{Name: "ErrorPayload", Pars: "int,str,struct", Ret: "", Code: 162, 
+		Func: ErrorPayloadºStruct, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPEINT,core.TYPESTR,core.TYPESTRUCT}, 
+		Variadic: false, Runtime: false, CanError: true},
+	{Name: "ErrPayload", Pars: "error", Ret: "obj", Code: 163, 
+		Func: ErrPayload, Return: core.TYPEOBJ, 
+		Params: []uint16{core.TYPEERROR}, 
+		Variadic: false, Runtime: false, CanError: true},
+	{Name: "ErrText", Pars: "error", Ret: "str", Code: 164, 
 		Func: ErrText, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPEERROR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "ErrTrace", Pars: "error", Ret: "arr.trace", Code: 150, 
+	{Name: "ErrTrace", Pars: "error", Ret: "arr.trace", Code: 165, 
 		Func: ErrTrace, Return: core.TYPEARR, 
 		Params: []uint16{core.TYPEERROR}, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "ExistFile", Pars: "str", Ret: "bool", Code: 151, 
+	{Name: "ExistFile", Pars: "str", Ret: "bool", Code: 166, 
 		Func: ExistFile, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "exit", Pars: "int", Ret: "", Code: 152, 
+	{Name: "exit", Pars: "int", Ret: "", Code: 167, 
 		Func: exit, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPEINT}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "ExpStr", Pars: "str,bool", Ret: "str", Code: 153, 
+	{Name: "ExpStr", Pars: "str,bool", Ret: "str", Code: 168, 
 		Func: ExpStrºBool, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPEBOOL}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "ExpStr", Pars: "str,char", Ret: "str", Code: 154, 
+	{Name: "ExpStr", Pars: "str,char", Ret: "str", Code: 169, 
 		Func: ExpStrºChar, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPECHAR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "ExpStr", Pars: "str,float", Ret: "str", Code: 155, 
+	{Name: "ExpStr", Pars: "str,float", Ret: "str", Code: 170, 
 		Func: ExpStrºFloat, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "ExpStr", Pars: "str,int", Ret: "str", Code: 156, 
+	{Name: "ExpStr", Pars: "str,int", Ret: "str", Code: 171, 
 		Func: ExpStrºInt, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "ExpStr", Pars: "str,obj", Ret: "str", Code: 157, 
+	{Name: "ExpStr", Pars: "str,obj", Ret: "str", Code: 172, 
 		Func: ExpStrºObj, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPEOBJ}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -645,71 +705,71 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Ext", Pars: "str", Ret: "str", Code: 159, 
+	{Name: "Ext", Pars: "str", Ret: "str", Code: 174, 
 		Func: Ext, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "FileInfo", Pars: "file", Ret: "finfo", Code: 160, 
+	{Name: "FileInfo", Pars: "file", Ret: "finfo", Code: 175, 
 		Func: FileInfoºFile, Return: core.TYPESTRUCT, 
 		Params: []uint16{core.TYPEFILE}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "FileInfo", Pars: "str", Ret: "finfo", Code: 161, 
+	{Name: "FileInfo", Pars: "str", Ret: "finfo", Code: 176, 
 		Func: FileInfoºStr, Return: core.TYPESTRUCT, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "FileMode", Pars: "str", Ret: "int", Code: 162, 
+	{Name: "FileMode", Pars: "str", Ret: "int", Code: 177, 
 		Func: FileModeºStr, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "Find", Pars: "str,str", Ret: "int", Code: 163, 
+	{Name: "Find", Pars: "str,str", Ret: "int", Code: 178, 
 		Func: FindºStrStr, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "FindFirstRegExp", Pars: "str,str", Ret: "arr.str", Code: 164, 
+	{Name: "FindFirstRegExp", Pars: "str,str", Ret: "arr.str", Code: 179, 
 		Func: FindFirstRegExpºStrStr, Return: core.TYPEARR, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "FindRegExp", Pars: "str,str", Ret: "arr.arr.str", Code: 165, 
+	{Name: "FindRegExp", Pars: "str,str", Ret: "arr.arr.str", Code: 180, 
 		Func: FindRegExpºStrStr, Return: core.TYPEARR, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "float", Pars: "int", Ret: "float", Code: 166, 
+	{Name: "float", Pars: "int", Ret: "float", Code: 181, 
 		Func: floatºInt, Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "float", Pars: "obj", Ret: "float", Code: 167, 
+	{Name: "float", Pars: "obj", Ret: "float", Code: 182, 
 		Func: floatºObj, Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPEOBJ}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "float", Pars: "obj,float", Ret: "float", Code: 168, 
+	{Name: "float", Pars: "obj,float", Ret: "float", Code: 183, 
 		Func: floatºObjDef, Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPEOBJ,core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "float", Pars: "str", Ret: "float", Code: 169, 
+	{Name: "float", Pars: "str", Ret: "float", Code: 184, 
 		Func: floatºStr, Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "Floor", Pars: "float", Ret: "int", Code: 170, 
+	{Name: "Floor", Pars: "float", Ret: "int", Code: 185, 
 		Func: FloorºFloat, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Format", Pars: "str", Ret: "str", Code: 171, 
+	{Name: "Format", Pars: "str", Ret: "str", Code: 186, 
 		Func: FormatºStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: true, Runtime: false, CanError: false},
-	{Name: "Format", Pars: "str,time", Ret: "str", Code: 172, 
+	{Name: "Format", Pars: "str,time", Ret: "str", Code: 187, 
 		Func: FormatºTimeStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPESTRUCT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "GetCurDir", Pars: "", Ret: "str", Code: 173, 
+	{Name: "GetCurDir", Pars: "", Ret: "str", Code: 188, 
 		Func: GetCurDir, Return: core.TYPESTR, 
 		Params: nil, 
-		Variadic: false, Runtime: false, CanError: true},
-	{Name: "GetEnv", Pars: "str", Ret: "str", Code: 174, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "GetEnv", Pars: "str", Ret: "str", Code: 189, 
 		Func: GetEnv, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Greater", Pars: "char,char", Ret: "bool", Code: 175, 
+	{Name: "Greater", Pars: "char,char", Ret: "bool", Code: 190, 
 		Func: GreaterºCharChar, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPECHAR,core.TYPECHAR}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -717,7 +777,7 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPEFLOAT,core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Greater", Pars: "float,int", Ret: "bool", Code: 177, 
+	{Name: "Greater", Pars: "float,int", Ret: "bool", Code: 192, 
 		Func: GreaterºFloatInt, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPEFLOAT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -729,55 +789,55 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Greater", Pars: "time,time", Ret: "bool", Code: 180, 
+	{Name: "Greater", Pars: "time,time", Ret: "bool", Code: 195, 
 		Func: GreaterºTimeTime, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPESTRUCT,core.TYPESTRUCT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "HasPrefix", Pars: "str,str", Ret: "bool", Code: 181, 
+	{Name: "HasPrefix", Pars: "str,str", Ret: "bool", Code: 196, 
 		Func: HasPrefixºStrStr, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "HasSuffix", Pars: "str,str", Ret: "bool", Code: 182, 
+	{Name: "HasSuffix", Pars: "str,str", Ret: "bool", Code: 197, 
 		Func: HasSuffixºStrStr, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "HeadInfo", Pars: "str", Ret: "hinfo", Code: 183, 
+	{Name: "HeadInfo", Pars: "str", Ret: "hinfo", Code: 198, 
 		Func: HeadInfo, Return: core.TYPESTRUCT, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "Hex", Pars: "buf", Ret: "str", Code: 184, 
+	{Name: "Hex", Pars: "buf", Ret: "str", Code: 199, 
 		Func: HexºBuf, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPEBUF}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "HTTPGet", Pars: "str", Ret: "buf", Code: 185, 
+	{Name: "HTTPGet", Pars: "str", Ret: "buf", Code: 200, 
 		Func: HTTPGet, Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "HTTPPage", Pars: "str", Ret: "str", Code: 186, 
+	{Name: "HTTPPage", Pars: "str", Ret: "str", Code: 201, 
 		Func: HTTPPage, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "HTTPRequest", Pars: "str,str,map.str,map.str", Ret: "str", Code: 187, 
+	{Name: "HTTPRequest", Pars: "str,str,map.str,map.str", Ret: "str", Code: 202, 
 		Func: HTTPRequest, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR,core.TYPEMAP,core.TYPEMAP}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "Join", Pars: "arr.str,str", Ret: "str", Code: 188, 
+	{Name: "Join", Pars: "arr.str,str", Ret: "str", Code: 203, 
 		Func: JoinºArrStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPEARR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "JoinPath", Pars: "", Ret: "str", Code: 189, 
+	{Name: "JoinPath", Pars: "", Ret: "str", Code: 204, 
 		Func: JoinPath, Return: core.TYPESTR, 
 		Params: nil, 
 		Variadic: true, Runtime: false, CanError: false},
-	{Name: "Json", Pars: "obj", Ret: "str", Code: 190, 
+	{Name: "Json", Pars: "obj", Ret: "str", Code: 205, 
 		Func: Json, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPEOBJ}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "JsonToObj", Pars: "str", Ret: "obj", Code: 191, 
+	{Name: "JsonToObj", Pars: "str", Ret: "obj", Code: 206, 
 		Func: JsonToObj, Return: core.TYPEOBJ, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "Insert", Pars: "buf,int,buf", Ret: "buf", Code: 192, 
+	{Name: "Insert", Pars: "buf,int,buf", Ret: "buf", Code: 207, 
 		Func: InsertºBufIntBuf, Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPEBUF,core.TYPEINT,core.TYPEBUF}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -789,63 +849,63 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPECHAR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "int", Pars: "float", Ret: "int", Code: 195, 
+	{Name: "int", Pars: "float", Ret: "int", Code: 210, 
 		Func: intºFloat, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "int", Pars: "obj", Ret: "int", Code: 196, 
+	{Name: "int", Pars: "obj", Ret: "int", Code: 211, 
 		Func: intºObj, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEOBJ}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "int", Pars: "obj,int", Ret: "int", Code: 197, 
+	{Name: "int", Pars: "obj,int", Ret: "int", Code: 212, 
 		Func: intºObjDef, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEOBJ,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "int", Pars: "str", Ret: "int", Code: 198, 
+	{Name: "int", Pars: "str", Ret: "int", Code: 213, 
 		Func: intºStr, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "int", Pars: "time", Ret: "int", Code: 199, 
+	{Name: "int", Pars: "time", Ret: "int", Code: 214, 
 		Func: intºTime, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPESTRUCT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "IsArray", Pars: "obj", Ret: "bool", Code: 200, 
+	{Name: "IsArray", Pars: "obj", Ret: "bool", Code: 215, 
 		Func: IsArrayºObj, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPEOBJ}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "IsArg", Pars: "str", Ret: "bool", Code: 201, 
+	{Name: "IsArg", Pars: "str", Ret: "bool", Code: 216, 
 		Func: IsArgºStr, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "IsEmptyDir", Pars: "str", Ret: "bool", Code: 202, 
+	{Name: "IsEmptyDir", Pars: "str", Ret: "bool", Code: 217, 
 		Func: IsEmptyDir, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "IsKeyAuto", Pars: "map*,str", Ret: "bool", Code: 203, 
+	{Name: "IsKeyAuto", Pars: "map*,str", Ret: "bool", Code: 218, 
 		Func: IsKeyºMapStr, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPESTRUCT,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "IsMap", Pars: "obj", Ret: "bool", Code: 204, 
+	{Name: "IsMap", Pars: "obj", Ret: "bool", Code: 219, 
 		Func: IsMapºObj, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPEOBJ}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "IsNil", Pars: "obj", Ret: "bool", Code: 205, 
+	{Name: "IsNil", Pars: "obj", Ret: "bool", Code: 220, 
 		Func: IsNil, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPEOBJ}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "item", Pars: "obj,int", Ret: "obj", Code: 206, 
+	{Name: "item", Pars: "obj,int", Ret: "obj", Code: 221, 
 		Func: ItemºObjInt, Return: core.TYPEOBJ, 
 		Params: []uint16{core.TYPEOBJ,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "item", Pars: "obj,str", Ret: "obj", Code: 207, 
+	{Name: "item", Pars: "obj,str", Ret: "obj", Code: 222, 
 		Func: ItemºObjStr, Return: core.TYPEOBJ, 
 		Params: []uint16{core.TYPEOBJ,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "KeyAuto", Pars: "map*,int", Ret: "str", Code: 208, 
+	{Name: "KeyAuto", Pars: "map*,int", Ret: "str", Code: 223, 
 		Func: KeyºMapInt, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTRUCT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "Left", Pars: "str,int", Ret: "str", Code: 209, 
+	{Name: "Left", Pars: "str,int", Ret: "str", Code: 224, 
 		Func: LeftºStrInt, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -857,6 +917,10 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEBUF}, 
 		Variadic: false, Runtime: false, CanError: false},
+	{Name: "Len", Pars: "chan*", Ret: "int", Code: 227, 
+		Func: LenºChan, Return: core.TYPEINT, 
+		Params: []uint16{core.TYPECHAN}, 
+		Variadic: false, Runtime: false, CanError: false},
 	{Name: "LenºMap", Pars: "map*", Ret: "int", Code: core.TYPESTRUCT<<16 | core.LEN, 
 		Func: nil, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPESTRUCT}, 
@@ -873,7 +937,7 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Less", Pars: "char,char", Ret: "bool", Code: 216, 
+	{Name: "Less", Pars: "char,char", Ret: "bool", Code: 232, 
 		Func: LessºCharChar, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPECHAR,core.TYPECHAR}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -881,7 +945,7 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPEFLOAT,core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Less", Pars: "float,int", Ret: "bool", Code: 218, 
+	{Name: "Less", Pars: "float,int", Ret: "bool", Code: 234, 
 		Func: LessºFloatInt, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPEFLOAT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -893,19 +957,27 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Less", Pars: "time,time", Ret: "bool", Code: 221, 
+	{Name: "Less", Pars: "time,time", Ret: "bool", Code: 237, 
 		Func: LessºTimeTime, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPESTRUCT,core.TYPESTRUCT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Lines", Pars: "str", Ret: "arr.str", Code: 222, 
+	{Name: "Lines", Pars: "str", Ret: "arr.str", Code: 238, 
 		Func: LinesºStr, Return: core.TYPEARR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Lock", Pars: "", Ret: "", Code: 223, 
+	{Name: "Lock", Pars: "", Ret: "", Code: 239, 
 		Func: Lock, Return: core.TYPENONE, 
 		Params: nil, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "Lower", Pars: "str", Ret: "str", Code: 224, 
+	{Name: "Lock", Pars: "mutex", Ret: "", Code: 240, 
+		Func: LockºMutex, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPESYNC}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "Lock", Pars: "rwmutex", Ret: "", Code: 241, 
+		Func: LockºMutex, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPESYNC}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "Lower", Pars: "str", Ret: "str", Code: 242, 
 		Func: LowerºStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -913,43 +985,43 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "map", Pars: "obj", Ret: "map.obj", Code: 226, 
+	{Name: "map", Pars: "obj", Ret: "map.obj", Code: 244, 
 		Func: mapºObj, Return: core.TYPEMAP, 
 		Params: []uint16{core.TYPEOBJ}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "Match", Pars: "str,str", Ret: "bool", Code: 227, 
+	{Name: "Match", Pars: "str,str", Ret: "bool", Code: 245, 
 		Func: MatchºStrStr, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "MatchPath", Pars: "str,str", Ret: "bool", Code: 228, 
+	{Name: "MatchPath", Pars: "str,str", Ret: "bool", Code: 246, 
 		Func: MatchPath, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "Max", Pars: "float,float", Ret: "float", Code: 229, 
+	{Name: "Max", Pars: "float,float", Ret: "float", Code: 247, 
 		Func: MaxºFloatFloat, Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPEFLOAT,core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Max", Pars: "int,int", Ret: "int", Code: 230, 
+	{Name: "Max", Pars: "int,int", Ret: "int", Code: 248, 
 		Func: MaxºIntInt, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Md5", Pars: "buf", Ret: "buf", Code: 231, 
+	{Name: "Md5", Pars: "buf", Ret: "buf", Code: 249, 
 		Func: Md5ºBuf, Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPEBUF}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Md5", Pars: "str", Ret: "buf", Code: 232, 
+	{Name: "Md5", Pars: "str", Ret: "buf", Code: 250, 
 		Func: Md5ºStr, Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Md5File", Pars: "str", Ret: "str", Code: 233, 
+	{Name: "Md5File", Pars: "str", Ret: "str", Code: 251, 
 		Func: Md5FileºStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "Min", Pars: "float,float", Ret: "float", Code: 234, 
+	{Name: "Min", Pars: "float,float", Ret: "float", Code: 252, 
 		Func: MinºFloatFloat, Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPEFLOAT,core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Min", Pars: "int,int", Ret: "int", Code: 235, 
+	{Name: "Min", Pars: "int,int", Ret: "int", Code: 253, 
 		Func: MinºIntInt, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -961,11 +1033,11 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPEFLOAT,core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Mul", Pars: "float,int", Ret: "float", Code: 238, 
+	{Name: "Mul", Pars: "float,int", Ret: "float", Code: 256, 
 		Func: MulºFloatInt, Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPEFLOAT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Mul", Pars: "int,float", Ret: "float", Code: 239, 
+	{Name: "Mul", Pars: "int,float", Ret: "float", Code: 257, 
 		Func: MulºIntFloat, Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPEINT,core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -977,183 +1049,243 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPESTRUCT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
+	{Name: "NewChan", Pars: "int", Ret: "chan*", Code: 260, 
+		Func: NewChanºInt, Return: core.TYPECHAN, 
+		Params: []uint16{core.TYPEINT}, 
+		Variadic: false, Runtime: false, CanError: true},
+	{Name: "NewSemaphore", Pars: "int", Ret: "semaphore", Code: 261, 
+		Func: NewSemaphoreºInt, Return: core.TYPESYNC, 
+		Params: []uint16{core.TYPEINT}, 
+		Variadic: false, Runtime: false, CanError: true},
 	{Name: "NewRange", Pars: "int,int", Ret: "range", Code: core.RANGE, 
 		Func: nil, Return: core.TYPERANGE, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
+	{Name: "NextºChan", Pars: "chan*", Ret: "bool", Code: 263, 
+		Func: NextºChan, Return: core.TYPEBOOL, 
+		Params: []uint16{core.TYPECHAN}, 
+		Variadic: false, Runtime: true, CanError: true},
 	{Name: "Not", Pars: "bool", Ret: "bool", Code: core.NOT, 
 		Func: nil, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPEBOOL}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Now", Pars: "", Ret: "time", Code: 244, 
+	{Name: "Now", Pars: "", Ret: "time", Code: 265, 
 		Func: Now, Return: core.TYPESTRUCT, 
 		Params: nil, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "obj", Pars: "arr*", Ret: "obj", Code: 245, 
+	{Name: "obj", Pars: "arr*", Ret: "obj", Code: 266, 
 		Func: objºArrMap, Return: core.TYPEOBJ, 
 		Params: []uint16{core.TYPESTRUCT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "obj", Pars: "bool", Ret: "obj", Code: 246, 
+	{Name: "obj", Pars: "bool", Ret: "obj", Code: 267, 
 		Func: objºBool, Return: core.TYPEOBJ, 
 		Params: []uint16{core.TYPEBOOL}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "obj", Pars: "finfo", Ret: "obj", Code: 247, 
+	{Name: "obj", Pars: "finfo", Ret: "obj", Code: 268, 
 		Func: ObjºFinfo, Return: core.TYPEOBJ, 
 		Params: []uint16{core.TYPESTRUCT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "obj", Pars: "float", Ret: "obj", Code: 248, 
+	{Name: "obj", Pars: "float", Ret: "obj", Code: 269, 
 		Func: objºAny, Return: core.TYPEOBJ, 
 		Params: []uint16{core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "obj", Pars: "int", Ret: "obj", Code: 249, 
+	{Name: "obj", Pars: "int", Ret: "obj", Code: 270, 
 		Func: objºAny, Return: core.TYPEOBJ, 
 		Params: []uint16{core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "obj", Pars: "map*", Ret: "obj", Code: 250, 
+	{Name: "obj", Pars: "map*", Ret: "obj", Code: 271, 
 		Func: objºArrMap, Return: core.TYPEOBJ, 
 		Params: []uint16{core.TYPESTRUCT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "obj", Pars: "str", Ret: "obj", Code: 251, 
+	{Name: "obj", Pars: "str", Ret: "obj", Code: 272, 
 		Func: objºAny, Return: core.TYPEOBJ, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Open", Pars: "str", Ret: "", Code: 252, 
+	{Name: "Open", Pars: "str", Ret: "", Code: 273, 
 		Func: OpenºStr, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "OpenFile", Pars: "str,int", Ret: "file", Code: 253, 
+	{Name: "OpenFile", Pars: "str,int", Ret: "file", Code: 274, 
 		Func: OpenFileºStr, Return: core.TYPEFILE, 
 		Params: []uint16{core.TYPESTR,core.TYPEINT}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "OpenWith", Pars: "str,str", Ret: "", Code: 254, 
+	{Name: "OpenWith", Pars: "str,str", Ret: "", Code: 275, 
 		Func: OpenWithºStr, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "Path", Pars: "finfo", Ret: "str", Code: 255, 
+	{Name: "ParallelºFor", Pars: "int,obj,fn", Ret: "", Code: 276, 
+		Func: ParallelºFor, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPEINT,core.TYPEOBJ,core.TYPEFUNC}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "Path", Pars: "finfo", Ret: "str", Code: 277, 
 		Func: FileInfoToPath, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTRUCT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "ParseTime", Pars: "str,str", Ret: "time", Code: 256, 
+	{Name: "ParseTime", Pars: "str,str", Ret: "time", Code: 278, 
 		Func: ParseTimeºStrStr, Return: core.TYPESTRUCT, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "Print", Pars: "", Ret: "int", Code: 257, 
+	{Name: "Print", Pars: "", Ret: "int", Code: 279, 
 		Func: Print, Return: core.TYPEINT, 
 		Params: nil, 
 		Variadic: true, Runtime: false, CanError: true},
-	{Name: "Println", Pars: "", Ret: "int", Code: 258, 
+	{Name: "Println", Pars: "", Ret: "int", Code: 280, 
 		Func: Println, Return: core.TYPEINT, 
 		Params: nil, 
 		Variadic: true, Runtime: false, CanError: true},
-	{Name: "PrintShift", Pars: "str", Ret: "int", Code: 259, 
+	{Name: "PrintShift", Pars: "str", Ret: "int", Code: 281, 
 		Func: PrintShiftºStr, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "Progress", Pars: "int,int", Ret: "", Code: 260, 
+	{Name: "Progress", Pars: "int,int", Ret: "", Code: 282, 
 		Func: ProgressInc, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "ProgressEnd", Pars: "int", Ret: "", Code: 261, 
+	{Name: "ProgressEnd", Pars: "int", Ret: "", Code: 283, 
 		Func: ProgressEnd, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPEINT}, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "ProgressStart", Pars: "int,int,str,str", Ret: "int", Code: 262, 
+	{Name: "ProgressStart", Pars: "int,int,str,str", Ret: "int", Code: 284, 
 		Func: ProgressStart, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT,core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "Random", Pars: "int", Ret: "int", Code: 263, 
+	{Name: "Random", Pars: "int", Ret: "int", Code: 285, 
 		Func: Random, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "RandomBuf", Pars: "int", Ret: "buf", Code: 264, 
+	{Name: "RandomBuf", Pars: "int", Ret: "buf", Code: 286, 
 		Func: RandomBuf, Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPEINT}, 
-		Variadic: false, Runtime: false, CanError: true},
-	{Name: "Read", Pars: "file,int", Ret: "buf", Code: 265, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "Read", Pars: "file,int", Ret: "buf", Code: 287, 
 		Func: ReadºFileInt, Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPEFILE,core.TYPEINT}, 
-		Variadic: false, Runtime: false, CanError: true},
-	{Name: "ReadDir", Pars: "str", Ret: "arr.finfo", Code: 266, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "ReadDir", Pars: "str", Ret: "arr.finfo", Code: 288, 
 		Func: ReadDirºStr, Return: core.TYPEARR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "ReadDir", Pars: "str,int,arr.str,arr.str", Ret: "arr.finfo", Code: 267, 
+	{Name: "ReadDir", Pars: "str,int,arr.str,arr.str", Ret: "arr.finfo", Code: 289, 
 		Func: ReadDirºStrArr, Return: core.TYPEARR, 
 		Params: []uint16{core.TYPESTR,core.TYPEINT,core.TYPEARR,core.TYPEARR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "ReadDir", Pars: "str,int,str", Ret: "arr.finfo", Code: 268, 
+	{Name: "ReadDir", Pars: "str,int,str", Ret: "arr.finfo", Code: 290, 
 		Func: ReadDirºStrIntStr, Return: core.TYPEARR, 
 		Params: []uint16{core.TYPESTR,core.TYPEINT,core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "ReadFile", Pars: "str", Ret: "str", Code: 269, 
+	{Name: "ReadFile", Pars: "str", Ret: "str", Code: 291, 
 		Func: ReadFileºStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "ReadFile", Pars: "str,buf", Ret: "buf", Code: 270, 
+	{Name: "ReadFile", Pars: "str,buf", Ret: "buf", Code: 292, 
 		Func: ReadFileºStrBuf, Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPESTR,core.TYPEBUF}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "ReadFile", Pars: "str,int,int", Ret: "buf", Code: 271, 
+	{Name: "ReadFile", Pars: "str,int,int", Ret: "buf", Code: 293, 
 		Func: ReadFileºStrIntInt, Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPESTR,core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "ReadString", Pars: "str", Ret: "str", Code: 272, 
+	{Name: "ReadString", Pars: "str", Ret: "str", Code: 294, 
 		Func: ReadString, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "ReadTarGz", Pars: "str", Ret: "arr.finfo", Code: 273, 
+	{Name: "ReadTarGz", Pars: "str", Ret: "arr.finfo", Code: 295, 
 		Func: ReadTarGz, Return: core.TYPEARR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "ReadZip", Pars: "str", Ret: "arr.finfo", Code: 274, 
+	{Name: "ReadZip", Pars: "str", Ret: "arr.finfo", Code: 296, 
 		Func: ReadZip, Return: core.TYPEARR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "RegExp", Pars: "str,str", Ret: "str", Code: 275, 
+	{Name: "ReceiveºChanAny", Pars: "chan*", Ret: "obj", Code: 297, 
+		Func: ReceiveºChanAny, Return: core.TYPEOBJ, 
+		Params: []uint16{core.TYPECHAN}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "ReceiveºChanFloat", Pars: "chan*", Ret: "float", Code: 298, 
+		Func: ReceiveºChanFloat, Return: core.TYPEFLOAT, 
+		Params: []uint16{core.TYPECHAN}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "ReceiveºChanInt", Pars: "chan*", Ret: "int", Code: 299, 
+		Func: ReceiveºChanInt, Return: core.TYPEINT, 
+		Params: []uint16{core.TYPECHAN}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "ReceiveºChanStr", Pars: "chan*", Ret: "str", Code: 300, 
+		Func: ReceiveºChanStr, Return: core.TYPESTR, 
+		Params: []uint16{core.TYPECHAN}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "ReceivedºChanAny", Pars: "", Ret: "obj", Code: 301, 
+		Func: ReceivedºChanAny, Return: core.TYPEOBJ, 
+		Params: nil, 
+		Variadic: false, Runtime: true, CanError: false},
+	{Name: "ReceivedºChanFloat", Pars: "", Ret: "float", Code: 302, 
+		Func: ReceivedºChanFloat, Return: core.TYPEFLOAT, 
+		Params: nil, 
+		Variadic: false, Runtime: true, CanError: false},
+	{Name: "ReceivedºChanInt", Pars: "", Ret: "int", Code: 303, 
+		Func: ReceivedºChanInt, Return: core.TYPEINT, 
+		Params: nil, 
+		Variadic: false, Runtime: true, CanError: false},
+	{Name: "ReceivedºChanStr", Pars: "", Ret: "str", Code: 304, 
+		Func: ReceivedºChanStr, Return: core.TYPESTR, 
+		Params: nil, 
+		Variadic: false, Runtime: true, CanError: false},
+	{Name: "RegExp", Pars: "str,str", Ret: "str", Code: 305, 
 		Func: RegExpºStrStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "Remove", Pars: "str", Ret: "", Code: 276, 
+	{Name: "Release", Pars: "semaphore", Ret: "", Code: 306, 
+		Func: ReleaseºSemaphore, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPESYNC}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "Remove", Pars: "str", Ret: "", Code: 307, 
 		Func: RemoveºStr, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "RemoveDir", Pars: "str", Ret: "", Code: 277, 
+	{Name: "RemoveDir", Pars: "str", Ret: "", Code: 308, 
 		Func: RemoveDirºStr, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "Rename", Pars: "str,str", Ret: "", Code: 278, 
+	{Name: "Rename", Pars: "str,str", Ret: "", Code: 309, 
 		Func: RenameºStrStr, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "Repeat", Pars: "str,int", Ret: "str", Code: 279, 
+	{Name: "Repeat", Pars: "str,int", Ret: "str", Code: 310, 
 		Func: RepeatºStrInt, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPEINT}, 
-		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Replace", Pars: "str,str,str", Ret: "str", Code: 280, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "Replace", Pars: "str,str,str", Ret: "str", Code: 311, 
 		Func: ReplaceºStrStrStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "ReplaceRegExp", Pars: "str,str,str", Ret: "str", Code: 281, 
+	{Name: "ReplaceRegExp", Pars: "str,str,str", Ret: "str", Code: 312, 
 		Func: ReplaceRegExpºStrStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "ReverseAuto", Pars: "arr*", Ret: "arr*", Code: 282, 
+	{Name: "ReverseAuto", Pars: "arr*", Ret: "arr*", Code: 313, 
 		Func: ReverseºArr, Return: core.TYPESTRUCT, 
 		Params: []uint16{core.TYPESTRUCT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "resume", Pars: "thread", Ret: "", Code: 283, 
+	{Name: "RLock", Pars: "rwmutex", Ret: "", Code: 314, 
+		Func: RLockºRWMutex, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPESYNC}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "RUnlock", Pars: "rwmutex", Ret: "", Code: 315, 
+		Func: RUnlockºRWMutex, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPESYNC}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "resume", Pars: "thread", Ret: "", Code: 316, 
 		Func: resumeºThread, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPEINT}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "Right", Pars: "str,int", Ret: "str", Code: 284, 
+	{Name: "Right", Pars: "str,int", Ret: "str", Code: 317, 
 		Func: RightºStrInt, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Round", Pars: "float", Ret: "int", Code: 285, 
+	{Name: "Round", Pars: "float", Ret: "int", Code: 318, 
 		Func: RoundºFloat, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Round", Pars: "float,int", Ret: "float", Code: 286, 
+	{Name: "Round", Pars: "float,int", Ret: "float", Code: 319, 
 		Func: RoundºFloatInt, Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPEFLOAT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -1161,59 +1293,79 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "set", Pars: "arr.int", Ret: "set", Code: 288, 
+	{Name: "SelectºChan", Pars: "int", Ret: "int", Code: 321, 
+		Func: SelectºChan, Return: core.TYPEINT, 
+		Params: []uint16{core.TYPEINT}, 
+		Variadic: true, Runtime: true, CanError: true},
+	{Name: "SendºChanAny", Pars: "chan*,obj", Ret: "", Code: 322, 
+		Func: SendºChanAny, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPECHAN,core.TYPEOBJ}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "SendºChanFloat", Pars: "chan*,float", Ret: "", Code: 323, 
+		Func: SendºChanFloat, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPECHAN,core.TYPEFLOAT}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "SendºChanInt", Pars: "chan*,int", Ret: "", Code: 324, 
+		Func: SendºChanInt, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPECHAN,core.TYPEINT}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "SendºChanStr", Pars: "chan*,str", Ret: "", Code: 325, 
+		Func: SendºChanStr, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPECHAN,core.TYPESTR}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "set", Pars: "arr.int", Ret: "set", Code: 326, 
 		Func: setºArr, Return: core.TYPESET, 
 		Params: []uint16{core.TYPEARR}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "Set", Pars: "set,int", Ret: "set", Code: 289, 
+	{Name: "Set", Pars: "set,int", Ret: "set", Code: 327, 
 		Func: SetºSet, Return: core.TYPESET, 
 		Params: []uint16{core.TYPESET,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "set", Pars: "str", Ret: "set", Code: 290, 
+	{Name: "set", Pars: "str", Ret: "set", Code: 328, 
 		Func: setºStr, Return: core.TYPESET, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "SetEnv", Pars: "str,str", Ret: "str", Code: 291, 
+	{Name: "SetEnv", Pars: "str,str", Ret: "str", Code: 329, 
 		Func: SetEnv, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "SetEnv", Pars: "str,int", Ret: "str", Code: 292, 
+	{Name: "SetEnv", Pars: "str,int", Ret: "str", Code: 330, 
 		Func: SetEnv, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPEINT}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "SetEnv", Pars: "str,bool", Ret: "str", Code: 293, 
+	{Name: "SetEnv", Pars: "str,bool", Ret: "str", Code: 331, 
 		Func: SetEnvBool, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPEBOOL}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "SetFileTime", Pars: "str,time", Ret: "", Code: 294, 
+	{Name: "SetFileTime", Pars: "str,time", Ret: "", Code: 332, 
 		Func: SetFileTimeºStrTime, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPESTR,core.TYPESTRUCT}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "SetLen", Pars: "buf,int", Ret: "buf", Code: 295, 
+	{Name: "SetLen", Pars: "buf,int", Ret: "buf", Code: 333, 
 		Func: SetLenºBuf, Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPEBUF,core.TYPEINT}, 
-		Variadic: false, Runtime: false, CanError: true},
-	{Name: "SetPos", Pars: "file,int,int", Ret: "int", Code: 296, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "SetPos", Pars: "file,int,int", Ret: "int", Code: 334, 
 		Func: SetPosºFileIntInt, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEFILE,core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "SetThreadData", Pars: "obj", Ret: "", Code: 297, 
+	{Name: "SetThreadData", Pars: "obj", Ret: "", Code: 335, 
 		Func: SetThreadData, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPEOBJ}, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "Sha256", Pars: "buf", Ret: "buf", Code: 298, 
+	{Name: "Sha256", Pars: "buf", Ret: "buf", Code: 336, 
 		Func: Sha256ºBuf, Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPEBUF}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Sha256", Pars: "str", Ret: "buf", Code: 299, 
+	{Name: "Sha256", Pars: "str", Ret: "buf", Code: 337, 
 		Func: Sha256ºStr, Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Sha256File", Pars: "str", Ret: "str", Code: 300, 
+	{Name: "Sha256File", Pars: "str", Ret: "str", Code: 338, 
 		Func: Sha256FileºStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "Shift", Pars: "str", Ret: "str", Code: 301, 
+	{Name: "Shift", Pars: "str", Ret: "str", Code: 339, 
 		Func: ShiftºStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -1225,71 +1377,71 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Size", Pars: "int,str", Ret: "str", Code: 304, 
+	{Name: "Size", Pars: "int,str", Ret: "str", Code: 342, 
 		Func: SizeToStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPEINT,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "sleep", Pars: "int", Ret: "", Code: 305, 
+	{Name: "sleep", Pars: "int", Ret: "", Code: 343, 
 		Func: sleepºInt, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPEINT}, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "SliceAuto", Pars: "arr*,int,int", Ret: "arr*", Code: 306, 
+	{Name: "SliceAuto", Pars: "arr*,int,int", Ret: "arr*", Code: 344, 
 		Func: SliceºArr, Return: core.TYPESTRUCT, 
 		Params: []uint16{core.TYPESTRUCT,core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "Sort", Pars: "arr.str", Ret: "arr.str", Code: 307, 
+	{Name: "Sort", Pars: "arr.str", Ret: "arr.str", Code: 345, 
 		Func: SortºArr, Return: core.TYPEARR, 
 		Params: []uint16{core.TYPEARR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Split", Pars: "str,str", Ret: "arr.str", Code: 308, 
+	{Name: "Split", Pars: "str,str", Ret: "arr.str", Code: 346, 
 		Func: SplitºStrStr, Return: core.TYPEARR, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "SplitCmdLine", Pars: "str", Ret: "arr.str", Code: 309, 
+	{Name: "SplitCmdLine", Pars: "str", Ret: "arr.str", Code: 347, 
 		Func: SplitCmdLine, Return: core.TYPEARR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "str", Pars: "bool", Ret: "str", Code: 310, 
+	{Name: "str", Pars: "bool", Ret: "str", Code: 348, 
 		Func: strºBool, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPEBOOL}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "str", Pars: "buf", Ret: "str", Code: 311, 
+	{Name: "str", Pars: "buf", Ret: "str", Code: 349, 
 		Func: strºBuf, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPEBUF}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "str", Pars: "char", Ret: "str", Code: 312, 
+	{Name: "str", Pars: "char", Ret: "str", Code: 350, 
 		Func: strºChar, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPECHAR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "str", Pars: "float", Ret: "str", Code: 313, 
+	{Name: "str", Pars: "float", Ret: "str", Code: 351, 
 		Func: strºFloat, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "str", Pars: "int", Ret: "str", Code: 314, 
+	{Name: "str", Pars: "int", Ret: "str", Code: 352, 
 		Func: strºInt, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "str", Pars: "obj", Ret: "str", Code: 315, 
+	{Name: "str", Pars: "obj", Ret: "str", Code: 353, 
 		Func: strºObj, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPEOBJ}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "str", Pars: "obj,str", Ret: "str", Code: 316, 
+	{Name: "str", Pars: "obj,str", Ret: "str", Code: 354, 
 		Func: strºObjDef, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPEOBJ,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "str", Pars: "set", Ret: "str", Code: 317, 
+	{Name: "str", Pars: "set", Ret: "str", Code: 355, 
 		Func: strºSet, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESET}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "str", Pars: "time", Ret: "str", Code: 318, 
+	{Name: "str", Pars: "time", Ret: "str", Code: 356, 
 		Func: StrºTime, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTRUCT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "StructDecode", Pars: "buf,struct", Ret: "", Code: 319, 
+	{Name: "StructDecode", Pars: "buf,struct", Ret: "", Code: 357, 
 		Func: StructDecode, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPEBUF,core.TYPESTRUCT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "StructEncode", Pars: "struct", Ret: "buf", Code: 320, 
+	{Name: "StructEncode", Pars: "struct", Ret: "buf", Code: 358, 
 		Func: StructEncode, Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPESTRUCT}, 
 		Variadic: false, Runtime: false, CanError: true},
@@ -1297,11 +1449,11 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPEFLOAT,core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Sub", Pars: "float,int", Ret: "float", Code: 322, 
+	{Name: "Sub", Pars: "float,int", Ret: "float", Code: 360, 
 		Func: SubºFloatInt, Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPEFLOAT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Sub", Pars: "int,float", Ret: "float", Code: 323, 
+	{Name: "Sub", Pars: "int,float", Ret: "float", Code: 361, 
 		Func: SubºIntFloat, Return: core.TYPEFLOAT, 
 		Params: []uint16{core.TYPEINT,core.TYPEFLOAT}, 
 		Variadic: false, Runtime: false, CanError: false},
@@ -1309,165 +1461,3406 @@ var EmbedFuncs = []core.Embed{
 		Func: nil, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Subbuf", Pars: "buf,int,int", Ret: "buf", Code: 325, 
+	{Name: "Subbuf", Pars: "buf,int,int", Ret: "buf", Code: 363, 
 		Func: Subbuf, Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPEBUF,core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "Substr", Pars: "str,int,int", Ret: "str", Code: 326, 
+	{Name: "Substr", Pars: "str,int,int", Ret: "str", Code: 364, 
 		Func: SubstrºStrIntInt, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPEINT,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "suspend", Pars: "thread", Ret: "", Code: 327, 
+	{Name: "suspend", Pars: "thread", Ret: "", Code: 365, 
 		Func: suspendºThread, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPEINT}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "sysBufNil", Pars: "", Ret: "buf", Code: 328, 
+	{Name: "sysBufNil", Pars: "", Ret: "buf", Code: 366, 
 		Func: sysBufNil, Return: core.TYPEBUF, 
 		Params: nil, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "sysRun", Pars: "str,bool,buf,buf,buf,arr.str", Ret: "", Code: 329, 
+	{Name: "sysRun", Pars: "str,bool,buf,buf,buf,arr.str", Ret: "", Code: 367, 
 		Func: sysRun, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPESTR,core.TYPEBOOL,core.TYPEBUF,core.TYPEBUF,core.TYPEBUF,core.TYPEARR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "TarGz", Pars: "str,str", Ret: "", Code: 330, 
+	{Name: "TarGz", Pars: "str,str", Ret: "", Code: 368, 
 		Func: TarGz, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "TempDir", Pars: "", Ret: "str", Code: 331, 
+	{Name: "TempDir", Pars: "", Ret: "str", Code: 369, 
 		Func: TempDir, Return: core.TYPESTR, 
 		Params: nil, 
-		Variadic: false, Runtime: false, CanError: false},
-	{Name: "TempDir", Pars: "str,str", Ret: "str", Code: 332, 
+		Variadic: false, Runtime: true, CanError: false},
+	{Name: "TempDir", Pars: "str,str", Ret: "str", Code: 370, 
 		Func: TempDirºStrStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "terminate", Pars: "thread", Ret: "", Code: 333, 
+	{Name: "terminate", Pars: "thread", Ret: "", Code: 371, 
 		Func: terminateºThread, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPEINT}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "time", Pars: "int", Ret: "time", Code: 334, 
+	{Name: "time", Pars: "int", Ret: "time", Code: 372, 
 		Func: timeºInt, Return: core.TYPESTRUCT, 
 		Params: []uint16{core.TYPEINT}, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "Toggle", Pars: "set,int", Ret: "bool", Code: 335, 
+	{Name: "Toggle", Pars: "set,int", Ret: "bool", Code: 373, 
 		Func: ToggleºSetInt, Return: core.TYPEBOOL, 
 		Params: []uint16{core.TYPESET,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Trace", Pars: "", Ret: "arr.trace", Code: 336, 
+	{Name: "Trace", Pars: "", Ret: "arr.trace", Code: 374, 
 		Func: Trace, Return: core.TYPEARR, 
 		Params: nil, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "ThreadData", Pars: "", Ret: "obj", Code: 337, 
+	{Name: "ThreadData", Pars: "", Ret: "obj", Code: 375, 
 		Func: ThreadData, Return: core.TYPEOBJ, 
 		Params: nil, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "Trim", Pars: "str,str", Ret: "str", Code: 338, 
+	{Name: "Trim", Pars: "str,str", Ret: "str", Code: 376, 
 		Func: TrimºStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "TrimLeft", Pars: "str,str", Ret: "str", Code: 339, 
+	{Name: "TrimLeft", Pars: "str,str", Ret: "str", Code: 377, 
 		Func: TrimLeftºStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "TrimRight", Pars: "str,str", Ret: "str", Code: 340, 
+	{Name: "TrimRight", Pars: "str,str", Ret: "str", Code: 378, 
 		Func: TrimRightºStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "TrimSpace", Pars: "str", Ret: "str", Code: 341, 
+	{Name: "TrimSpace", Pars: "str", Ret: "str", Code: 379, 
 		Func: TrimSpaceºStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Type", Pars: "obj", Ret: "str", Code: 342, 
+	{Name: "TryAcquire", Pars: "semaphore,int", Ret: "bool", Code: 380, 
+		Func: TryAcquireºSemaphore, Return: core.TYPEBOOL, 
+		Params: []uint16{core.TYPESYNC,core.TYPEINT}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "TryLock", Pars: "mutex,int", Ret: "bool", Code: 381, 
+		Func: TryLockºMutex, Return: core.TYPEBOOL, 
+		Params: []uint16{core.TYPESYNC,core.TYPEINT}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "TryLock", Pars: "rwmutex,int", Ret: "bool", Code: 382, 
+		Func: TryLockºMutex, Return: core.TYPEBOOL, 
+		Params: []uint16{core.TYPESYNC,core.TYPEINT}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "TryRLock", Pars: "rwmutex,int", Ret: "bool", Code: 383, 
+		Func: TryRLockºRWMutex, Return: core.TYPEBOOL, 
+		Params: []uint16{core.TYPESYNC,core.TYPEINT}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "Type", Pars: "obj", Ret: "str", Code: 384, 
 		Func: Type, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPEOBJ}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "UnBase64", Pars: "str", Ret: "buf", Code: 343, 
+	{Name: "UnBase64", Pars: "str", Ret: "buf", Code: 385, 
 		Func: UnBase64ºStr, Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "UnHex", Pars: "str", Ret: "buf", Code: 344, 
+	{Name: "UnHex", Pars: "str", Ret: "buf", Code: 386, 
 		Func: UnHexºStr, Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "Unlock", Pars: "", Ret: "", Code: 345, 
+	{Name: "Unlock", Pars: "", Ret: "", Code: 387, 
 		Func: Unlock, Return: core.TYPENONE, 
 		Params: nil, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "UnpackTarGz", Pars: "str,str", Ret: "", Code: 346, 
+	{Name: "Unlock", Pars: "mutex", Ret: "", Code: 388, 
+		Func: UnlockºMutex, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPESYNC}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "Unlock", Pars: "rwmutex", Ret: "", Code: 389, 
+		Func: UnlockºRWMutex, Return: core.TYPENONE, 
+		Params: []uint16{core.TYPESYNC}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "UnpackTarGz", Pars: "str,str", Ret: "", Code: 390, 
 		Func: UnpackTarGz, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "UnpackTarGz", Pars: "str,str,arr.str,arr.str", Ret: "", Code: 347, 
+	{Name: "UnpackTarGz", Pars: "str,str,arr.str,arr.str", Ret: "", Code: 391, 
 		Func: UnpackTarGzºStr, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR,core.TYPEARR,core.TYPEARR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "UnpackZip", Pars: "str,str", Ret: "", Code: 348, 
+	{Name: "UnpackZip", Pars: "str,str", Ret: "", Code: 392, 
 		Func: UnpackZip, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "UnpackZip", Pars: "str,str,arr.str,arr.str", Ret: "", Code: 349, 
+	{Name: "UnpackZip", Pars: "str,str,arr.str,arr.str", Ret: "", Code: 393, 
 		Func: UnpackZipºStr, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR,core.TYPEARR,core.TYPEARR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "UnSet", Pars: "set,int", Ret: "set", Code: 350, 
+	{Name: "UnSet", Pars: "set,int", Ret: "set", Code: 394, 
 		Func: UnSetºSet, Return: core.TYPESET, 
 		Params: []uint16{core.TYPESET,core.TYPEINT}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "UnsetEnv", Pars: "str", Ret: "", Code: 351, 
+	{Name: "UnsetEnv", Pars: "str", Ret: "", Code: 395, 
 		Func: UnsetEnv, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "Upper", Pars: "str", Ret: "str", Code: 352, 
+	{Name: "Upper", Pars: "str", Ret: "str", Code: 396, 
 		Func: UpperºStr, Return: core.TYPESTR, 
 		Params: []uint16{core.TYPESTR}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "UTC", Pars: "time", Ret: "time", Code: 353, 
+	{Name: "UTC", Pars: "time", Ret: "time", Code: 397, 
 		Func: UTCºTime, Return: core.TYPESTRUCT, 
 		Params: []uint16{core.TYPESTRUCT}, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "wait", Pars: "thread", Ret: "", Code: 354, 
+	{Name: "wait", Pars: "thread", Ret: "", Code: 398, 
 		Func: waitºThread, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPEINT}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "WaitAll", Pars: "", Ret: "", Code: 355, 
+	{Name: "WaitAll", Pars: "", Ret: "", Code: 399, 
 		Func: WaitAll, Return: core.TYPENONE, 
 		Params: nil, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "WaitDone", Pars: "", Ret: "", Code: 356, 
+	{Name: "WaitAllºFuture", Pars: "arr*", Ret: "arr*", Code: 400, 
+		Func: WaitAllºFuture, Return: core.TYPESTRUCT, 
+		Params: []uint16{core.TYPESTRUCT}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "WaitºFutureAny", Pars: "future*", Ret: "obj", Code: 401, 
+		Func: WaitºFutureAny, Return: core.TYPEOBJ, 
+		Params: []uint16{core.TYPEFUTURE}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "WaitºFutureFloat", Pars: "future*", Ret: "float", Code: 402, 
+		Func: WaitºFutureFloat, Return: core.TYPEFLOAT, 
+		Params: []uint16{core.TYPEFUTURE}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "WaitºFutureInt", Pars: "future*", Ret: "int", Code: 403, 
+		Func: WaitºFutureInt, Return: core.TYPEINT, 
+		Params: []uint16{core.TYPEFUTURE}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "WaitºFutureStr", Pars: "future*", Ret: "str", Code: 404, 
+		Func: WaitºFutureStr, Return: core.TYPESTR, 
+		Params: []uint16{core.TYPEFUTURE}, 
+		Variadic: false, Runtime: true, CanError: true},
+	{Name: "WaitDone", Pars: "", Ret: "", Code: 405, 
 		Func: WaitDone, Return: core.TYPENONE, 
 		Params: nil, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "WaitGroup", Pars: "int", Ret: "", Code: 357, 
+	{Name: "WaitGroup", Pars: "int", Ret: "", Code: 406, 
 		Func: WaitGroup, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPEINT}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "Weekday", Pars: "time", Ret: "int", Code: 358, 
+	{Name: "Weekday", Pars: "time", Ret: "int", Code: 407, 
 		Func: WeekdayºTime, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPESTRUCT}, 
 		Variadic: false, Runtime: true, CanError: false},
-	{Name: "Write", Pars: "buf,int,buf", Ret: "buf", Code: 359, 
+	{Name: "Write", Pars: "buf,int,buf", Ret: "buf", Code: 408, 
 		Func: WriteºBuf, Return: core.TYPEBUF, 
 		Params: []uint16{core.TYPEBUF,core.TYPEINT,core.TYPEBUF}, 
 		Variadic: false, Runtime: false, CanError: true},
-	{Name: "Write", Pars: "file,buf", Ret: "file", Code: 360, 
+	{Name: "Write", Pars: "file,buf", Ret: "file", Code: 409, 
 		Func: WriteFileºFileBuf, Return: core.TYPEFILE, 
 		Params: []uint16{core.TYPEFILE,core.TYPEBUF}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "WriteFile", Pars: "str,buf", Ret: "", Code: 361, 
+	{Name: "WriteFile", Pars: "str,buf", Ret: "", Code: 410, 
 		Func: WriteFileºStrBuf, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPESTR,core.TYPEBUF}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "WriteFile", Pars: "str,str", Ret: "", Code: 362, 
+	{Name: "WriteFile", Pars: "str,str", Ret: "", Code: 411, 
 		Func: WriteFileºStrStr, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
-	{Name: "YearDay", Pars: "time", Ret: "int", Code: 363, 
+	{Name: "YearDay", Pars: "time", Ret: "int", Code: 412, 
 		Func: YearDayºTime, Return: core.TYPEINT, 
 		Params: []uint16{core.TYPESTRUCT}, 
 		Variadic: false, Runtime: false, CanError: false},
-	{Name: "Zip", Pars: "str,str", Ret: "", Code: 364, 
+	{Name: "Zip", Pars: "str,str", Ret: "", Code: 413, 
 		Func: ZipºStr, Return: core.TYPENONE, 
 		Params: []uint16{core.TYPESTR,core.TYPESTR}, 
 		Variadic: false, Runtime: true, CanError: true},
 }
-const StdLibCount = 365
+const StdLibCount = 414
+
+// embedStubs contains the typed calls of the embedded functions. nil means that
+// the function is called with reflect.
+var embedStubs = []embedStub{
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p0 := rt.SInt[top.Int]
+		ret := AbsºInt(p0)
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := AbsPath(rt, p0)
+		if err != nil {
+			return err
+		}
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Sync)
+		if err := AcquireºSemaphore(rt, p0); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p1 := rt.SAny[top.Any].(*core.Buffer)
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Buffer)
+		ret := AddºBufBuf(p0, p1)
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Int--
+		p0 := rt.SInt[top.Int]
+		ret := AddºCharChar(p0, p1)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Int--
+		p0 := rt.SInt[top.Int]
+		ret := AddºCharStr(p0, p1)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	nil,
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Float--
+		p0 := rt.SFloat[top.Float]
+		ret := AddºFloatInt(p0, p1)
+		rt.SFloat[top.Float] = ret
+		top.Float++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Float--
+		p1 := rt.SFloat[top.Float]
+		top.Int--
+		p0 := rt.SInt[top.Int]
+		ret := AddºIntFloat(p0, p1)
+		rt.SFloat[top.Float] = ret
+		top.Float++
+		return nil
+	},
+	nil,
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret := AddºStrChar(p0, p1)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	nil,
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Any--
+		p0 := rt.SAny[top.Any].(*Struct)
+		ret := AddHoursºTimeInt(rt, p0, p1)
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p0 := rt.SInt[top.Int]
+		ret := AfterºInt(p0)
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p1 := rt.SAny[top.Any].(*core.Buffer)
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := AESDecryptBuf(p0, p1)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p1 := rt.SAny[top.Any].(*core.Buffer)
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := AESEncryptBuf(p0, p1)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p1 := rt.SAny[top.Any].(*core.Buffer)
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		if err := AppendFileºStrBuf(rt, p0, p1); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		if err := AppendFileºStrStr(rt, p0, p1); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret := ArgºStr(rt, p0)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := ArgºStrInt(rt, p0, p1)
+		if err != nil {
+			return err
+		}
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret := ArgºStrStr(rt, p0, p1)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		ret := ArgCount(rt)
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		ret := Args(rt)
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret := ArgsºStr(rt, p0)
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		ret := ArgsTail(rt)
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Any--
+		p0 := rt.SAny[top.Any].(*Struct)
+		ret := ArchiveName(p0, p1)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Obj)
+		ret, err := arrºObj(p0)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Set)
+		ret := arrºSet(p0)
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Obj)
+		ret, err := arrstrºObj(p0)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	nil,
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Buffer)
+		ret := Base64ºBuf(p0)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret := BaseName(p0)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	nil,
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p1 := rt.SAny[top.Any].(*core.Set)
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Set)
+		ret := BitAndºSetSet(p0, p1)
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	nil,
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Set)
+		ret := BitNotºSet(p0)
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	nil,
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p1 := rt.SAny[top.Any].(*core.Set)
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Set)
+		ret := BitOrºSetSet(p0, p1)
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	nil,
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Array)
+		ret := boolºArr(p0)
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Buffer)
+		ret := boolºBuf(p0)
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Float--
+		p0 := rt.SFloat[top.Float]
+		ret := boolºFloat(p0)
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p0 := rt.SInt[top.Int]
+		ret := boolºInt(p0)
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Obj)
+		ret, err := boolºObj(p0)
+		if err != nil {
+			return err
+		}
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Obj)
+		ret, err := boolºObjDef(p0, p1)
+		if err != nil {
+			return err
+		}
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Map)
+		ret := boolºMap(p0)
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret := boolºStr(p0)
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret := bufºStr(p0)
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Chan)
+		ret := CapºChan(p0)
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Float--
+		p0 := rt.SFloat[top.Float]
+		ret := CeilºFloat(p0)
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		if err := ChDirºStr(rt, p0); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		if err := ChModeºStr(rt, p0, p1); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret := ClearCarriage(p0)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Chan)
+		if err := closeºChan(p0); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.File)
+		if err := CloseFile(p0); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*GzFile)
+		if err := CloseTarGz(p0); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*ZipFile)
+		if err := CloseZip(p0); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		if err := Command(rt, p0); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := CommandOutput(rt, p0)
+		if err != nil {
+			return err
+		}
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p2 := rt.SStr[top.Str]
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Any--
+		p0 := rt.SAny[top.Any].(Pack)
+		if err := CompressFile(rt, p0, p1, p2); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := CopyFileºStrStr(rt, p0, p1)
+		if err != nil {
+			return err
+		}
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		if err := CreateDirºStr(rt, p0); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		if err := CreateFileºStrBool(rt, p0, p1); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := CreateTarGz(rt, p0)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := CreateZip(rt, p0)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := CtxºStr(rt, p0)
+		if err != nil {
+			return err
+		}
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := CtxGetºStr(rt, p0)
+		if err != nil {
+			return err
+		}
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret := CtxIsºStr(rt, p0)
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := CtxSetºStrBool(rt, p0, p1)
+		if err != nil {
+			return err
+		}
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Float--
+		p1 := rt.SFloat[top.Float]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := CtxSetºStrFloat(rt, p0, p1)
+		if err != nil {
+			return err
+		}
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := CtxSetºStrInt(rt, p0, p1)
+		if err != nil {
+			return err
+		}
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := CtxSetºStrStr(rt, p0, p1)
+		if err != nil {
+			return err
+		}
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret := CtxValueºStr(rt, p0)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p2 := rt.SInt[top.Int]
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Int--
+		p0 := rt.SInt[top.Int]
+		ret := DateºInts(rt, p0, p1, p2)
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p5 := rt.SInt[top.Int]
+		top.Int--
+		p4 := rt.SInt[top.Int]
+		top.Int--
+		p3 := rt.SInt[top.Int]
+		top.Int--
+		p2 := rt.SInt[top.Int]
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Int--
+		p0 := rt.SInt[top.Int]
+		ret := DateTimeºInts(rt, p0, p1, p2, p3, p4, p5)
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*Struct)
+		ret := DaysºTime(p0)
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Buffer)
+		ret, err := DecodeºBufInt(p0, p1)
+		if err != nil {
+			return err
+		}
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p2 := rt.SInt[top.Int]
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Buffer)
+		ret, err := DelºBufIntInt(p0, p1, p2)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Map)
+		ret := DelºMapStr(p0, p1)
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret := Dir(p0)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := Download(rt, p0, p1)
+		if err != nil {
+			return err
+		}
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	nil,
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Float--
+		p0 := rt.SFloat[top.Float]
+		ret, err := DivºFloatInt(p0, p1)
+		if err != nil {
+			return err
+		}
+		rt.SFloat[top.Float] = ret
+		top.Float++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Float--
+		p1 := rt.SFloat[top.Float]
+		top.Int--
+		p0 := rt.SInt[top.Int]
+		ret, err := DivºIntFloat(p0, p1)
+		if err != nil {
+			return err
+		}
+		rt.SFloat[top.Float] = ret
+		top.Float++
+		return nil
+	},
+	nil,
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Buffer)
+		ret := EncodeºBufInt(p0, p1)
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	nil,
+	nil,
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Float--
+		p0 := rt.SFloat[top.Float]
+		ret := EqualºFloatInt(p0, p1)
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	nil,
+	nil,
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p1 := rt.SAny[top.Any].(*Struct)
+		top.Any--
+		p0 := rt.SAny[top.Any].(*Struct)
+		ret := EqualºTimeTime(p0, p1)
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*RuntimeError)
+		ret, err := ErrCause(p0)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*RuntimeError)
+		ret := ErrID(p0)
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p2 := rt.SStr[top.Str]
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Any--
+		p0 := rt.SAny[top.Any].(*RuntimeError)
+		if err := errorºErrIntStr(p0, p1, p2); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Int--
+		p0 := rt.SInt[top.Int]
+		if err := errorºIntStr(p0, p1, vars...); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p2 := rt.SAny[top.Any].(*core.Obj)
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Int--
+		p0 := rt.SInt[top.Int]
+		if err := ErrorPayloadºObj(p0, p1, p2); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p2 := rt.SAny[top.Any].(*Struct)
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Int--
+		p0 := rt.SInt[top.Int]
+		if err := ErrorPayloadºStruct(p0, p1, p2); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*RuntimeError)
+		ret, err := ErrPayload(p0)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*RuntimeError)
+		ret := ErrText(p0)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*RuntimeError)
+		ret := ErrTrace(rt, p0)
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := ExistFile(rt, p0)
+		if err != nil {
+			return err
+		}
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p0 := rt.SInt[top.Int]
+		if err := exit(rt, p0); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret := ExpStrºBool(p0, p1)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret := ExpStrºChar(p0, p1)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Float--
+		p1 := rt.SFloat[top.Float]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret := ExpStrºFloat(p0, p1)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret := ExpStrºInt(p0, p1)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p1 := rt.SAny[top.Any].(*core.Obj)
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret := ExpStrºObj(p0, p1)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	nil,
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret := Ext(p0)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.File)
+		ret, err := FileInfoºFile(rt, p0)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := FileInfoºStr(rt, p0)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := FileModeºStr(rt, p0)
+		if err != nil {
+			return err
+		}
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret := FindºStrStr(p0, p1)
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := FindFirstRegExpºStrStr(p0, p1)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := FindRegExpºStrStr(p0, p1)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p0 := rt.SInt[top.Int]
+		ret := floatºInt(p0)
+		rt.SFloat[top.Float] = ret
+		top.Float++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Obj)
+		ret, err := floatºObj(p0)
+		if err != nil {
+			return err
+		}
+		rt.SFloat[top.Float] = ret
+		top.Float++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Float--
+		p1 := rt.SFloat[top.Float]
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Obj)
+		ret, err := floatºObjDef(p0, p1)
+		if err != nil {
+			return err
+		}
+		rt.SFloat[top.Float] = ret
+		top.Float++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := floatºStr(p0)
+		if err != nil {
+			return err
+		}
+		rt.SFloat[top.Float] = ret
+		top.Float++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Float--
+		p0 := rt.SFloat[top.Float]
+		ret := FloorºFloat(p0)
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret := FormatºStr(p0, vars...)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p1 := rt.SAny[top.Any].(*Struct)
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret := FormatºTimeStr(p0, p1)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		ret, err := GetCurDir(rt)
+		if err != nil {
+			return err
+		}
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret := GetEnv(p0)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Int--
+		p0 := rt.SInt[top.Int]
+		ret := GreaterºCharChar(p0, p1)
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	nil,
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Float--
+		p0 := rt.SFloat[top.Float]
+		ret := GreaterºFloatInt(p0, p1)
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	nil,
+	nil,
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p1 := rt.SAny[top.Any].(*Struct)
+		top.Any--
+		p0 := rt.SAny[top.Any].(*Struct)
+		ret := GreaterºTimeTime(p0, p1)
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret := HasPrefixºStrStr(p0, p1)
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret := HasSuffixºStrStr(p0, p1)
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := HeadInfo(rt, p0)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Buffer)
+		ret := HexºBuf(p0)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := HTTPGet(rt, p0)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := HTTPPage(rt, p0)
+		if err != nil {
+			return err
+		}
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p3 := rt.SAny[top.Any].(*core.Map)
+		top.Any--
+		p2 := rt.SAny[top.Any].(*core.Map)
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := HTTPRequest(rt, p0, p1, p2, p3)
+		if err != nil {
+			return err
+		}
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Array)
+		ret := JoinºArrStr(p0, p1)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		ret := JoinPath(vars...)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Obj)
+		ret, err := Json(p0)
+		if err != nil {
+			return err
+		}
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := JsonToObj(p0)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p2 := rt.SAny[top.Any].(*core.Buffer)
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Buffer)
+		ret, err := InsertºBufIntBuf(p0, p1, p2)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	nil,
+	nil,
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Float--
+		p0 := rt.SFloat[top.Float]
+		ret := intºFloat(p0)
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Obj)
+		ret, err := intºObj(p0)
+		if err != nil {
+			return err
+		}
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Obj)
+		ret, err := intºObjDef(p0, p1)
+		if err != nil {
+			return err
+		}
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := intºStr(p0)
+		if err != nil {
+			return err
+		}
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*Struct)
+		ret := intºTime(p0)
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Obj)
+		ret := IsArrayºObj(p0)
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret := IsArgºStr(rt, p0)
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := IsEmptyDir(rt, p0)
+		if err != nil {
+			return err
+		}
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Map)
+		ret := IsKeyºMapStr(p0, p1)
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Obj)
+		ret := IsMapºObj(p0)
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Obj)
+		ret := IsNil(p0)
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Obj)
+		ret, err := ItemºObjInt(p0, p1)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Obj)
+		ret, err := ItemºObjStr(p0, p1)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Map)
+		ret, err := KeyºMapInt(p0, p1)
+		if err != nil {
+			return err
+		}
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret := LeftºStrInt(p0, p1)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	nil,
+	nil,
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Chan)
+		ret := LenºChan(p0)
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	nil,
+	nil,
+	nil,
+	nil,
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Int--
+		p0 := rt.SInt[top.Int]
+		ret := LessºCharChar(p0, p1)
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	nil,
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Float--
+		p0 := rt.SFloat[top.Float]
+		ret := LessºFloatInt(p0, p1)
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	nil,
+	nil,
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p1 := rt.SAny[top.Any].(*Struct)
+		top.Any--
+		p0 := rt.SAny[top.Any].(*Struct)
+		ret := LessºTimeTime(p0, p1)
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret := LinesºStr(p0)
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		Lock(rt)
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Sync)
+		if err := LockºMutex(rt, p0); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Sync)
+		if err := LockºMutex(rt, p0); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret := LowerºStr(p0)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	nil,
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Obj)
+		ret, err := mapºObj(p0)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := MatchºStrStr(p0, p1)
+		if err != nil {
+			return err
+		}
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := MatchPath(p0, p1)
+		if err != nil {
+			return err
+		}
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Float--
+		p1 := rt.SFloat[top.Float]
+		top.Float--
+		p0 := rt.SFloat[top.Float]
+		ret := MaxºFloatFloat(p0, p1)
+		rt.SFloat[top.Float] = ret
+		top.Float++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Int--
+		p0 := rt.SInt[top.Int]
+		ret := MaxºIntInt(p0, p1)
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Buffer)
+		ret := Md5ºBuf(p0)
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret := Md5ºStr(p0)
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := Md5FileºStr(rt, p0)
+		if err != nil {
+			return err
+		}
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Float--
+		p1 := rt.SFloat[top.Float]
+		top.Float--
+		p0 := rt.SFloat[top.Float]
+		ret := MinºFloatFloat(p0, p1)
+		rt.SFloat[top.Float] = ret
+		top.Float++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Int--
+		p0 := rt.SInt[top.Int]
+		ret := MinºIntInt(p0, p1)
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	nil,
+	nil,
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Float--
+		p0 := rt.SFloat[top.Float]
+		ret := MulºFloatInt(p0, p1)
+		rt.SFloat[top.Float] = ret
+		top.Float++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Float--
+		p1 := rt.SFloat[top.Float]
+		top.Int--
+		p0 := rt.SInt[top.Int]
+		ret := MulºIntFloat(p0, p1)
+		rt.SFloat[top.Float] = ret
+		top.Float++
+		return nil
+	},
+	nil,
+	nil,
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p0 := rt.SInt[top.Int]
+		ret, err := NewChanºInt(p0)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p0 := rt.SInt[top.Int]
+		ret, err := NewSemaphoreºInt(p0)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	nil,
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Chan)
+		ret, err := NextºChan(rt, p0)
+		if err != nil {
+			return err
+		}
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	nil,
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		ret := Now(rt)
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any]
+		ret, err := objºArrMap(p0)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p0 := rt.SInt[top.Int]
+		ret := objºBool(p0)
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*Struct)
+		ret := ObjºFinfo(p0)
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Float--
+		p0 := rt.SFloat[top.Float]
+		ret := objºAny(p0)
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p0 := rt.SInt[top.Int]
+		ret := objºAny(p0)
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any]
+		ret, err := objºArrMap(p0)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret := objºAny(p0)
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		if err := OpenºStr(rt, p0); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := OpenFileºStr(rt, p0, p1)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		if err := OpenWithºStr(rt, p0, p1); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p2 := rt.SAny[top.Any].(*Fn)
+		top.Any--
+		p1 := rt.SAny[top.Any]
+		top.Int--
+		p0 := rt.SInt[top.Int]
+		if err := ParallelºFor(rt, p0, p1, p2); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*Struct)
+		ret := FileInfoToPath(p0)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := ParseTimeºStrStr(rt, p0, p1)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		ret, err := Print(vars...)
+		if err != nil {
+			return err
+		}
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		ret, err := Println(vars...)
+		if err != nil {
+			return err
+		}
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := PrintShiftºStr(p0)
+		if err != nil {
+			return err
+		}
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Int--
+		p0 := rt.SInt[top.Int]
+		ProgressInc(rt, p0, p1)
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p0 := rt.SInt[top.Int]
+		ProgressEnd(rt, p0)
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p3 := rt.SStr[top.Str]
+		top.Str--
+		p2 := rt.SStr[top.Str]
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Int--
+		p0 := rt.SInt[top.Int]
+		ret := ProgressStart(rt, p0, p1, p2, p3)
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p0 := rt.SInt[top.Int]
+		ret := Random(p0)
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p0 := rt.SInt[top.Int]
+		ret, err := RandomBuf(rt, p0)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.File)
+		ret, err := ReadºFileInt(rt, p0, p1)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := ReadDirºStr(rt, p0)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p3 := rt.SAny[top.Any].(*core.Array)
+		top.Any--
+		p2 := rt.SAny[top.Any].(*core.Array)
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := ReadDirºStrArr(rt, p0, p1, p2, p3)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p2 := rt.SStr[top.Str]
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := ReadDirºStrIntStr(rt, p0, p1, p2)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := ReadFileºStr(rt, p0)
+		if err != nil {
+			return err
+		}
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p1 := rt.SAny[top.Any].(*core.Buffer)
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := ReadFileºStrBuf(rt, p0, p1)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p2 := rt.SInt[top.Int]
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := ReadFileºStrIntInt(rt, p0, p1, p2)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := ReadString(rt, p0)
+		if err != nil {
+			return err
+		}
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := ReadTarGz(rt, p0)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := ReadZip(rt, p0)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Chan)
+		ret, err := ReceiveºChanAny(rt, p0)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Chan)
+		ret, err := ReceiveºChanFloat(rt, p0)
+		if err != nil {
+			return err
+		}
+		rt.SFloat[top.Float] = ret
+		top.Float++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Chan)
+		ret, err := ReceiveºChanInt(rt, p0)
+		if err != nil {
+			return err
+		}
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Chan)
+		ret, err := ReceiveºChanStr(rt, p0)
+		if err != nil {
+			return err
+		}
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		ret := ReceivedºChanAny(rt)
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		ret := ReceivedºChanFloat(rt)
+		rt.SFloat[top.Float] = ret
+		top.Float++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		ret := ReceivedºChanInt(rt)
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		ret := ReceivedºChanStr(rt)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := RegExpºStrStr(p0, p1)
+		if err != nil {
+			return err
+		}
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Sync)
+		if err := ReleaseºSemaphore(rt, p0); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		if err := RemoveºStr(rt, p0); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		if err := RemoveDirºStr(rt, p0); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		if err := RenameºStrStr(rt, p0, p1); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := RepeatºStrInt(rt, p0, p1)
+		if err != nil {
+			return err
+		}
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p2 := rt.SStr[top.Str]
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret := ReplaceºStrStrStr(p0, p1, p2)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p2 := rt.SStr[top.Str]
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := ReplaceRegExpºStrStr(p0, p1, p2)
+		if err != nil {
+			return err
+		}
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Array)
+		ret := ReverseºArr(p0)
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Sync)
+		if err := RLockºRWMutex(rt, p0); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Sync)
+		if err := RUnlockºRWMutex(rt, p0); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p0 := rt.SInt[top.Int]
+		if err := resumeºThread(rt, p0); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret := RightºStrInt(p0, p1)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Float--
+		p0 := rt.SFloat[top.Float]
+		ret := RoundºFloat(p0)
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Float--
+		p0 := rt.SFloat[top.Float]
+		ret := RoundºFloatInt(p0, p1)
+		rt.SFloat[top.Float] = ret
+		top.Float++
+		return nil
+	},
+	nil,
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p0 := rt.SInt[top.Int]
+		ret, err := SelectºChan(rt, p0, vars...)
+		if err != nil {
+			return err
+		}
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p1 := rt.SAny[top.Any]
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Chan)
+		if err := SendºChanAny(rt, p0, p1); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Float--
+		p1 := rt.SFloat[top.Float]
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Chan)
+		if err := SendºChanFloat(rt, p0, p1); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Chan)
+		if err := SendºChanInt(rt, p0, p1); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Chan)
+		if err := SendºChanStr(rt, p0, p1); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Array)
+		ret, err := setºArr(p0)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Set)
+		ret, err := SetºSet(p0, p1)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := setºStr(p0)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := SetEnv(rt, p0, p1)
+		if err != nil {
+			return err
+		}
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := SetEnv(rt, p0, p1)
+		if err != nil {
+			return err
+		}
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := SetEnvBool(rt, p0, p1)
+		if err != nil {
+			return err
+		}
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p1 := rt.SAny[top.Any].(*Struct)
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		if err := SetFileTimeºStrTime(rt, p0, p1); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Buffer)
+		ret, err := SetLenºBuf(rt, p0, p1)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p2 := rt.SInt[top.Int]
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.File)
+		ret, err := SetPosºFileIntInt(p0, p1, p2)
+		if err != nil {
+			return err
+		}
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Obj)
+		SetThreadData(rt, p0)
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Buffer)
+		ret := Sha256ºBuf(p0)
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret := Sha256ºStr(p0)
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := Sha256FileºStr(rt, p0)
+		if err != nil {
+			return err
+		}
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret := ShiftºStr(p0)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	nil,
+	nil,
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Int--
+		p0 := rt.SInt[top.Int]
+		ret := SizeToStr(p0, p1)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p0 := rt.SInt[top.Int]
+		sleepºInt(rt, p0)
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p2 := rt.SInt[top.Int]
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Array)
+		ret, err := SliceºArr(rt, p0, p1, p2)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Array)
+		ret := SortºArr(p0)
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret := SplitºStrStr(p0, p1)
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret := SplitCmdLine(p0)
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p0 := rt.SInt[top.Int]
+		ret := strºBool(p0)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Buffer)
+		ret := strºBuf(p0)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p0 := rt.SInt[top.Int]
+		ret := strºChar(p0)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Float--
+		p0 := rt.SFloat[top.Float]
+		ret := strºFloat(p0)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p0 := rt.SInt[top.Int]
+		ret := strºInt(p0)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Obj)
+		ret := strºObj(p0)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Obj)
+		ret := strºObjDef(p0, p1)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Set)
+		ret := strºSet(p0)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*Struct)
+		ret := StrºTime(p0)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p1 := rt.SAny[top.Any].(*Struct)
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Buffer)
+		if err := StructDecode(p0, p1); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*Struct)
+		ret, err := StructEncode(p0)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	nil,
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Float--
+		p0 := rt.SFloat[top.Float]
+		ret := SubºFloatInt(p0, p1)
+		rt.SFloat[top.Float] = ret
+		top.Float++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Float--
+		p1 := rt.SFloat[top.Float]
+		top.Int--
+		p0 := rt.SInt[top.Int]
+		ret := SubºIntFloat(p0, p1)
+		rt.SFloat[top.Float] = ret
+		top.Float++
+		return nil
+	},
+	nil,
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p2 := rt.SInt[top.Int]
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Buffer)
+		ret, err := Subbuf(p0, p1, p2)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p2 := rt.SInt[top.Int]
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := SubstrºStrIntInt(p0, p1, p2)
+		if err != nil {
+			return err
+		}
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p0 := rt.SInt[top.Int]
+		if err := suspendºThread(rt, p0); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		ret := sysBufNil()
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p5 := rt.SAny[top.Any].(*core.Array)
+		top.Any--
+		p4 := rt.SAny[top.Any].(*core.Buffer)
+		top.Any--
+		p3 := rt.SAny[top.Any].(*core.Buffer)
+		top.Any--
+		p2 := rt.SAny[top.Any].(*core.Buffer)
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		if err := sysRun(rt, p0, p1, p2, p3, p4, p5); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		if err := TarGz(rt, p0, p1); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		ret := TempDir(rt)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := TempDirºStrStr(rt, p0, p1)
+		if err != nil {
+			return err
+		}
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p0 := rt.SInt[top.Int]
+		if err := terminateºThread(rt, p0); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p0 := rt.SInt[top.Int]
+		ret := timeºInt(rt, p0)
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Set)
+		ret, err := ToggleºSetInt(p0, p1)
+		if err != nil {
+			return err
+		}
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		ret := Trace(rt)
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		ret, err := ThreadData(rt)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret := TrimºStr(p0, p1)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret := TrimLeftºStr(p0, p1)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret := TrimRightºStr(p0, p1)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret := TrimSpaceºStr(p0)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Sync)
+		ret, err := TryAcquireºSemaphore(rt, p0, p1)
+		if err != nil {
+			return err
+		}
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Sync)
+		ret, err := TryLockºMutex(rt, p0, p1)
+		if err != nil {
+			return err
+		}
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Sync)
+		ret, err := TryLockºMutex(rt, p0, p1)
+		if err != nil {
+			return err
+		}
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Sync)
+		ret, err := TryRLockºRWMutex(rt, p0, p1)
+		if err != nil {
+			return err
+		}
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Obj)
+		ret := Type(p0)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := UnBase64ºStr(p0)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret, err := UnHexºStr(p0)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		Unlock(rt)
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Sync)
+		if err := UnlockºMutex(rt, p0); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Sync)
+		if err := UnlockºRWMutex(rt, p0); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		if err := UnpackTarGz(rt, p0, p1); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p3 := rt.SAny[top.Any].(*core.Array)
+		top.Any--
+		p2 := rt.SAny[top.Any].(*core.Array)
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		if err := UnpackTarGzºStr(rt, p0, p1, p2, p3); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		if err := UnpackZip(rt, p0, p1); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p3 := rt.SAny[top.Any].(*core.Array)
+		top.Any--
+		p2 := rt.SAny[top.Any].(*core.Array)
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		if err := UnpackZipºStr(rt, p0, p1, p2, p3); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Set)
+		ret, err := UnSetºSet(p0, p1)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		if err := UnsetEnv(rt, p0); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		ret := UpperºStr(p0)
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*Struct)
+		ret := UTCºTime(rt, p0)
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p0 := rt.SInt[top.Int]
+		if err := waitºThread(rt, p0); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		if err := WaitAll(rt); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Array)
+		ret, err := WaitAllºFuture(rt, p0)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Future)
+		ret, err := WaitºFutureAny(rt, p0)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Future)
+		ret, err := WaitºFutureFloat(rt, p0)
+		if err != nil {
+			return err
+		}
+		rt.SFloat[top.Float] = ret
+		top.Float++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Future)
+		ret, err := WaitºFutureInt(rt, p0)
+		if err != nil {
+			return err
+		}
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Future)
+		ret, err := WaitºFutureStr(rt, p0)
+		if err != nil {
+			return err
+		}
+		rt.SStr[top.Str] = ret
+		top.Str++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		if err := WaitDone(rt); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Int--
+		p0 := rt.SInt[top.Int]
+		if err := WaitGroup(rt, p0); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*Struct)
+		ret := WeekdayºTime(rt, p0)
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p2 := rt.SAny[top.Any].(*core.Buffer)
+		top.Int--
+		p1 := rt.SInt[top.Int]
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.Buffer)
+		ret, err := WriteºBuf(p0, p1, p2)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p1 := rt.SAny[top.Any].(*core.Buffer)
+		top.Any--
+		p0 := rt.SAny[top.Any].(*core.File)
+		ret, err := WriteFileºFileBuf(rt, p0, p1)
+		if err != nil {
+			return err
+		}
+		rt.SAny[top.Any] = ret
+		top.Any++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p1 := rt.SAny[top.Any].(*core.Buffer)
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		if err := WriteFileºStrBuf(rt, p0, p1); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		if err := WriteFileºStrStr(rt, p0, p1); err != nil {
+			return err
+		}
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Any--
+		p0 := rt.SAny[top.Any].(*Struct)
+		ret := YearDayºTime(p0)
+		rt.SInt[top.Int] = ret
+		top.Int++
+		return nil
+	},
+	func(rt *Runtime, top *Call, vars []interface{}) error {
+		top.Str--
+		p1 := rt.SStr[top.Str]
+		top.Str--
+		p0 := rt.SStr[top.Str]
+		if err := ZipºStr(rt, p0, p1); err != nil {
+			return err
+		}
+		return nil
+	},
+}
@@ -5,7 +5,6 @@
 package vm
 
 import (
-	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -62,7 +61,7 @@ func FindºStrStr(s, substr string) (off int64) {
 func floatºStr(val string) (ret float64, err error) {
 	ret, err = strconv.ParseFloat(val, 64)
 	if err != nil {
-		err = errors.New(ErrorText(ErrStrToFloat))
+		err = newError(ErrStrToFloat)
 	}
 	return
 }
@@ -92,7 +91,7 @@ func HasSuffixºStrStr(s, suffix string) int64 {
 func intºStr(val string) (ret int64, err error) {
 	ret, err = strconv.ParseInt(val, 0, 64)
 	if err != nil {
-		err = errors.New(ErrorText(ErrStrToInt))
+		err = newError(ErrStrToInt)
 	}
 	return
 }
@@ -122,8 +121,11 @@ func LowerºStr(s string) string {
 }
 
 // RepeatºStrInt returns a new string consisting of count copies of the specified string.
-func RepeatºStrInt(input string, count int64) string {
-	return strings.Repeat(input, int(count))
+func RepeatºStrInt(rt *Runtime, input string, count int64) (string, error) {
+	if err := checkMemory(rt, mulSize(count, int64(len(input)))); err != nil {
+		return ``, err
+	}
+	return strings.Repeat(input, int(count)), nil
 }
 
 // ReplaceºStrStrStr replaces strings in a string
@@ -209,7 +211,7 @@ func SubstrºStrIntInt(in string, off, length int64) (string, error) {
 		off -= length
 	}
 	if off < 0 || off >= rlen || off+length > rlen {
-		return ``, fmt.Errorf(ErrorText(ErrInvalidParam))
+		return ``, newError(ErrInvalidParam)
 	}
 	if length == 0 {
 		length = rlen - off
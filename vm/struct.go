@@ -42,6 +42,9 @@ func NewStruct(rt *Runtime, sInfo *core.StructInfo) *Struct {
 			&rt.Owner.Exec.Structs[(v-core.TYPESTRUCT)>>8] != sInfo {
 			values[i] = newValue(rt, int(v))
 		}
+		if sInfo.Defaults != nil && sInfo.Defaults[i] != nil {
+			values[i] = sInfo.Defaults[i]
+		}
 	}
 	return &Struct{
 		Type:   sInfo,
@@ -91,7 +94,7 @@ func StructDecode(input *core.Buffer, pstruct *Struct) (err error) {
 	)
 	bint := make([]byte, 0, binary.MaxVarintLen64+1)
 	buf := bytes.NewReader(input.Data)
-	errDecode := fmt.Errorf(ErrorText(ErrDecode))
+	errDecode := newError(ErrDecode)
 	getInt := func(size uint8) (x int64, err error) {
 		var (
 			n int
@@ -0,0 +1,199 @@
+// Copyright 2019 Alexey Krivonogov. All rights reserved.
+// Use of this source code is governed by a MIT license
+// that can be found in the LICENSE file.
+
+package vm
+
+import (
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/gentee/gentee/core"
+)
+
+// lockItem is the lock which is held by the thread
+type lockItem struct {
+	Sync      *core.Sync
+	Exclusive bool
+}
+
+// syncAcquire takes the exclusive or shared lock. limit is the maximum count of the shared
+// locks. If timeout is less than zero then it waits without the time limit.
+func syncAcquire(rt *Runtime, sync *core.Sync, exclusive bool, limit, timeout int64) (bool, error) {
+	var timer *time.Timer
+	if timeout > 0 {
+		timer = time.NewTimer(time.Duration(timeout) * time.Millisecond)
+		defer timer.Stop()
+	}
+	waiting := false
+	defer func() {
+		if waiting {
+			sync.Mutex.Lock()
+			sync.Writers--
+			// the readers could wait for this writer
+			if sync.Wake != nil {
+				close(sync.Wake)
+				sync.Wake = nil
+			}
+			sync.Mutex.Unlock()
+		}
+	}()
+	for {
+		sync.Mutex.Lock()
+		if exclusive {
+			if !sync.Writer && sync.Readers == 0 {
+				sync.Writer = true
+				sync.Mutex.Unlock()
+				rt.Locks = append(rt.Locks, lockItem{Sync: sync, Exclusive: true})
+				return true, nil
+			}
+			if !waiting {
+				// the waiting writer stops new readers
+				sync.Writers++
+				waiting = true
+			}
+		} else if !sync.Writer && sync.Writers == 0 && sync.Readers < limit {
+			sync.Readers++
+			sync.Mutex.Unlock()
+			rt.Locks = append(rt.Locks, lockItem{Sync: sync})
+			return true, nil
+		}
+		if sync.Wake == nil {
+			sync.Wake = make(chan struct{})
+		}
+		wake := sync.Wake
+		sync.Mutex.Unlock()
+		if timeout == 0 {
+			return false, nil
+		}
+		cases := []reflect.SelectCase{{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(wake)}}
+		if timer != nil {
+			cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv,
+				Chan: reflect.ValueOf(timer.C)})
+		}
+		chosen, _, _, err := chanSelect(rt, cases, false)
+		if err != nil {
+			return false, err
+		}
+		if chosen == 1 {
+			return false, nil
+		}
+	}
+}
+
+// syncUnlock releases the lock and wakes up the waiting threads
+func syncUnlock(sync *core.Sync, exclusive bool) {
+	sync.Mutex.Lock()
+	if exclusive {
+		sync.Writer = false
+	} else {
+		sync.Readers--
+	}
+	if sync.Wake != nil {
+		close(sync.Wake)
+		sync.Wake = nil
+	}
+	sync.Mutex.Unlock()
+}
+
+// syncRelease releases the lock which has been taken by the current thread
+func syncRelease(rt *Runtime, sync *core.Sync, exclusive bool, name string) error {
+	for i := len(rt.Locks) - 1; i >= 0; i-- {
+		if rt.Locks[i].Sync == sync && rt.Locks[i].Exclusive == exclusive {
+			rt.Locks = append(rt.Locks[:i], rt.Locks[i+1:]...)
+			syncUnlock(sync, exclusive)
+			return nil
+		}
+	}
+	return newError(ErrNotLocked, name)
+}
+
+// releaseLocks releases all locks of the thread
+func (rt *Runtime) releaseLocks() {
+	for i := len(rt.Locks) - 1; i >= 0; i-- {
+		syncUnlock(rt.Locks[i].Sync, rt.Locks[i].Exclusive)
+	}
+	rt.Locks = rt.Locks[:0]
+}
+
+// semaphoreSize returns the count of slots of the semaphore
+func semaphoreSize(sync *core.Sync) int64 {
+	if sync.Size <= 0 {
+		return 1
+	}
+	return sync.Size
+}
+
+// LockºMutex locks the mutex
+func LockºMutex(rt *Runtime, mutex *core.Sync) error {
+	_, err := syncAcquire(rt, mutex, true, 0, -1)
+	return err
+}
+
+// TryLockºMutex tries to lock the mutex during timeout milliseconds
+func TryLockºMutex(rt *Runtime, mutex *core.Sync, timeout int64) (int64, error) {
+	ok, err := syncAcquire(rt, mutex, true, 0, timeout)
+	if ok {
+		return 1, err
+	}
+	return 0, err
+}
+
+// UnlockºMutex unlocks the mutex
+func UnlockºMutex(rt *Runtime, mutex *core.Sync) error {
+	return syncRelease(rt, mutex, true, `mutex`)
+}
+
+// RLockºRWMutex locks rwmutex for reading
+func RLockºRWMutex(rt *Runtime, mutex *core.Sync) error {
+	_, err := syncAcquire(rt, mutex, false, math.MaxInt64, -1)
+	return err
+}
+
+// TryRLockºRWMutex tries to lock rwmutex for reading during timeout milliseconds
+func TryRLockºRWMutex(rt *Runtime, mutex *core.Sync, timeout int64) (int64, error) {
+	ok, err := syncAcquire(rt, mutex, false, math.MaxInt64, timeout)
+	if ok {
+		return 1, err
+	}
+	return 0, err
+}
+
+// RUnlockºRWMutex unlocks rwmutex which has been locked for reading
+func RUnlockºRWMutex(rt *Runtime, mutex *core.Sync) error {
+	return syncRelease(rt, mutex, false, `rwmutex`)
+}
+
+// UnlockºRWMutex unlocks rwmutex which has been locked for writing
+func UnlockºRWMutex(rt *Runtime, mutex *core.Sync) error {
+	return syncRelease(rt, mutex, true, `rwmutex`)
+}
+
+// NewSemaphoreºInt creates a new semaphore
+func NewSemaphoreºInt(size int64) (*core.Sync, error) {
+	if size <= 0 {
+		return nil, newError(ErrInvalidParam)
+	}
+	return core.NewSemaphore(size), nil
+}
+
+// AcquireºSemaphore takes the slot of the semaphore
+func AcquireºSemaphore(rt *Runtime, sem *core.Sync) error {
+	_, err := syncAcquire(rt, sem, false, semaphoreSize(sem), -1)
+	return err
+}
+
+// TryAcquireºSemaphore tries to take the slot of the semaphore during timeout milliseconds
+func TryAcquireºSemaphore(rt *Runtime, sem *core.Sync, timeout int64) (int64, error) {
+	ok, err := syncAcquire(rt, sem, false, semaphoreSize(sem), timeout)
+	if ok {
+		return 1, err
+	}
+	return 0, err
+}
+
+// ReleaseºSemaphore releases the slot of the semaphore
+func ReleaseºSemaphore(rt *Runtime, sem *core.Sync) error {
+	return syncRelease(rt, sem, false, `semaphore`)
+}
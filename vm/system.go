@@ -33,7 +33,7 @@ func ClearCarriage(input string) string {
 // Command executes the command line
 func Command(rt *Runtime, cmdLine string) error {
 	if rt.Owner.Settings.IsPlayground {
-		return fmt.Errorf(ErrorText(ErrPlayRun))
+		return newError(ErrPlayRun)
 	}
 	cmd, err := splitCmdLine(cmdLine)
 	if err != nil {
@@ -50,7 +50,7 @@ func Command(rt *Runtime, cmdLine string) error {
 // CommandOutput executes the command line and returns the standard output
 func CommandOutput(rt *Runtime, cmdLine string) (string, error) {
 	if rt.Owner.Settings.IsPlayground {
-		return ``, fmt.Errorf(ErrorText(ErrPlayRun))
+		return ``, newError(ErrPlayRun)
 	}
 	cmd, err := splitCmdLine(cmdLine)
 	if err != nil {
@@ -71,7 +71,7 @@ func GetEnv(name string) string {
 // SetEnv assign the value to the environment variable
 func SetEnv(rt *Runtime, name string, value interface{}) (string, error) {
 	if rt.Owner.Settings.IsPlayground {
-		return ``, fmt.Errorf(ErrorText(ErrPlayEnv))
+		return ``, newError(ErrPlayEnv)
 	}
 	ret := fmt.Sprint(value)
 	err := os.Setenv(name, ret)
@@ -81,7 +81,7 @@ func SetEnv(rt *Runtime, name string, value interface{}) (string, error) {
 // SetEnvBool assign the value to the environment variable
 func SetEnvBool(rt *Runtime, name string, value int64) (string, error) {
 	if rt.Owner.Settings.IsPlayground {
-		return ``, fmt.Errorf(ErrorText(ErrPlayEnv))
+		return ``, newError(ErrPlayEnv)
 	}
 	ret := strºBool(value)
 	err := os.Setenv(name, ret)
@@ -91,7 +91,7 @@ func SetEnvBool(rt *Runtime, name string, value int64) (string, error) {
 // UnsetEnv unsets the environment variable
 func UnsetEnv(rt *Runtime, name string) error {
 	if rt.Owner.Settings.IsPlayground {
-		return fmt.Errorf(ErrorText(ErrPlayEnv))
+		return newError(ErrPlayEnv)
 	}
 	return os.Unsetenv(name)
 }
@@ -133,13 +133,13 @@ func splitCmdLine(cmdLine string) (*exec.Cmd, error) {
 		}
 	}
 	if quote != 0 {
-		return nil, fmt.Errorf(ErrorText(ErrQuoteCommand))
+		return nil, newError(ErrQuoteCommand)
 	}
 	if offset < len(input) {
 		cmds = append(cmds, string(input[offset:]))
 	}
 	if len(cmds) == 0 {
-		return nil, fmt.Errorf(ErrorText(ErrEmptyCommand))
+		return nil, newError(ErrEmptyCommand)
 	}
 	if cmds[0] == `echo` && runtime.GOOS == "windows" {
 		cmds[0] = `cmd.exe`
@@ -17,7 +17,7 @@ const (
 	ThWork
 	// ThPaused means that the thread has been suspended
 	ThPaused
-	// ThWait means that the thread is waiting for the end of another thread
+	// ThWait means that the thread is waiting for the end of another thread or for the channel
 	ThWait
 	// ThFinished means that the thread finished
 	ThFinished
@@ -38,53 +38,97 @@ const (
 
 // Thread contains information about a thread
 type Thread struct {
-	Status byte
-	Sleep  int64
-	Chan   chan int
-	Notify []int64 // who waits the end
+	ID      int64
+	Status  byte
+	Running bool // the thread is counted in VM.Running
+	Sleep   int64
+	Chan    chan int
+	Notify  []int64 // who waits the end
 }
 
 func (rt *Runtime) setStatus(status byte) {
 	rt.Owner.ThreadMutex.Lock()
-	rt.Thread.Status = status
+	rt.Owner.switchStatus(rt.Thread, status)
 	rt.Owner.ThreadMutex.Unlock()
 }
 
-func (vm *VM) newThread(status byte) *Runtime {
-	if len(vm.Runtimes) > 0 && vm.Runtimes[0].Thread.Status >= ThFinished {
+// switchStatus changes the status of the thread. The waiting thread is not counted
+// in the running threads. ThreadMutex must be locked.
+func (vm *VM) switchStatus(thread *Thread, status byte) {
+	thread.Status = status
+	switch status {
+	case ThWait:
+		vm.releaseSlot(thread)
+	case ThWork:
+		if thread.ID != 0 && !thread.Running {
+			thread.Running = true
+			vm.Running++
+		}
+	}
+}
+
+// releaseSlot removes the thread from the running threads and starts the queued threads.
+// ThreadMutex must be locked.
+func (vm *VM) releaseSlot(thread *Thread) {
+	if !thread.Running {
+		return
+	}
+	thread.Running = false
+	vm.Running--
+	for len(vm.Queue) > 0 && (vm.Settings.MaxThreads <= 0 ||
+		vm.Running < vm.Settings.MaxThreads) {
+		next := vm.Queue[0]
+		vm.Queue = vm.Queue[1:]
+		next()
+	}
+}
+
+func (vm *VM) newThread(status byte) *Thread {
+	if len(vm.Threads) > 0 && vm.Threads[0].Status >= ThFinished {
 		return nil
 	}
-	rt := &Runtime{
-		Owner: vm,
-		Thread: Thread{
-			Status: status,
-			Chan:   make(chan int, 8),
-		},
+	thread := &Thread{
+		Status: status,
+		Chan:   make(chan int, 8),
 	}
 	vm.ThreadMutex.Lock()
 	defer vm.ThreadMutex.Unlock()
-	vm.Runtimes = append(vm.Runtimes, rt)
-	rt.ThreadID = int64(len(vm.Runtimes) - 1)
+	vm.Threads = append(vm.Threads, thread)
+	thread.ID = int64(len(vm.Threads) - 1)
 	if status == ThQueue {
 		vm.Count++
 	}
-	return rt
+	return thread
+}
+
+// newRuntime creates the runtime of the thread. The queued threads get it at the start.
+func (vm *VM) newRuntime(thread *Thread) *Runtime {
+	return &Runtime{
+		Owner:    vm,
+		Thread:   thread,
+		ThreadID: thread.ID,
+	}
 }
 
 func (vm *VM) closeAll() {
 	vm.ThreadMutex.Lock()
-	for i := range vm.Runtimes {
-		if vm.Runtimes[i].Thread.Status < ThFinished {
-			vm.Runtimes[i].Thread.Chan <- ThCmdClose
+	for _, thread := range vm.Threads {
+		if thread.Status < ThFinished {
+			thread.Chan <- ThCmdClose
 		}
 	}
 	vm.ThreadMutex.Unlock()
 }
 
-// GoThread executes a new thread
-func (rt *Runtime) GoThread(offset int64, pars []int32, top *Call) int64 {
+// GoThread executes a new thread. If future is not nil then it gets the result of the thread
+// and the error of the thread is not sent to the main thread.
+func (rt *Runtime) GoThread(offset int64, pars []int32, top *Call, future *core.Future) int64 {
 	thread := rt.Owner.newThread(ThQueue)
 	if thread == nil {
+		if future != nil {
+			future.Err = newError(ErrThreadClosed)
+			close(future.Done)
+		}
 		return -1
 	}
 	optional := make([]OptValue, len(pars))
@@ -110,31 +154,69 @@ func (rt *Runtime) GoThread(offset int64, pars []int32, top *Call) int64 {
 			Value: value,
 		}
 	}
-	thread.Optional = &optional
+	if future != nil {
+		future.ThreadID = thread.ID
+	}
+	rt.Owner.startThread(thread, func(trt *Runtime) (interface{}, error) {
+		trt.Optional = &optional
+		return trt.Run(offset)
+	}, future)
+	return thread.ID
+}
 
-	go func() {
-		thread.Thread.Status = ThWork
+// startThread runs the thread in a new goroutine. If the count of running threads has
+// reached Settings.MaxThreads then the thread stays in the queue until another thread finishes
+// or waits. The runtime of the thread is created when it starts.
+func (vm *VM) startThread(thread *Thread, run func(*Runtime) (interface{}, error),
+	future *core.Future) {
+	start := func() {
+		thread.Running = true
+		vm.Running++
+		go func() {
+			vm.ThreadMutex.Lock()
+			if thread.Status == ThQueue {
+				thread.Status = ThWork
+			}
+			vm.ThreadMutex.Unlock()
 
-		_, err := thread.Run(offset)
-		rt.Owner.ThreadMutex.Lock()
-		if err != nil {
-			if thread.Thread.Status != ThClosed {
-				thread.Thread.Status = ThError
-				rt.Owner.ChError <- err
+			rt := vm.newRuntime(thread)
+			result, err := run(rt)
+			rt.releaseLocks()
+			releaseMemory(rt)
+			vm.ThreadMutex.Lock()
+			if err != nil {
+				if thread.Status != ThClosed {
+					thread.Status = ThError
+					if future == nil {
+						vm.ChError <- err
+					}
+				}
+			} else {
+				thread.Status = ThFinished
 			}
-		} else {
-			thread.Thread.Status = ThFinished
-		}
-		close(thread.Thread.Chan)
-		for _, nfyid := range thread.Thread.Notify {
-			if rt.Owner.Runtimes[nfyid].Thread.Status == ThWait {
-				rt.Owner.Runtimes[nfyid].Thread.Chan <- ThCmdContinue
+			if future != nil {
+				future.Result = futureResult(result)
+				future.Err = err
+				close(future.Done)
 			}
-		}
-		rt.Owner.ThreadMutex.Unlock()
-		rt.Owner.ChCount <- 1
-	}()
-	return thread.ThreadID
+			close(thread.Chan)
+			for _, nfyid := range thread.Notify {
+				if vm.Threads[nfyid].Status == ThWait {
+					vm.Threads[nfyid].Chan <- ThCmdContinue
+				}
+			}
+			vm.releaseSlot(thread)
+			vm.ThreadMutex.Unlock()
+			vm.ChCount <- 1
+		}()
+	}
+	vm.ThreadMutex.Lock()
+	if vm.Settings.MaxThreads > 0 && vm.Running >= vm.Settings.MaxThreads {
+		vm.Queue = append(vm.Queue, start)
+	} else {
+		start()
+	}
+	vm.ThreadMutex.Unlock()
 }
 
 // Lock locks vm mutex
@@ -157,8 +239,8 @@ type threadFunc func(vm *VM)
 func changeStatus(rt *Runtime, threadID int64, todo threadFunc) error {
 	rt.Owner.ThreadMutex.Lock()
 	defer rt.Owner.ThreadMutex.Unlock()
-	if threadID <= 0 || int64(len(rt.Owner.Runtimes)) <= threadID {
-		return fmt.Errorf(ErrorText(ErrThreadIndex))
+	if threadID <= 0 || int64(len(rt.Owner.Threads)) <= threadID {
+		return newError(ErrThreadIndex)
 	}
 	todo(rt.Owner)
 	return nil
@@ -167,8 +249,8 @@ func changeStatus(rt *Runtime, threadID int64, todo threadFunc) error {
 // resumeºThread resumes the thread
 func resumeºThread(rt *Runtime, threadID int64) error {
 	return changeStatus(rt, threadID, func(vm *VM) {
-		if vm.Runtimes[threadID].Thread.Status == ThPaused {
-			vm.Runtimes[threadID].Thread.Chan <- ThCmdResume
+		if vm.Threads[threadID].Status == ThPaused {
+			vm.Threads[threadID].Chan <- ThCmdResume
 		}
 	})
 }
@@ -176,8 +258,8 @@ func resumeºThread(rt *Runtime, threadID int64) error {
 // suspendºThread suspends the thread
 func suspendºThread(rt *Runtime, threadID int64) error {
 	return changeStatus(rt, threadID, func(vm *VM) {
-		if vm.Runtimes[threadID].Thread.Status < ThFinished {
-			vm.Runtimes[threadID].Thread.Status = ThPaused
+		if vm.Threads[threadID].Status < ThFinished {
+			vm.Threads[threadID].Status = ThPaused
 		}
 	})
 }
@@ -185,8 +267,8 @@ func suspendºThread(rt *Runtime, threadID int64) error {
 // terminateºThread closes the thread
 func terminateºThread(rt *Runtime, threadID int64) error {
 	return changeStatus(rt, threadID, func(vm *VM) {
-		if vm.Runtimes[threadID].Thread.Status < ThFinished {
-			vm.Runtimes[threadID].Thread.Chan <- ThCmdClose
+		if vm.Threads[threadID].Status < ThFinished {
+			vm.Threads[threadID].Chan <- ThCmdClose
 		}
 	})
 }
@@ -194,10 +276,10 @@ func terminateºThread(rt *Runtime, threadID int64) error {
 // waitºThread waits for the finish of the thread
 func waitºThread(rt *Runtime, threadID int64) error {
 	return changeStatus(rt, threadID, func(vm *VM) {
-		if vm.Runtimes[threadID].Thread.Status < ThFinished {
-			vm.Runtimes[threadID].Thread.Notify = append(vm.Runtimes[threadID].Thread.Notify,
+		if vm.Threads[threadID].Status < ThFinished {
+			vm.Threads[threadID].Notify = append(vm.Threads[threadID].Notify,
 				rt.ThreadID)
-			rt.Thread.Status = ThWait
+			vm.switchStatus(rt.Thread, ThWait)
 		}
 	})
 }
@@ -205,7 +287,7 @@ func waitºThread(rt *Runtime, threadID int64) error {
 // WaitAll blocks until the WaitGroup counter is zero
 func WaitAll(rt *Runtime) error {
 	if rt.ThreadID != 0 {
-		return fmt.Errorf(ErrorText(ErrMainThread), `WaitAll`)
+		return newError(ErrMainThread, `WaitAll`)
 	}
 	//rt.Owner.WaitGroup.Wait()
 	if rt.Owner.WaitCount > 0 {
@@ -217,7 +299,7 @@ func WaitAll(rt *Runtime) error {
 // WaitDone decrements the WaitGroup counter by one
 func WaitDone(rt *Runtime) error {
 	if rt.ThreadID == 0 {
-		return fmt.Errorf(ErrorText(ErrThread), `WaitDone`)
+		return newError(ErrThread, `WaitDone`)
 	}
 	//rt.Owner.WaitGroup.Done()
 	rt.Owner.ChWait <- 1
@@ -227,10 +309,10 @@ func WaitDone(rt *Runtime) error {
 // WaitGroup changes WaitGroup counter
 func WaitGroup(rt *Runtime, count int64) error {
 	if rt.ThreadID != 0 {
-		return fmt.Errorf(ErrorText(ErrMainThread), `WaitGroup`)
+		return newError(ErrMainThread, `WaitGroup`)
 	}
 	if count < 0 {
-		return fmt.Errorf(ErrorText(ErrInvalidParam))
+		return newError(ErrInvalidParam)
 	}
 	//rt.Owner.WaitGroup.Add(int(count))
 	rt.Owner.WaitCount = count
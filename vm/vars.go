@@ -12,7 +12,8 @@ import (
 
 // Fn is used for custom func types
 type Fn struct {
-	Func int32 // id of function
+	Func     int32         // id of function
+	Captures []interface{} // captured values of the closure
 }
 
 // CopyVar copies one object to another one
@@ -26,6 +27,7 @@ func CopyVar(rt *Runtime, ptr *interface{}, value interface{}) {
 			pfn = (*ptr).(*Fn)
 		}
 		pfn.Func = vItem.Func
+		pfn.Captures = vItem.Captures
 		*ptr = pfn
 	case *core.File:
 		var pfile *core.File
@@ -43,6 +45,7 @@ func CopyVar(rt *Runtime, ptr *interface{}, value interface{}) {
 			pstruct = NewStruct(rt, vItem.Type)
 		} else {
 			pstruct = (*ptr).(*Struct)
+			pstruct.Type = vItem.Type
 		}
 		pstruct.Values = make([]interface{}, len(vItem.Values))
 		for i, v := range vItem.Values {
@@ -150,6 +153,12 @@ func newValue(rt *Runtime, vtype int) interface{} {
 		return core.NewSet()
 	case core.TYPEOBJ:
 		return core.NewObj()
+	case core.TYPECHAN:
+		return core.NewChan(0)
+	case core.TYPEFUTURE:
+		return &core.Future{}
+	case core.TYPESYNC:
+		return &core.Sync{}
 	default:
 		if vtype >= core.TYPESTRUCT {
 			return NewStruct(rt, &rt.Owner.Exec.Structs[(vtype-core.TYPESTRUCT)>>8])
@@ -5,7 +5,6 @@
 package vm
 
 import (
-	"fmt"
 	"os"
 	"sync"
 
@@ -15,7 +14,13 @@ import (
 //go:generate go run generate/generate.go
 
 const (
-	STACKSIZE = 256
+	// STACKSIZE is the initial size of the typed stacks
+	STACKSIZE = 32
+	// STACKMAX is the maximum size of the typed stacks
+	STACKMAX = uint32(0x100000)
+	// stackReserve is the count of free items of the typed stacks before the commands
+	// which push values
+	stackReserve = 16
 	// CYCLE is the limit of loops
 	CYCLE = uint64(16000000)
 	// DEPTH is the maximum size of blocks stack
@@ -37,10 +42,18 @@ type Settings struct {
 	Input          []byte   // stdin
 	Cycle          uint64   // limit of loops
 	Depth          uint32   // limit of blocks stack
+	StackSize      uint32   // limit of the size of each typed stack
 	SysChan        chan int // system chan
+	MaxThreads     int64    // limit of running threads except waiting ones, others stay in the queue
+	MemoryLimit    int64    // approximate limit of the memory used by the variables in bytes
+	Policy         *Policy  // capabilities of the script, nil means that everything is allowed
+	DryRun         bool     // mutating functions are not executed and are reported as actions
 	IsPlayground   bool
 	Playground     Playground
 	ProgressHandle ProgressFunc
+	ActionHandle   ActionFunc // gets the actions in DryRun mode, by default they are printed
+	AuditHandle    AuditFunc  // gets the events of the side-effecting operations
+	FS             FileSystem // file system of the file functions, nil means the OS file system
 }
 
 type Const struct {
@@ -53,14 +66,20 @@ type VM struct {
 	Settings    Settings
 	Exec        *core.Exec
 	Consts      map[int32]Const
-	Runtimes    []*Runtime
+	Globals     map[int32]interface{} // global variables
+	Threads     []*Thread
 	CtxMutex    sync.RWMutex
+	GlobalMutex sync.Mutex
 	ThreadMutex sync.RWMutex
 	LockMutex   sync.Mutex
 	WaitGroup   sync.WaitGroup
 	Unique      *sync.Map // now for progress
 	Context     map[string]string
-	Count       int64 // count of active threads
+	Count       int64    // count of active threads
+	Running     int64    // count of running threads
+	Memory      int64    // approximate size of the used memory
+	GlobalSize  int64    // size of the global variables at the latest recount
+	Queue       []func() // threads waiting for the start
 	WaitCount   int64
 	Stopped     bool
 	ChCount     chan int64
@@ -80,16 +99,22 @@ type Runtime struct {
 	Owner    *VM
 	ParCount int32
 	Calls    []Call
-	Thread   Thread
+	Thread   *Thread
 	ThreadID int64
 	Optional *[]OptValue
+	Defers   []Defer     // deferred calls and finally blocks
 	Data     *core.Obj   // gentee embedded object
 	Custom   interface{} // embedded structure
+	Received interface{} // the value received from the channel by select or for
+	Locks    []lockItem  // mutexes and semaphores which are held by the thread
+	memory   int64       // the part of VM.Memory which has been counted by the thread
+	top      *Call       // the top of the stacks
+	isGlobal bool        // GlobalMutex is locked by the current command
 	// These are stacks for different types
-	SInt   [STACKSIZE]int64       // int, char, bool
-	SFloat [STACKSIZE]float64     // float
-	SStr   [STACKSIZE]string      // str
-	SAny   [STACKSIZE]interface{} // all other types
+	SInt   []int64       // int, char, bool
+	SFloat []float64     // float
+	SStr   []string      // str
+	SAny   []interface{} // all other types
 }
 
 // Call stores stack of blocks
@@ -111,22 +136,32 @@ type Call struct {
 	Try      int32 // shift for try
 	Recover  int32 // shift for recover
 	Retry    int32 // shift for retry
+	Err      error // the error is thrown again after the deferred call
+}
+
+// Defer is a deferred call or finally block
+type Defer struct {
+	Level  int   // it is called when the block with this index is left
+	Fn     *Fn   // deferred function
+	Offset int64 // the code of finally block if Fn is nil
 }
 
 func (vm *VM) runConsts(offset int64) (interface{}, error) {
-	rt := &Runtime{
-		Owner: vm,
-	}
-	vm.Runtimes = append(vm.Runtimes, rt)
+	rt := vm.newRuntime(&Thread{Status: ThWork})
+	vm.Threads = append(vm.Threads, rt.Thread)
+	defer releaseMemory(rt)
 	return rt.Run(offset)
 }
 
 func Run(exec *core.Exec, settings Settings) (interface{}, error) {
 	if exec == nil {
-		return nil, fmt.Errorf(ErrorText(ErrNotRun))
+		return nil, newError(ErrNotRun)
 	}
 	if exec.CRCStdlib != CRCStdlib || (exec.CRCCustom != 0 && exec.CRCCustom != CRCCustom) {
-		return nil, fmt.Errorf(ErrorText(ErrCRC))
+		return nil, newError(ErrCRC)
+	}
+	if settings.FS == nil {
+		settings.FS = OSFS{}
 	}
 	if settings.IsPlayground {
 		if err := InitPlayground(&settings); err != nil {
@@ -137,8 +172,9 @@ func Run(exec *core.Exec, settings Settings) (interface{}, error) {
 		Settings: settings,
 		Exec:     exec,
 		Consts:   make(map[int32]Const),
+		Globals:  make(map[int32]interface{}),
 		Context:  make(map[string]string),
-		Runtimes: make([]*Runtime, 0, 32),
+		Threads:  make([]*Thread, 0, 32),
 		ChCount:  make(chan int64, 16),
 		ChError:  make(chan error, 16),
 		ChWait:   make(chan int64, 16),
@@ -155,6 +191,9 @@ func Run(exec *core.Exec, settings Settings) (interface{}, error) {
 	if vm.Settings.Depth == 0 {
 		vm.Settings.Depth = DEPTH
 	}
+	if vm.Settings.StackSize == 0 {
+		vm.Settings.StackSize = STACKMAX
+	}
 	var (
 		pStdin, pStdout, pStderr *os.File
 	)
@@ -218,8 +257,13 @@ func Run(exec *core.Exec, settings Settings) (interface{}, error) {
 		}
 		vm.Consts[id] = Const{Type: constType, Value: val}
 	}
-	vm.Runtimes = vm.Runtimes[:0]
-	rt := vm.newThread(ThWork)
+	for _, id := range vm.Exec.Globals {
+		if _, err := vm.runConsts(int64(vm.Exec.Funcs[id])); err != nil {
+			return nil, err
+		}
+	}
+	vm.Threads = vm.Threads[:0]
+	rt := vm.newRuntime(vm.newThread(ThWork))
 	go func() {
 		x := int64(1)
 		for x != 0 {
@@ -246,7 +290,7 @@ func Run(exec *core.Exec, settings Settings) (interface{}, error) {
 				case SysResume:
 					vm.Stopped = false
 				case SysTerminate:
-					rt.Owner.ChError <- fmt.Errorf(ErrorText(ErrTerminated))
+					rt.Owner.ChError <- newError(ErrTerminated)
 					vm.Stopped = false // if it has been suspended
 				}
 			}
@@ -274,7 +318,7 @@ func Run(exec *core.Exec, settings Settings) (interface{}, error) {
 		errResult = nil
 	}
 	vm.ChCount <- 0
-	close(vm.Runtimes[0].Thread.Chan)
+	close(vm.Threads[0].Chan)
 	close(vm.ChCount)
 	close(vm.ChError)
 	if pStdin != nil {